@@ -0,0 +1,98 @@
+package client
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// AdminTokenHeader - HTTP header carrying admin token, as `Bearer <token>`
+const AdminTokenHeader = "Authorization"
+
+// AdminActorHeader - HTTP header carrying name of person, performing admin
+// action, to be recorded in audit log
+const AdminActorHeader = "X-Admin-Actor"
+
+// TxRow - Status of tx, as persisted in `root_chain`/ `child_chain` table
+type TxRow struct {
+	Chain   string `json:"chain"`
+	TxHash  string `json:"txHash"`
+	Code    int    `json:"code"`
+	Message string `json:"msg"`
+}
+
+// TxStatusChange - One change in persisted status of tx, recorded in `tx_status_history`
+type TxStatusChange struct {
+	Chain     string    `json:"chain"`
+	TxHash    string    `json:"txHash"`
+	Code      int       `json:"code"`
+	Message   string    `json:"msg"`
+	RequestID string    `json:"requestId,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// AdminTxResponse - Response of `GET /admin/tx/:hash`, where row is nil, when
+// tx is not persisted in that chain's table
+type AdminTxResponse struct {
+	RootChain  *TxRow            `json:"rootChain"`
+	ChildChain *TxRow            `json:"childChain"`
+	History    []*TxStatusChange `json:"history"`
+}
+
+// RecheckRequest - Payload of `POST /admin/tx/:hash/recheck`, where status of tx is
+// found out once again using status checking function behind `Endpoint` e.g. `/v1/deposit`
+//
+// `BurnTxHash` is required for `/v1/plasma-confirm`, where tx hash is of confirm withdraw tx
+type RecheckRequest struct {
+	Endpoint   string      `json:"endpoint" binding:"required"`
+	BurnTxHash common.Hash `json:"burnTxHash"`
+}
+
+// RecheckResponse - Response of `POST /admin/tx/:hash/recheck`, carrying persisted
+// rows, before & after status was found out once again
+type RecheckResponse struct {
+	Before *AdminTxResponse  `json:"before"`
+	Status *TransactionState `json:"status"`
+	After  *AdminTxResponse  `json:"after"`
+}
+
+// DeleteResponse - Response of `DELETE /admin/tx/:hash`
+type DeleteResponse struct {
+	Deleted int64 `json:"deleted"`
+}
+
+// BulkRecheckResponse - Response of `POST /admin/recheck`, where rows are rechecked
+// in background, after responding
+type BulkRecheckResponse struct {
+	Count int `json:"count"`
+}
+
+// AuditEntry - One admin action, recorded in `admin_audit`
+type AuditEntry struct {
+	ID        uint      `json:"id"`
+	Actor     string    `json:"actor"`
+	Action    string    `json:"action"`
+	Target    string    `json:"target"`
+	Details   string    `json:"details"`
+	RequestID string    `json:"requestId"`
+	IP        string    `json:"ip"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// StuckTx - Persisted status of tx, held for longer than threshold of its status
+// code, where age & threshold are in seconds
+type StuckTx struct {
+	Chain     string    `json:"chain"`
+	TxHash    string    `json:"txHash"`
+	Code      int       `json:"code"`
+	Message   string    `json:"msg"`
+	Since     time.Time `json:"since"`
+	Age       int64     `json:"ageSeconds"`
+	Threshold int64     `json:"thresholdSeconds"`
+}
+
+// StuckResponse - Response of `GET /v2/admin/stuck`
+type StuckResponse struct {
+	Count        int        `json:"count"`
+	Transactions []*StuckTx `json:"txs"`
+}
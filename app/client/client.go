@@ -0,0 +1,265 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Paths of bulk status checking endpoints exposed by bridge API
+const (
+	ApprovalEndpoint      = "/v1/approval"
+	DepositEndpoint       = "/v1/deposit"
+	POSBurnEndpoint       = "/v1/pos-burn"
+	POSExitEndpoint       = "/v1/pos-exit"
+	PlasmaBurnEndpoint    = "/v1/plasma-burn"
+	PlasmaConfirmEndpoint = "/v1/plasma-confirm"
+	PlasmaExitEndpoint    = "/v1/plasma-exit"
+	WithdrawEndpoint      = "/v2/withdraw"
+	TokensEndpoint        = "/v2/tokens"
+)
+
+// APIError - Returned when bridge API responds with non-200 status code
+type APIError struct {
+	StatusCode int
+	Message    string
+}
+
+func (a *APIError) Error() string {
+	return fmt.Sprintf("bridge api responded with %d : %s", a.StatusCode, a.Message)
+}
+
+// Client - Typed client of bridge API
+//
+// Requests failing due to network errors or 5xx/429 responses are retried
+// `Retries` times, waiting `RetryInterval` in between
+//
+// `APIKey`, if set, is sent in `X-API-Key` header
+//
+// This package depends on nothing but standard library & go-ethereum, so that
+// other services can make use of it. Request ID & trace context can be forwarded
+// by setting transport of `HTTPClient`
+type Client struct {
+	URL           string
+	APIKey        string
+	HTTPClient    *http.Client
+	Retries       int
+	RetryInterval time.Duration
+}
+
+// New - Creates client talking to bridge API running at given base URL
+func New(url string) *Client {
+	return &Client{
+		URL:           strings.TrimSuffix(url, "/"),
+		HTTPClient:    &http.Client{Timeout: time.Second * time.Duration(30)},
+		Retries:       3,
+		RetryInterval: time.Second,
+	}
+}
+
+// Whether request is worth retrying, given response status code
+func retryable(statusCode int) bool {
+	return statusCode >= 500 || statusCode == http.StatusTooManyRequests
+}
+
+// Sends JSON encoded payload to given path, decoding response body into `out`,
+// while retrying on transient failures
+func (c *Client) post(ctx context.Context, path string, payload interface{}, out interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	return c.do(ctx, http.MethodPost, path, body, out)
+}
+
+// Sends GET request to given path, decoding response body into `out`, while
+// retrying on transient failures
+func (c *Client) get(ctx context.Context, path string, out interface{}) error {
+	return c.do(ctx, http.MethodGet, path, nil, out)
+}
+
+// Sends request with given body, if any, to given path, decoding response body
+// into `out`, while retrying on transient failures
+func (c *Client) do(ctx context.Context, method string, path string, body []byte, out interface{}) error {
+	var lastErr error
+
+	for attempt := 0; attempt <= c.Retries; attempt++ {
+
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(c.RetryInterval):
+			}
+		}
+
+		req, err := http.NewRequestWithContext(ctx, method, c.URL+path, bytes.NewReader(body))
+		if err != nil {
+			return err
+		}
+		if body != nil {
+			req.Header.Set("Content-Type", "application/json")
+		}
+
+		if c.APIKey != "" {
+			req.Header.Set("X-API-Key", c.APIKey)
+		}
+
+		resp, err := c.HTTPClient.Do(req)
+		if err != nil {
+			// Caller has given up, no point in retrying
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+
+			lastErr = err
+			continue
+		}
+
+		data, err := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			lastErr = err
+			continue
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			var _err ErrorResponse
+			json.Unmarshal(data, &_err)
+
+			lastErr = &APIError{StatusCode: resp.StatusCode, Message: _err.Message}
+			if retryable(resp.StatusCode) {
+				continue
+			}
+
+			return lastErr
+		}
+
+		return json.Unmarshal(data, out)
+
+	}
+
+	return lastErr
+}
+
+// Sends tx hashes to one of `/v1/*` endpoints, responding with status keyed by tx hash
+func (c *Client) statuses(ctx context.Context, path string, hashes []common.Hash) (Statuses, error) {
+	var resp Statuses
+
+	if err := c.post(ctx, path, &BulkPayload{TransactionHashes: hashes}, &resp); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// Approval - Status of `Token.approve(...)` tx(s) on root chain
+func (c *Client) Approval(ctx context.Context, hashes []common.Hash) (*ApprovalResponse, error) {
+	var resp ApprovalResponse
+
+	if err := c.post(ctx, ApprovalEndpoint, &BulkPayload{TransactionHashes: hashes}, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// Deposit - Status of `depositFor`/ `depositEtherFor` tx(s) on root chain
+func (c *Client) Deposit(ctx context.Context, hashes []common.Hash) (*DepositResponse, error) {
+	var resp DepositResponse
+
+	if err := c.post(ctx, DepositEndpoint, &BulkPayload{TransactionHashes: hashes}, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// POSBurn - Status of POS bridge burn tx(s) on child chain, upto exit
+func (c *Client) POSBurn(ctx context.Context, hashes []common.Hash) (Statuses, error) {
+	return c.statuses(ctx, POSBurnEndpoint, hashes)
+}
+
+// POSExit - Status of `RootChain*.exit(...)` tx(s) on root chain
+func (c *Client) POSExit(ctx context.Context, hashes []common.Hash) (Statuses, error) {
+	return c.statuses(ctx, POSExitEndpoint, hashes)
+}
+
+// PlasmaBurn - Status of Plasma bridge burn tx(s) on child chain, upto checkpoint
+func (c *Client) PlasmaBurn(ctx context.Context, hashes []common.Hash) (Statuses, error) {
+	return c.statuses(ctx, PlasmaBurnEndpoint, hashes)
+}
+
+// PlasmaConfirm - Status of Plasma withdraw, given burn & confirm withdraw tx hash pairs,
+// keyed by confirm withdraw tx hash
+func (c *Client) PlasmaConfirm(ctx context.Context, pairs []CheckExitable) (Statuses, error) {
+	var resp Statuses
+
+	if err := c.post(ctx, PlasmaConfirmEndpoint, &PlasmaExitBulkPayload{TransactionHashes: pairs}, &resp); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// PlasmaExit - Status of `WithdrawManager.processExits(...)` tx(s) on root chain
+func (c *Client) PlasmaExit(ctx context.Context, hashes []common.Hash) (Statuses, error) {
+	return c.statuses(ctx, PlasmaExitEndpoint, hashes)
+}
+
+// Withdraw - Status of Plasma/ POS withdraw(s), keyed by burn tx hash
+func (c *Client) Withdraw(ctx context.Context, txs []*WithdrawTransaction) (*WithdrawResponse, error) {
+	var resp WithdrawResponse
+
+	if err := c.post(ctx, WithdrawEndpoint, &WithdrawTransactions{Transactions: txs}, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// Tokens - Tokens mapped between root & child chain, optionally filtered by
+// bridge & type, when non-empty
+func (c *Client) Tokens(ctx context.Context, bridge string, _type string) ([]*Token, error) {
+	query := url.Values{}
+	if bridge != "" {
+		query.Set("bridge", bridge)
+	}
+	if _type != "" {
+		query.Set("type", _type)
+	}
+
+	path := TokensEndpoint
+	if len(query) != 0 {
+		path = strings.Join([]string{path, query.Encode()}, "?")
+	}
+
+	var resp TokensResponse
+
+	if err := c.get(ctx, path, &resp); err != nil {
+		return nil, err
+	}
+
+	return resp.Tokens, nil
+}
+
+// Token - Mappings of token, given its address on either of chains, where
+// `APIError` with status code 404 is returned, when it's not mapped
+func (c *Client) Token(ctx context.Context, address common.Address) ([]*TokenMapping, error) {
+	var resp TokenResponse
+
+	if err := c.get(ctx, strings.Join([]string{TokensEndpoint, address.Hex()}, "/"), &resp); err != nil {
+		return nil, err
+	}
+
+	return resp.Mappings, nil
+}
@@ -0,0 +1,378 @@
+package client
+
+import (
+	"encoding/json"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// BulkPayload - An array of tx hashes can be sent with request body, which
+// will eventually return JSON response where keys will be txHashes & values
+// will be their respective status
+type BulkPayload struct {
+	TransactionHashes []common.Hash `json:"txHashes" binding:"required"`
+}
+
+// Given txHash, checks whether that's present in this slice or not
+func exists(buffer []common.Hash, txHash common.Hash) bool {
+	for _, v := range buffer {
+		if v == txHash {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Unique - Returns a slice of non-duplicate txHashes
+//
+// This function is implemented so that we don't end up
+// wasting resources in computing current status of tx ( using txHash )
+// if user tries to fool service, by sending a JSON array of same txHashes
+// for `n` times
+func (b *BulkPayload) Unique() []common.Hash {
+	buffer := make([]common.Hash, 0)
+
+	for _, v := range b.TransactionHashes {
+		if !exists(buffer, v) {
+			buffer = append(buffer, v)
+		}
+	}
+
+	return buffer
+}
+
+// PlasmaExitBulkPayload - JSON encoded payload to be feeded to /v1/plasma-exit endpoint
+// to check status of plasma withdraw
+type PlasmaExitBulkPayload struct {
+	TransactionHashes []CheckExitable `json:"txHashes" binding:"required"`
+}
+
+// Unique - Returns a slice of non-duplicate pairs of burn & confirm
+// withdraw tx hashes
+func (p *PlasmaExitBulkPayload) Unique() []CheckExitable {
+	buffer := make([]CheckExitable, 0)
+
+	for _, v := range p.TransactionHashes {
+		duplicate := false
+		for _, w := range buffer {
+			if v == w {
+				duplicate = true
+				break
+			}
+		}
+
+		if !duplicate {
+			buffer = append(buffer, v)
+		}
+	}
+
+	return buffer
+}
+
+// POSExited - Payload to be sent when querying `pos-exit-checker`
+// for checking exit status of transaction
+type POSExited struct {
+	TransactionHash string `json:"txHash"`
+}
+
+// JSON - Converts to JSON encoded byte array, nil if it can't be
+func (p *POSExited) JSON() []byte {
+	data, err := json.Marshal(p)
+	if err != nil {
+		return nil
+	}
+
+	return data
+}
+
+// TransactionState - Represents current state of any transaction, though note
+// that transaction hash is not being kept inside this structure
+//
+// When some dependency of bridge API couldn't be reached, it falls back to
+// last known/ guessed state, in that case `Degraded` is set & `Source` holds
+// name of dependency which failed, while `Reason` explains what went wrong
+//
+// Tokens moved by deposit/ burn tx are carried in `Tokens`, when known, while
+// accounts involved in exit of withdraw are carried in `Exit`, once it's exited
+type TransactionState struct {
+	Code     int              `json:"code"`
+	Message  string           `json:"msg"`
+	Degraded bool             `json:"degraded"`
+	Source   string           `json:"source,omitempty"`
+	Reason   string           `json:"reason,omitempty"`
+	Tokens   []*TokenTransfer `json:"tokens,omitempty"`
+	Exit     *ExitParties     `json:"exit,omitempty"`
+	// How deposit landed on child chain, set only when it's just
+	// been found out from index of `state-id-manager`
+	StateSync *CommittedState `json:"stateSync,omitempty"`
+}
+
+// ExitParties - Accounts involved in exit of withdraw, where `Burner` burnt tokens on
+// child chain, `Submitter` sent exit tx on root chain & `Recipient` received funds
+//
+// Anyone can call `RootChainManager.exit(...)` for POS withdraw, while Plasma exit NFT
+// can be transferred, so funds may end up with other account than burner, which is
+// flagged with `RecipientMismatch`. `ThirdParty` is set when exit tx was submitted by
+// other account than burner. `Recipient` is not set, when it can't be found from logs
+// of exit tx
+type ExitParties struct {
+	Burner            common.Address  `json:"burner"`
+	Submitter         common.Address  `json:"submitter"`
+	Recipient         *common.Address `json:"recipient,omitempty"`
+	ThirdParty        bool            `json:"thirdParty"`
+	RecipientMismatch bool            `json:"recipientMismatch"`
+}
+
+// TokenTransfer - Tokens moved across bridge by deposit/ burn tx, as found in its
+// logs, where `Token` is address on chain, tx is performed on
+//
+// `TokenIDs` are set for ERC721 & ERC1155, while `Amounts` are set for ERC20, Ether
+// & ERC1155, where i-th amount is of i-th token ID, in case of ERC1155
+type TokenTransfer struct {
+	Token    common.Address `json:"token"`
+	Type     string         `json:"type"`
+	TokenIDs []string       `json:"tokenIds,omitempty"`
+	Amounts  []string       `json:"amounts,omitempty"`
+}
+
+// JSON - Converts to JSON encoded byte array, nil if it can't be
+func (t *TransactionState) JSON() []byte {
+	data, err := json.Marshal(t)
+	if err != nil {
+		return nil
+	}
+
+	return data
+}
+
+// LastStateID - Holds last state what was synced, to child chain
+// to be queried by talking to `state-id-manager` micro service
+type LastStateID struct {
+	ID string `json:"id"`
+}
+
+// Given json encoded byte data, unmarshalling it to LastStateID struct
+func decodeToLastStateID(data []byte) *LastStateID {
+	var lastStateID LastStateID
+
+	err := json.Unmarshal(data, &lastStateID)
+	if err != nil {
+		return nil
+	}
+
+	return &lastStateID
+}
+
+// CommittedState - One state sync, as it landed on child chain, as indexed
+// by `state-id-manager` micro service
+type CommittedState struct {
+	ID          string    `json:"id"`
+	BlockNumber uint64    `json:"blockNumber"`
+	TxHash      string    `json:"txHash"`
+	Success     bool      `json:"success"`
+	SyncTime    int64     `json:"syncTime"`
+	Contract    string    `json:"contract"`
+	RootTxHash  string    `json:"rootTxHash"`
+	Data        string    `json:"data"`
+	Decoded     *SyncData `json:"decoded,omitempty"`
+}
+
+// SyncData - Decoded form of state sync data, generated by POS bridge's
+// RootChainManager, otherwise only `type` is set i.e. `UNKNOWN`
+type SyncData struct {
+	Type       string `json:"type"`
+	User       string `json:"user,omitempty"`
+	RootToken  string `json:"rootToken,omitempty"`
+	ChildToken string `json:"childToken,omitempty"`
+	TokenType  string `json:"tokenType,omitempty"`
+	Payload    string `json:"payload,omitempty"`
+}
+
+// CheckPointed - Data to be sent in POST request, before performing
+// a check on whether this child chain block has been check pointed or not
+type CheckPointed struct {
+	BlockNumber string `json:"blockNumber"`
+}
+
+// JSON - JSON encoded form, to be sent as HTTP request body
+func (c *CheckPointed) JSON() []byte {
+	data, err := json.Marshal(c)
+	if err != nil {
+		return nil
+	}
+
+	return data
+}
+
+// CheckExitable - Data to be sent in POST request to check
+// whether this withdraw is still under challenge period or not
+//
+// Also `/v1/plasma-exit` will accept array of this type, for querying status of
+// confirm withdraw tx on root chain
+type CheckExitable struct {
+	BurnTxHash    common.Hash `json:"burnTxHash" binding:"required"`
+	ConfirmTxHash common.Hash `json:"confirmTxHash" binding:"required"`
+}
+
+// JSON - JSON encoded form, to be sent as HTTP request body
+func (c *CheckExitable) JSON() []byte {
+	data, err := json.Marshal(c)
+	if err != nil {
+		return nil
+	}
+
+	return data
+}
+
+// WithdrawTransaction - Data schema for withdraw transaction tracking request,
+// to be received in this form
+//
+// When `isPoS` is true, it'll not have `ConfirmWithdrawTxHash` field
+// else, it'll have all fields, which is why `ConfirmWithdrawTxHash` field is not
+// strictly bound
+//
+// `isPoS` is optional, bridge is inferred from burn tx, when omitted, while if
+// supplied, it must agree with burn tx
+type WithdrawTransaction struct {
+	BurnTxHash            common.Hash `json:"txHash" binding:"required"`
+	IsPOS                 *bool       `json:"isPoS"`
+	ConfirmWithdrawTxHash common.Hash `json:"relatedTxHash"`
+	ExitTxHash            common.Hash `json:"exitTxHash"`
+}
+
+// WithdrawTransactions - All withdraw transactions required to be tracked
+// are to be sent in this form
+type WithdrawTransactions struct {
+	Transactions []*WithdrawTransaction `json:"withdrawTxObjectArray" binding:"required"`
+}
+
+// Unique - Returns withdraw transactions, where only first one is kept, when
+// same burn tx hash is present more than once, while null ones are skipped
+func (w *WithdrawTransactions) Unique() []*WithdrawTransaction {
+	buffer := make([]*WithdrawTransaction, 0)
+	hashes := make([]common.Hash, 0)
+
+	for _, v := range w.Transactions {
+		if v == nil || exists(hashes, v.BurnTxHash) {
+			continue
+		}
+
+		buffer = append(buffer, v)
+		hashes = append(hashes, v.BurnTxHash)
+	}
+
+	return buffer
+}
+
+// WithdrawTransactionStatus - Reponse of withdraw tx status tracking request
+//
+// `Degraded`, `Source` & `Reason` carry same meaning as in `TransactionState`,
+// while `IsPOS` denotes bridge withdraw was tracked on
+//
+// Confirm/ exit tx hashes, which were not supplied, but discovered on root chain
+// are responded with, in `ConfirmWithdrawTxHash` & `ExitTxHash`, along with tokens
+// burnt, in `Tokens` & accounts involved in exit, in `Exit`
+type WithdrawTransactionStatus struct {
+	Code                  int              `json:"code"`
+	Message               string           `json:"msg"`
+	IsPOS                 bool             `json:"isPoS"`
+	Degraded              bool             `json:"degraded"`
+	Source                string           `json:"source,omitempty"`
+	Reason                string           `json:"reason,omitempty"`
+	ConfirmWithdrawTxHash *common.Hash     `json:"relatedTxHash,omitempty"`
+	ExitTxHash            *common.Hash     `json:"exitTxHash,omitempty"`
+	Tokens                []*TokenTransfer `json:"tokens,omitempty"`
+	Exit                  *ExitParties     `json:"exit,omitempty"`
+}
+
+// Statuses - Response of `/v1/*` endpoints, other than `/v1/approval` &
+// `/v1/deposit`, keyed by tx hash
+type Statuses map[common.Hash]*TransactionState
+
+// ApprovalResponse - Response of `/v1/approval`
+type ApprovalResponse struct {
+	Statuses map[common.Hash]*TransactionState `json:"approvalTxStatus"`
+	Action   string                            `json:"action"`
+	Count    int                               `json:"count"`
+}
+
+// DepositResponse - Response of `/v1/deposit`
+type DepositResponse struct {
+	Statuses map[common.Hash]*TransactionState `json:"depositTxStatus"`
+	Action   string                            `json:"action"`
+	Count    int                               `json:"count"`
+}
+
+// WithdrawResponse - Response of `/v2/withdraw`
+type WithdrawResponse struct {
+	Statuses map[common.Hash]*WithdrawTransactionStatus `json:"withdrawTxStatus"`
+	Action   string                                     `json:"action"`
+	Count    int                                        `json:"count"`
+}
+
+// DependencyHealth - Health of one dependency of bridge API
+type DependencyHealth struct {
+	Healthy bool   `json:"healthy"`
+	Message string `json:"msg,omitempty"`
+}
+
+// HealthResponse - Response of `/health`, where bridge API is healthy only
+// when all of its dependencies are
+type HealthResponse struct {
+	Healthy      bool                         `json:"healthy"`
+	Dependencies map[string]*DependencyHealth `json:"dependencies"`
+}
+
+// ErrorResponse - Body of non-200 responses
+type ErrorResponse struct {
+	Message string `json:"msg"`
+}
+
+// Bridges, tokens can be mapped on
+const (
+	POSBridge    = "pos"
+	PlasmaBridge = "plasma"
+)
+
+// Types of mapped tokens
+const (
+	ERC20Token   = "ERC20"
+	ERC721Token  = "ERC721"
+	ERC1155Token = "ERC1155"
+	EtherToken   = "Ether"
+)
+
+// Token - Token mapped between root & child chain, on one of bridges, as
+// found from `TokenMapped` events
+type Token struct {
+	RootToken  common.Address `json:"rootToken"`
+	ChildToken common.Address `json:"childToken"`
+	Type       string         `json:"type"`
+	Bridge     string         `json:"bridge"`
+	Decimals   uint8          `json:"decimals"`
+	Symbol     string         `json:"symbol"`
+}
+
+// TokensResponse - Response of `/v2/tokens`
+type TokensResponse struct {
+	Tokens []*Token `json:"tokens"`
+}
+
+// TokenMapping - Mapping of token, looked up by its address on either of chains,
+// where `Chain` denotes chain it lives on & `Counterpart` is its address on other one
+type TokenMapping struct {
+	Address     common.Address `json:"address"`
+	Chain       string         `json:"chain"`
+	Counterpart common.Address `json:"counterpart"`
+	Type        string         `json:"type"`
+	Bridge      string         `json:"bridge"`
+	Decimals    uint8          `json:"decimals"`
+	Symbol      string         `json:"symbol"`
+}
+
+// TokenResponse - Response of `/v2/tokens/:address`, which carries one mapping
+// per bridge, token is mapped on
+type TokenResponse struct {
+	Mappings []*TokenMapping `json:"mappings"`
+}
@@ -0,0 +1,5 @@
+module github.com/maticnetwork/deposits-withdrawals-tracking/app/client
+
+go 1.13
+
+require github.com/ethereum/go-ethereum v1.9.23
@@ -0,0 +1,105 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Returned when poller is asked to poll an endpoint, which doesn't
+// accept plain tx hashes as payload
+var errUnsupportedEndpoint = errors.New("endpoint can't be polled using tx hash only")
+
+// Returned when bridge API responds, but without status of asked tx hash
+var errMissingStatus = errors.New("status missing in response")
+
+// Poller - Keeps polling one bulk status checking endpoint, for status
+// of a tx hash, until it reaches one of expected states
+type Poller struct {
+	Client   *Client
+	Endpoint string
+	Interval time.Duration
+}
+
+// NewPoller - Creates poller for given endpoint, which must be one accepting
+// `{"txHashes": [...]}` payload i.e. anything other than `/v1/plasma-confirm`
+// & `/v2/withdraw`
+func (c *Client) NewPoller(endpoint string) *Poller {
+	return &Poller{
+		Client:   c,
+		Endpoint: endpoint,
+		Interval: time.Second * time.Duration(15),
+	}
+}
+
+// Status - Current status of given tx hash, as responded by endpoint
+func (p *Poller) Status(ctx context.Context, hash common.Hash) (*TransactionState, error) {
+	var statuses map[common.Hash]*TransactionState
+
+	switch p.Endpoint {
+
+	case ApprovalEndpoint:
+
+		resp, err := p.Client.Approval(ctx, []common.Hash{hash})
+		if err != nil {
+			return nil, err
+		}
+		statuses = resp.Statuses
+
+	case DepositEndpoint:
+
+		resp, err := p.Client.Deposit(ctx, []common.Hash{hash})
+		if err != nil {
+			return nil, err
+		}
+		statuses = resp.Statuses
+
+	case POSBurnEndpoint, POSExitEndpoint, PlasmaBurnEndpoint, PlasmaExitEndpoint:
+
+		resp, err := p.Client.statuses(ctx, p.Endpoint, []common.Hash{hash})
+		if err != nil {
+			return nil, err
+		}
+		statuses = resp
+
+	default:
+		return nil, errUnsupportedEndpoint
+
+	}
+
+	status, ok := statuses[hash]
+	if !ok || status == nil {
+		return nil, errMissingStatus
+	}
+
+	return status, nil
+}
+
+// WaitForStatus - Polls endpoint every `Interval`, until status code of given tx hash
+// becomes one of `terminalCodes`, returning that status
+//
+// Gives up when context gets cancelled or when request fails even after retries
+func (p *Poller) WaitForStatus(ctx context.Context, hash common.Hash, terminalCodes []int) (*TransactionState, error) {
+	for {
+
+		status, err := p.Status(ctx, hash)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, v := range terminalCodes {
+			if status.Code == v {
+				return status, nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(p.Interval):
+		}
+
+	}
+}
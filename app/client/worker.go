@@ -0,0 +1,205 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Returned when worker responds with something we can't make sense of
+var errBadWorkerResponse = errors.New("bad response from worker")
+
+// Sends request to worker & returns response body, given response
+// status code is 200
+func do(ctx context.Context, httpClient *http.Client, method string, url string, body []byte) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var _err ErrorResponse
+		json.Unmarshal(data, &_err)
+
+		return nil, &APIError{StatusCode: resp.StatusCode, Message: _err.Message}
+	}
+
+	return data, nil
+}
+
+// CheckPointTracker - HTTP client of `check-point-tracker` micro service
+type CheckPointTracker struct {
+	URL        string
+	HTTPClient *http.Client
+}
+
+// NewCheckPointTracker - Creates client talking to `check-point-tracker` running at given URL
+func NewCheckPointTracker(url string) *CheckPointTracker {
+	return &CheckPointTracker{
+		URL:        url,
+		HTTPClient: &http.Client{Timeout: time.Second * time.Duration(10)},
+	}
+}
+
+// IsCheckPointed - Sends request to `check-point-tracker` service
+// with `blockNumber` as payload, so that we can check whether this
+// child chain block has been checkpointed or not
+func (c *CheckPointTracker) IsCheckPointed(ctx context.Context, blockNumber *big.Int) (bool, error) {
+	data, err := do(ctx, c.HTTPClient, http.MethodPost, c.URL, (&CheckPointed{
+		BlockNumber: blockNumber.String(),
+	}).JSON())
+	if err != nil {
+		return false, err
+	}
+
+	var _tmp TransactionState
+
+	if err := json.Unmarshal(data, &_tmp); err != nil {
+		return false, err
+	}
+
+	return _tmp.Code != 0, nil
+}
+
+// StateIDManager - HTTP client of `state-id-manager` micro service
+type StateIDManager struct {
+	URL        string
+	HTTPClient *http.Client
+}
+
+// NewStateIDManager - Creates client talking to `state-id-manager` running at given URL
+func NewStateIDManager(url string) *StateIDManager {
+	return &StateIDManager{
+		URL:        url,
+		HTTPClient: &http.Client{Timeout: time.Second * time.Duration(10)},
+	}
+}
+
+// LastStateID - Fetches `lastStateId` of child chain contract
+// by querying `state-id-manager` micro service
+func (s *StateIDManager) LastStateID(ctx context.Context) (*big.Int, error) {
+	data, err := do(ctx, s.HTTPClient, http.MethodGet, s.URL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	lastStateID := decodeToLastStateID(data)
+	if lastStateID == nil {
+		return nil, errBadWorkerResponse
+	}
+
+	_tmp, ok := big.NewInt(0).SetString(lastStateID.ID, 10)
+	if !ok {
+		return nil, errBadWorkerResponse
+	}
+
+	return _tmp, nil
+}
+
+// State - Looks up state sync by `stateId`, as committed on child chain, by querying
+// `state-id-manager` micro service, returns nil if it's not yet indexed
+func (s *StateIDManager) State(ctx context.Context, id *big.Int) (*CommittedState, error) {
+	data, err := do(ctx, s.HTTPClient, http.MethodGet, fmt.Sprintf("%s/state/%s", strings.TrimSuffix(s.URL, "/"), id.String()), nil)
+	if err != nil {
+		var _err *APIError
+		if errors.As(err, &_err) && _err.StatusCode == http.StatusNotFound {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	var _tmp CommittedState
+
+	if err := json.Unmarshal(data, &_tmp); err != nil {
+		return nil, errBadWorkerResponse
+	}
+
+	return &_tmp, nil
+}
+
+// POSExitChecker - HTTP client of `pos-exit-checker` micro service
+type POSExitChecker struct {
+	URL        string
+	HTTPClient *http.Client
+}
+
+// NewPOSExitChecker - Creates client talking to `pos-exit-checker` running at given URL
+func NewPOSExitChecker(url string) *POSExitChecker {
+	return &POSExitChecker{
+		URL:        strings.TrimSuffix(url, "/"),
+		HTTPClient: &http.Client{Timeout: time.Second * time.Duration(30)},
+	}
+}
+
+// IsExited - Checks whether given burn tx on child chain, has been exited
+// on root chain using POS bridge or not
+func (p *POSExitChecker) IsExited(ctx context.Context, burnTxHash common.Hash) (bool, error) {
+	data, err := do(ctx, p.HTTPClient, http.MethodPost, p.URL, (&POSExited{
+		TransactionHash: burnTxHash.Hex(),
+	}).JSON())
+	if err != nil {
+		return false, err
+	}
+
+	var _tmp TransactionState
+
+	if err := json.Unmarshal(data, &_tmp); err != nil {
+		return false, err
+	}
+
+	return _tmp.Code != 0, nil
+}
+
+// Health - Checks whether `pos-exit-checker` is up & ready to serve requests
+func (p *POSExitChecker) Health(ctx context.Context) error {
+	_, err := do(ctx, p.HTTPClient, http.MethodGet, fmt.Sprintf("%s/%s", p.URL, "health"), nil)
+	return err
+}
+
+// ExitTime - Checks whether given Plasma withdraw has covered challenge period or not
+//
+// Response code `0` denotes it's not yet exitable, where message holds unix timestamp
+// in seconds, after which it'll become exitable
+func (p *POSExitChecker) ExitTime(ctx context.Context, burnTxHash common.Hash, confirmTxHash common.Hash) (*TransactionState, error) {
+	data, err := do(ctx, p.HTTPClient, http.MethodPost, fmt.Sprintf("%s/%s", p.URL, "exit-time"), (&CheckExitable{
+		BurnTxHash:    burnTxHash,
+		ConfirmTxHash: confirmTxHash,
+	}).JSON())
+	if err != nil {
+		return nil, err
+	}
+
+	var _tmp TransactionState
+
+	if err := json.Unmarshal(data, &_tmp); err != nil {
+		return nil, err
+	}
+
+	return &_tmp, nil
+}
@@ -0,0 +1,19 @@
+package main
+
+import (
+	t "app/tracker"
+	"logger"
+	"os"
+
+	"path/filepath"
+)
+
+func main() {
+	absPath, err := filepath.Abs(".env")
+	if err != nil {
+		logger.New("bridge-cli").Fatal("Failed to resolve config file path", logger.Fields{"error": err})
+		return
+	}
+
+	t.RunCLI(absPath, os.Args[1:])
+}
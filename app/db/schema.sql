@@ -1,13 +1,105 @@
 -- All tx hashes generated on root chain, to be persisted in this table
+--
+-- `updated_at` is when status last changed, so that ones held for too long can be found
 create table root_chain (
     txhash char(66) primary key,
     code smallint not null,
-    msg varchar not null
+    msg varchar not null,
+    created_at timestamptz not null default now(),
+    updated_at timestamptz not null default now()
 );
 
+create index idx_root_chain_updated_at on root_chain (updated_at);
+
 -- All tx hashes generated on child chain, to be persisted in this table
 create table child_chain (
     txhash char(66) primary key,
     code smallint not null,
-    msg varchar not null
+    msg varchar not null,
+    created_at timestamptz not null default now(),
+    updated_at timestamptz not null default now()
+);
+
+create index idx_child_chain_updated_at on child_chain (updated_at);
+
+-- API keys, where only SHA256 hash of key is kept, along with prefix for telling them apart
+create table api_keys (
+    id bigserial primary key,
+    name varchar not null,
+    prefix varchar(16) not null,
+    hash char(64) not null,
+    rate_limit bigint not null default 0,
+    daily_quota bigint not null default 0,
+    allowed_origins varchar not null default '',
+    disabled boolean not null default false,
+    created_at timestamptz
+);
+
+create unique index idx_api_keys_hash on api_keys (hash);
+
+-- Requests made using each API key, per day i.e. `YYYY-MM-DD` in UTC
+create table api_usage (
+    key_id bigint not null,
+    day char(10) not null,
+    requests bigint not null default 0,
+    throttled bigint not null default 0,
+    primary key (key_id, day)
+);
+
+-- Every change of persisted status of tx, from either table
+create table tx_status_history (
+    id bigserial primary key,
+    chain varchar(8) not null,
+    txhash char(66) not null,
+    code smallint not null,
+    msg varchar not null,
+    request_id varchar not null default '',
+    created_at timestamptz
+);
+
+create index idx_tx_status_history_txhash on tx_status_history (txhash);
+
+-- Actions taken using admin API & CLI
+create table admin_audit (
+    id bigserial primary key,
+    actor varchar not null,
+    action varchar not null,
+    target varchar not null,
+    details text not null default '',
+    request_id varchar not null default '',
+    ip varchar not null default '',
+    created_at timestamptz
+);
+
+-- Tokens mapped on POS & Plasma bridge, indexed from `TokenMapped` events
+create table token_mapping (
+    root_token char(42) not null,
+    bridge varchar(8) not null,
+    child_token char(42) not null,
+    type varchar(8) not null,
+    decimals smallint not null default 0,
+    symbol varchar not null default '',
+    block bigint not null,
+    txhash char(66) not null,
+    updated_at timestamptz,
+    primary key (root_token, bridge)
+);
+
+create index idx_token_mapping_child_token on token_mapping (child_token);
+
+-- Root chain block, each indexer has progressed upto
+create table index_cursor (
+    name varchar primary key,
+    block bigint not null
+);
+
+-- Confirm/ exit tx(s) discovered for burn tx, along with root chain block, search
+-- is to be resumed from
+create table exit_discovery (
+    txhash char(66) primary key,
+    confirmtxhash char(66) not null default '',
+    exitid char(66) not null default '',
+    exittxhash char(66) not null default '',
+    block bigint not null,
+    updated_at timestamptz
 );
@@ -3,6 +3,8 @@ module app
 go 1.13
 
 require (
+	logger v0.0.0
+	check-point-tracker v0.0.0
 	github.com/StackExchange/wmi v0.0.0-20190523213315-cbe66965904d // indirect
 	github.com/aristanetworks/goarista v0.0.0-20201012165903-2cb20defcd66 // indirect
 	github.com/btcsuite/btcd v0.21.0-beta // indirect
@@ -21,6 +23,7 @@ require (
 	github.com/json-iterator/go v1.1.10 // indirect
 	github.com/karalabe/usb v0.0.0-20191104083709-911d15fe12a9 // indirect
 	github.com/magiconair/properties v1.8.4 // indirect
+	github.com/maticnetwork/deposits-withdrawals-tracking/app/client v0.0.0
 	github.com/mattn/go-runewidth v0.0.9 // indirect
 	github.com/mitchellh/mapstructure v1.3.3 // indirect
 	github.com/olekukonko/tablewriter v0.0.4 // indirect
@@ -46,4 +49,19 @@ require (
 	gopkg.in/ini.v1 v1.62.0 // indirect
 	gorm.io/driver/postgres v1.0.5
 	gorm.io/gorm v1.20.5
+	state-id-manager v0.0.0
+	state-sender-indexer v0.0.0
+	worker-common v0.0.0
 )
+
+replace (
+	check-point-tracker => ../worker/check-point-tracker
+	state-id-manager => ../worker/state-id-manager
+	state-sender-indexer => ../worker/state-sender-indexer
+)
+
+replace github.com/maticnetwork/deposits-withdrawals-tracking/app/client => ./client
+
+replace logger => ../logger
+
+replace worker-common => ../worker/common
@@ -0,0 +1,413 @@
+// Package e2e - Tests of Go client, talking to bridge API, which is served
+// over HTTP, backed by stand-ins of chains, workers & DB
+package e2e
+
+import (
+	"app/internal/testutil"
+	"app/nft"
+	"app/tracker"
+	"context"
+	"database/sql/driver"
+	"errors"
+	"io/ioutil"
+	"logger"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/maticnetwork/deposits-withdrawals-tracking/app/client"
+	"github.com/spf13/viper"
+)
+
+var (
+	rootChain  *testutil.Chain
+	childChain *testutil.Chain
+	workers    *testutil.Workers
+	db         *testutil.DB
+	api        *client.Client
+)
+
+// Contract, Plasma withdraw NFTs are minted by, on root chain
+var exitNFT = common.HexToAddress("0x0000000000000000000000000000000000000e11")
+
+// Bridge API, talking to stand-ins of chains, workers & DB, is served over HTTP,
+// for being queried by client
+func TestMain(m *testing.M) {
+	logger.SetOutput(ioutil.Discard)
+
+	rootChain, childChain = testutil.NewChain(), testutil.NewChain()
+	workers = testutil.NewWorkers()
+
+	viper.Set("RootRPC", "http://localhost:8545")
+	viper.Set("ChildRPC", "http://localhost:8546")
+	viper.Set("CheckPointTracker", workers.CheckPointTracker.URL)
+	viper.Set("StateIDManager", workers.StateIDManager.URL)
+	viper.Set("POSExitChecker", workers.POSExitChecker.URL)
+	viper.Set("ExitNFT", exitNFT.Hex())
+
+	// Plasma withdraw NFTs are burnt, as soon as they're exited
+	rootChain.Call = func(to common.Address, data []byte) ([]byte, error) {
+		if to != exitNFT {
+			return nil, errors.New("unknown contract")
+		}
+
+		return common.LeftPadBytes([]byte{0}, 32), nil
+	}
+
+	rootClient, childClient := rootChain.Client(), childChain.Client()
+
+	_nft, err := nft.NewNft(exitNFT, rootClient)
+	if err != nil {
+		panic(err)
+	}
+
+	gormDB, _db := testutil.NewDB()
+	db = _db
+
+	server := httptest.NewServer(tracker.NewRouter(rootClient, childClient, gormDB, _nft))
+
+	api = client.New(server.URL)
+	api.RetryInterval = time.Millisecond
+
+	code := m.Run()
+
+	server.Close()
+	workers.Close()
+
+	os.Exit(code)
+}
+
+// Asserts status of tx hash in response, is as expected
+func expect(t *testing.T, statuses map[common.Hash]*client.TransactionState, hash common.Hash, code int, msg string) {
+	t.Helper()
+
+	status, ok := statuses[hash]
+	if !ok || status == nil {
+		t.Fatalf("status of %s missing", hash.Hex())
+	}
+
+	if status.Code != code || status.Message != msg {
+		t.Fatalf("status of %s : expected %d/ %s, found %d/ %s", hash.Hex(), code, msg, status.Code, status.Message)
+	}
+}
+
+// Log emitted by `StateSender`, when deposit with given state ID is synced
+func stateSynced(id int64) *types.Log {
+	return &types.Log{
+		Topics: []common.Hash{
+			crypto.Keccak256Hash([]byte("StateSynced(uint256,address,bytes)")),
+			common.BigToHash(big.NewInt(id)),
+			common.Hash{},
+		},
+	}
+}
+
+func TestApproval(t *testing.T) {
+	approved := rootChain.Mine(&testutil.Tx{})
+	failed := rootChain.Mine(&testutil.Tx{Failed: true})
+	pending := common.HexToHash("0x01")
+
+	resp, err := api.Approval(context.Background(), []common.Hash{approved, failed, pending})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if resp.Count != 1 {
+		t.Fatalf("expected 1 pending approval, found %d", resp.Count)
+	}
+
+	expect(t, resp.Statuses, approved, 5, "Approved")
+	expect(t, resp.Statuses, failed, 6, "Failed")
+	expect(t, resp.Statuses, pending, 7, "Pending")
+}
+
+func TestDeposit(t *testing.T) {
+	deposited := rootChain.Mine(&testutil.Tx{Logs: []*types.Log{stateSynced(1)}})
+	enRoute := rootChain.Mine(&testutil.Tx{Logs: []*types.Log{stateSynced(2)}})
+	bad := rootChain.Mine(&testutil.Tx{})
+
+	workers.Sync(&client.CommittedState{ID: "1", Success: true, TxHash: common.HexToHash("0x02").Hex()})
+
+	resp, err := api.Deposit(context.Background(), []common.Hash{deposited, enRoute, bad})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expect(t, resp.Statuses, deposited, 0, "Deposited")
+	expect(t, resp.Statuses, enRoute, 1, "En Route")
+	expect(t, resp.Statuses, bad, 3, "Bad Deposit Hash")
+
+	if state := resp.Statuses[deposited].StateSync; state == nil || state.ID != "1" || !state.Success {
+		t.Fatalf("expected state sync of deposit, found %+v", state)
+	}
+}
+
+func TestPOSBurn(t *testing.T) {
+	checkPointed := childChain.Mine(&testutil.Tx{})
+	exited := childChain.Mine(&testutil.Tx{})
+	burnt := childChain.Mine(&testutil.Tx{})
+	failed := childChain.Mine(&testutil.Tx{Failed: true})
+
+	// Blocks upto one, `exited` is mined in, are checkpointed
+	workers.CheckPoint(childChain.Head().Number.Uint64() - 2)
+	workers.Exit(exited)
+
+	statuses, err := api.POSBurn(context.Background(), []common.Hash{checkPointed, exited, burnt, failed})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expect(t, statuses, checkPointed, -4, "Checkpointed")
+	expect(t, statuses, exited, -5, "Exited")
+	expect(t, statuses, burnt, -3, "Burnt")
+	expect(t, statuses, failed, -2, "Failed")
+}
+
+func TestPOSExit(t *testing.T) {
+	exited := rootChain.Mine(&testutil.Tx{})
+	failed := rootChain.Mine(&testutil.Tx{Failed: true})
+	pending := common.HexToHash("0x03")
+
+	statuses, err := api.POSExit(context.Background(), []common.Hash{exited, failed, pending})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expect(t, statuses, exited, -10, "Exited")
+	expect(t, statuses, failed, -11, "Failed")
+	expect(t, statuses, pending, -12, "Pending")
+}
+
+func TestPlasmaBurn(t *testing.T) {
+	burnt := childChain.Mine(&testutil.Tx{})
+	pending := common.HexToHash("0x04")
+
+	statuses, err := api.PlasmaBurn(context.Background(), []common.Hash{burnt, pending})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expect(t, statuses, burnt, -3, "Burnt")
+	expect(t, statuses, pending, -1, "Pending")
+}
+
+func TestPlasmaConfirm(t *testing.T) {
+	burn := childChain.Mine(&testutil.Tx{})
+	confirm := rootChain.Mine(&testutil.Tx{Logs: []*types.Log{{
+		Topics: []common.Hash{
+			crypto.Keccak256Hash([]byte("ExitStarted(address,uint256,address,uint256,bool)")),
+			common.Hash{},
+			common.BigToHash(big.NewInt(1)),
+			common.Hash{},
+		},
+	}}})
+	bad := rootChain.Mine(&testutil.Tx{})
+
+	statuses, err := api.PlasmaConfirm(context.Background(), []client.CheckExitable{
+		{BurnTxHash: burn, ConfirmTxHash: confirm},
+		{BurnTxHash: burn, ConfirmTxHash: bad},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expect(t, statuses, confirm, -10, "Exited")
+	expect(t, statuses, bad, -6, "Bad Plasma Exit Hash")
+}
+
+func TestPlasmaExit(t *testing.T) {
+	exited := rootChain.Mine(&testutil.Tx{})
+	pending := common.HexToHash("0x05")
+
+	statuses, err := api.PlasmaExit(context.Background(), []common.Hash{exited, pending})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expect(t, statuses, exited, -10, "Exited")
+	expect(t, statuses, pending, -12, "Pending")
+}
+
+func TestWithdraw(t *testing.T) {
+	isPOS := true
+	burnt := childChain.Mine(&testutil.Tx{})
+
+	resp, err := api.Withdraw(context.Background(), []*client.WithdrawTransaction{{BurnTxHash: burnt, IsPOS: &isPOS}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	status, ok := resp.Statuses[burnt]
+	if !ok || status == nil {
+		t.Fatalf("status of %s missing", burnt.Hex())
+	}
+
+	if !status.IsPOS || status.Code != -3 || status.Message != "Burnt" {
+		t.Fatalf("expected POS withdraw to be burnt, found %+v", status)
+	}
+}
+
+func TestBadPayload(t *testing.T) {
+	_, err := api.Approval(context.Background(), []common.Hash{})
+
+	var _err *client.APIError
+	if !errors.As(err, &_err) || _err.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected bad request, found %v", err)
+	}
+}
+
+func TestTokens(t *testing.T) {
+	root := common.HexToAddress("0x00000000000000000000000000000000000000a1")
+	child := common.HexToAddress("0x00000000000000000000000000000000000000b1")
+
+	db.Respond = func(query string, args []driver.Value) (*testutil.Rows, error) {
+		if !strings.Contains(query, `"token_mapping"`) {
+			return nil, nil
+		}
+
+		return &testutil.Rows{
+			Columns: []string{"root_token", "bridge", "child_token", "type", "decimals", "symbol", "block", "txhash", "updated_at"},
+			Values:  [][]driver.Value{{root.Hex(), client.POSBridge, child.Hex(), client.ERC20Token, int64(18), "TST", int64(1), common.Hash{}.Hex(), time.Now()}},
+		}, nil
+	}
+	defer func() { db.Respond = nil }()
+
+	tokens, err := api.Tokens(context.Background(), client.POSBridge, client.ERC20Token)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(tokens) != 1 || tokens[0].RootToken != root || tokens[0].ChildToken != child {
+		t.Fatalf("expected one mapped token, found %+v", tokens)
+	}
+
+	if !db.Ran("bridge = $1") || !db.Ran("type = $2") {
+		t.Fatal("expected tokens to be filtered by bridge & type")
+	}
+
+	mappings, err := api.Token(context.Background(), child)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(mappings) != 1 || mappings[0].Chain != "child" || mappings[0].Counterpart != root || mappings[0].Symbol != "TST" {
+		t.Fatalf("expected mapping of child token, found %+v", mappings)
+	}
+
+	if _, err := api.Tokens(context.Background(), "unknown", ""); err == nil {
+		t.Fatal("expected unknown bridge to be rejected")
+	}
+}
+
+func TestTokenNotMapped(t *testing.T) {
+	_, err := api.Token(context.Background(), common.HexToAddress("0x00000000000000000000000000000000000000c1"))
+
+	var _err *client.APIError
+	if !errors.As(err, &_err) || _err.StatusCode != http.StatusNotFound {
+		t.Fatalf("expected token to be not mapped, found %v", err)
+	}
+}
+
+func TestRetries(t *testing.T) {
+	attempts := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	_client := client.New(server.URL)
+	_client.Retries, _client.RetryInterval = 2, time.Millisecond
+
+	_, err := _client.PlasmaExit(context.Background(), []common.Hash{common.HexToHash("0x06")})
+
+	var _err *client.APIError
+	if !errors.As(err, &_err) || _err.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("expected service to be unavailable, found %v", err)
+	}
+
+	if attempts != 3 {
+		t.Fatalf("expected 3 attempts, found %d", attempts)
+	}
+}
+
+func TestPoller(t *testing.T) {
+	exited := rootChain.Mine(&testutil.Tx{})
+
+	poller := api.NewPoller(client.POSExitEndpoint)
+	poller.Interval = time.Millisecond
+
+	status, err := poller.WaitForStatus(context.Background(), exited, []int{-10, -11})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if status.Code != -10 {
+		t.Fatalf("expected exited, found %d", status.Code)
+	}
+
+	// Keeps polling pending tx, until caller gives up
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*time.Duration(50))
+	defer cancel()
+
+	if _, err := poller.WaitForStatus(ctx, common.HexToHash("0x07"), []int{-10, -11}); err != context.DeadlineExceeded {
+		t.Fatalf("expected polling to stop with deadline, found %v", err)
+	}
+
+	if _, err := api.NewPoller(client.WithdrawEndpoint).Status(context.Background(), exited); err == nil {
+		t.Fatal("expected withdraw endpoint to be not pollable")
+	}
+}
+
+func TestWorkers(t *testing.T) {
+	ctx := context.Background()
+
+	checkPointTracker := client.NewCheckPointTracker(workers.CheckPointTracker.URL)
+	workers.CheckPoint(10)
+
+	if ok, err := checkPointTracker.IsCheckPointed(ctx, big.NewInt(10)); err != nil || !ok {
+		t.Fatalf("expected block to be checkpointed, found %v, %v", ok, err)
+	}
+	if ok, err := checkPointTracker.IsCheckPointed(ctx, big.NewInt(11)); err != nil || ok {
+		t.Fatalf("expected block to be not checkpointed, found %v, %v", ok, err)
+	}
+
+	stateIDManager := client.NewStateIDManager(workers.StateIDManager.URL)
+	workers.Sync(&client.CommittedState{ID: "100", Success: true})
+
+	if id, err := stateIDManager.LastStateID(ctx); err != nil || id.Int64() != 100 {
+		t.Fatalf("expected last state ID 100, found %v, %v", id, err)
+	}
+	if state, err := stateIDManager.State(ctx, big.NewInt(100)); err != nil || state == nil || !state.Success {
+		t.Fatalf("expected state 100 to be synced, found %+v, %v", state, err)
+	}
+	if state, err := stateIDManager.State(ctx, big.NewInt(101)); err != nil || state != nil {
+		t.Fatalf("expected state 101 to be not indexed, found %+v, %v", state, err)
+	}
+
+	posExitChecker := client.NewPOSExitChecker(workers.POSExitChecker.URL)
+	burnt, exited := common.HexToHash("0x08"), common.HexToHash("0x09")
+	workers.Exit(exited)
+	workers.Exitable(burnt, &client.TransactionState{Code: 0, Message: "1600000000"})
+
+	if err := posExitChecker.Health(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if ok, err := posExitChecker.IsExited(ctx, exited); err != nil || !ok {
+		t.Fatalf("expected exited, found %v, %v", ok, err)
+	}
+	if ok, err := posExitChecker.IsExited(ctx, burnt); err != nil || ok {
+		t.Fatalf("expected not exited, found %v, %v", ok, err)
+	}
+	if state, err := posExitChecker.ExitTime(ctx, burnt, common.HexToHash("0x0a")); err != nil || state.Message != "1600000000" {
+		t.Fatalf("expected exit time, found %+v, %v", state, err)
+	}
+}
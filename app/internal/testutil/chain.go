@@ -0,0 +1,345 @@
+package testutil
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/eth/filters"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// Timestamp of genesis block, where each following block is mined 2 seconds later
+const genesisTime = 1600000000
+
+// Tx - Transaction to be mined, sent by `Chain.From`
+type Tx struct {
+	To     common.Address
+	Data   []byte
+	Failed bool
+	Logs   []*types.Log
+}
+
+// Chain - Chain, which mines one transaction per block, serving its blocks,
+// transactions, receipts & logs over JSON-RPC, in-process
+//
+// `eth_call` is responded to using `Call`, if set
+type Chain struct {
+	// Invoked with target contract & calldata of each `eth_call`
+	Call func(to common.Address, data []byte) ([]byte, error)
+
+	key    *ecdsa.PrivateKey
+	signer types.Signer
+	server *rpc.Server
+
+	mutex    sync.Mutex
+	headers  []*types.Header
+	txs      map[common.Hash]json.RawMessage
+	receipts map[common.Hash]*types.Receipt
+	logs     []*types.Log
+}
+
+// NewChain - Creates chain, having only genesis block
+func NewChain() *Chain {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		panic(err)
+	}
+
+	c := &Chain{
+		key:      key,
+		signer:   types.HomesteadSigner{},
+		server:   rpc.NewServer(),
+		headers:  []*types.Header{header(0, common.Hash{})},
+		txs:      make(map[common.Hash]json.RawMessage),
+		receipts: make(map[common.Hash]*types.Receipt),
+		logs:     make([]*types.Log, 0),
+	}
+
+	if err := c.server.RegisterName("eth", &ethAPI{chain: c}); err != nil {
+		panic(err)
+	}
+
+	return c
+}
+
+// Header of block, given its number & parent
+func header(number uint64, parent common.Hash) *types.Header {
+	return &types.Header{
+		ParentHash: parent,
+		Number:     new(big.Int).SetUint64(number),
+		Difficulty: big.NewInt(0),
+		Time:       genesisTime + number*2,
+		Extra:      []byte{},
+	}
+}
+
+// Client - Client talking to this chain
+func (c *Chain) Client() *ethclient.Client {
+	return ethclient.NewClient(rpc.DialInProc(c.server))
+}
+
+// From - Address, all transactions are sent from
+func (c *Chain) From() common.Address {
+	return crypto.PubkeyToAddress(c.key.PublicKey)
+}
+
+// Head - Latest block
+func (c *Chain) Head() *types.Header {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.headers[len(c.headers)-1]
+}
+
+// Advance - Mines given number of empty blocks
+func (c *Chain) Advance(blocks int) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	for i := 0; i < blocks; i++ {
+		c.mine()
+	}
+}
+
+// Appends new block on top of head, returning it
+func (c *Chain) mine() *types.Header {
+	head := c.headers[len(c.headers)-1]
+
+	_header := header(head.Number.Uint64()+1, head.Hash())
+	c.headers = append(c.headers, _header)
+
+	return _header
+}
+
+// Mine - Mines given transaction in new block, returning its hash
+func (c *Chain) Mine(tx *Tx) common.Hash {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	nonce := uint64(len(c.txs))
+
+	signed, err := types.SignTx(types.NewTransaction(nonce, tx.To, big.NewInt(0), 1000000, big.NewInt(1), tx.Data), c.signer, c.key)
+	if err != nil {
+		panic(err)
+	}
+
+	_header := c.mine()
+	blockHash := _header.Hash()
+
+	logs := make([]*types.Log, 0, len(tx.Logs))
+	for i, v := range tx.Logs {
+		_log := *v
+		if _log.Topics == nil {
+			_log.Topics = []common.Hash{}
+		}
+		if _log.Data == nil {
+			_log.Data = []byte{}
+		}
+		_log.BlockNumber = _header.Number.Uint64()
+		_log.BlockHash = blockHash
+		_log.TxHash = signed.Hash()
+		_log.Index = uint(i)
+
+		logs = append(logs, &_log)
+	}
+
+	receipt := &types.Receipt{
+		Status:            types.ReceiptStatusSuccessful,
+		CumulativeGasUsed: 21000,
+		GasUsed:           21000,
+		Logs:              logs,
+		TxHash:            signed.Hash(),
+		BlockHash:         blockHash,
+		BlockNumber:       _header.Number,
+	}
+	if tx.Failed {
+		receipt.Status = types.ReceiptStatusFailed
+		receipt.Logs = []*types.Log{}
+	}
+	receipt.Bloom = types.CreateBloom(types.Receipts{receipt})
+
+	// Transaction, as responded with by `eth_getTransactionByHash`, carrying
+	// where it's included & who sent it
+	data, err := signed.MarshalJSON()
+	if err != nil {
+		panic(err)
+	}
+
+	var _tx map[string]interface{}
+	if err := json.Unmarshal(data, &_tx); err != nil {
+		panic(err)
+	}
+
+	_tx["blockHash"] = blockHash
+	_tx["blockNumber"] = (*hexutil.Big)(_header.Number)
+	_tx["from"] = c.From()
+	_tx["transactionIndex"] = hexutil.Uint(0)
+
+	if data, err = json.Marshal(_tx); err != nil {
+		panic(err)
+	}
+
+	c.txs[signed.Hash()] = data
+	c.receipts[signed.Hash()] = receipt
+	c.logs = append(c.logs, receipt.Logs...)
+
+	return signed.Hash()
+}
+
+// Block, given its number, where negative ones denote latest
+func (c *Chain) block(number rpc.BlockNumber) *types.Header {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if number < 0 {
+		return c.headers[len(c.headers)-1]
+	}
+
+	if int(number) >= len(c.headers) {
+		return nil
+	}
+
+	return c.headers[number]
+}
+
+// `eth` namespace, served over JSON-RPC
+type ethAPI struct {
+	chain *Chain
+}
+
+// Arguments of `eth_call`, which are of interest
+type callArgs struct {
+	To   *common.Address `json:"to"`
+	Data hexutil.Bytes   `json:"data"`
+}
+
+func (e *ethAPI) ChainId() hexutil.Uint64 {
+	return hexutil.Uint64(1337)
+}
+
+func (e *ethAPI) BlockNumber() hexutil.Uint64 {
+	return hexutil.Uint64(e.chain.Head().Number.Uint64())
+}
+
+func (e *ethAPI) GetBlockByNumber(number rpc.BlockNumber, full bool) *types.Header {
+	return e.chain.block(number)
+}
+
+func (e *ethAPI) GetTransactionByHash(hash common.Hash) json.RawMessage {
+	e.chain.mutex.Lock()
+	defer e.chain.mutex.Unlock()
+
+	if tx, ok := e.chain.txs[hash]; ok {
+		return tx
+	}
+
+	return json.RawMessage("null")
+}
+
+func (e *ethAPI) GetTransactionReceipt(hash common.Hash) *types.Receipt {
+	e.chain.mutex.Lock()
+	defer e.chain.mutex.Unlock()
+
+	return e.chain.receipts[hash]
+}
+
+func (e *ethAPI) GetLogs(crit filters.FilterCriteria) []*types.Log {
+	head := e.chain.Head().Number.Uint64()
+
+	from, to := uint64(0), head
+	if crit.FromBlock != nil && crit.FromBlock.Sign() >= 0 {
+		from = crit.FromBlock.Uint64()
+	}
+	if crit.ToBlock != nil && crit.ToBlock.Sign() >= 0 {
+		to = crit.ToBlock.Uint64()
+	}
+
+	e.chain.mutex.Lock()
+	defer e.chain.mutex.Unlock()
+
+	logs := make([]*types.Log, 0)
+
+	for _, v := range e.chain.logs {
+		if v.BlockNumber < from || v.BlockNumber > to {
+			continue
+		}
+
+		if matches(v, crit.Addresses, crit.Topics) {
+			logs = append(logs, v)
+		}
+	}
+
+	return logs
+}
+
+func (e *ethAPI) Call(ctx context.Context, args callArgs, number rpc.BlockNumber) (hexutil.Bytes, error) {
+	if e.chain.Call == nil || args.To == nil {
+		return hexutil.Bytes{}, nil
+	}
+
+	data, err := e.chain.Call(*args.To, args.Data)
+	if err != nil {
+		return nil, errors.New("execution reverted")
+	}
+
+	return data, nil
+}
+
+func (e *ethAPI) GetCode(address common.Address, number rpc.BlockNumber) hexutil.Bytes {
+	if e.chain.Call == nil {
+		return hexutil.Bytes{}
+	}
+
+	return hexutil.Bytes{0x00}
+}
+
+// Whether log is emitted by one of given addresses, if any, having given
+// topics, where empty set of topics at some position matches any
+func matches(log *types.Log, addresses []common.Address, topics [][]common.Hash) bool {
+	if len(addresses) != 0 {
+		found := false
+		for _, v := range addresses {
+			if v == log.Address {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	if len(topics) > len(log.Topics) {
+		return false
+	}
+
+	for i, v := range topics {
+		if len(v) == 0 {
+			continue
+		}
+
+		found := false
+		for _, w := range v {
+			if w == log.Topics[i] {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
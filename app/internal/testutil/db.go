@@ -0,0 +1,174 @@
+// Package testutil - Stand-ins for DB, chains, workers & Redis, this service
+// depends on, so that it can be tested without any of them running
+package testutil
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// Rows - Result of one query, as responded with by `DB`
+type Rows struct {
+	Columns []string
+	Values  [][]driver.Value
+}
+
+// DB - Database, which remembers all statements it's asked to run, responding
+// to each of them using `Respond`, if set
+//
+// Queries return no rows & statements affect one row, unless `Respond` says otherwise
+type DB struct {
+	// Invoked with each statement & its arguments, before running it, where non-nil
+	// rows are returned for queries & non-nil error fails statement
+	Respond func(query string, args []driver.Value) (*Rows, error)
+
+	mutex      sync.Mutex
+	statements []string
+}
+
+// NewDB - Creates database, along with gorm handle talking to it
+func NewDB() (*gorm.DB, *DB) {
+	_db := &DB{statements: make([]string, 0)}
+
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sql.OpenDB(_db)}), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return db, _db
+}
+
+// Statements - All statements run so far, in order
+func (d *DB) Statements() []string {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return append([]string{}, d.statements...)
+}
+
+// Ran - Whether any statement containing given fragment, was run
+func (d *DB) Ran(fragment string) bool {
+	for _, v := range d.Statements() {
+		if strings.Contains(v, fragment) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Remembers statement & finds out how it's to be responded to
+func (d *DB) run(query string, args []driver.NamedValue) (*Rows, error) {
+	d.mutex.Lock()
+	d.statements = append(d.statements, query)
+	respond := d.Respond
+	d.mutex.Unlock()
+
+	if respond == nil {
+		return nil, nil
+	}
+
+	values := make([]driver.Value, 0, len(args))
+	for _, v := range args {
+		values = append(values, v.Value)
+	}
+
+	return respond(query, values)
+}
+
+// Connect - Opens connection, to be used by `database/sql`
+func (d *DB) Connect(ctx context.Context) (driver.Conn, error) {
+	return &conn{db: d}, nil
+}
+
+// Driver - Driver, connections are opened using
+func (d *DB) Driver() driver.Driver {
+	return d
+}
+
+// Open - Opens connection, ignoring given DSN
+func (d *DB) Open(name string) (driver.Conn, error) {
+	return &conn{db: d}, nil
+}
+
+// Connection to `DB`, which runs statements without preparing them
+type conn struct {
+	db *DB
+}
+
+func (c *conn) Prepare(query string) (driver.Stmt, error) {
+	return nil, errors.New("statements are not prepared")
+}
+
+func (c *conn) Close() error {
+	return nil
+}
+
+func (c *conn) Begin() (driver.Tx, error) {
+	return c, nil
+}
+
+func (c *conn) Commit() error {
+	return nil
+}
+
+func (c *conn) Rollback() error {
+	return nil
+}
+
+func (c *conn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	_rows, err := c.db.run(query, args)
+	if err != nil {
+		return nil, err
+	}
+
+	if _rows == nil {
+		_rows = &Rows{}
+	}
+
+	return &rows{Rows: _rows}, nil
+}
+
+func (c *conn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	if _, err := c.db.run(query, args); err != nil {
+		return nil, err
+	}
+
+	return driver.RowsAffected(1), nil
+}
+
+// Iterates over rows, to be responded with
+type rows struct {
+	*Rows
+	next int
+}
+
+func (r *rows) Columns() []string {
+	return r.Rows.Columns
+}
+
+func (r *rows) Close() error {
+	return nil
+}
+
+func (r *rows) Next(dest []driver.Value) error {
+	if r.next >= len(r.Values) {
+		return io.EOF
+	}
+
+	copy(dest, r.Values[r.next])
+	r.next++
+
+	return nil
+}
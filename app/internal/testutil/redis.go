@@ -0,0 +1,286 @@
+package testutil
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Redis - Redis stand-in, keeping everything in memory
+//
+// Understands only commands sent by `redis.Client` i.e. PING, AUTH, SELECT, GET, SET ( with PX & NX ),
+// DEL & EVAL of scripts used by `IncrWindow` & `Release`, which are told apart by what they call
+type Redis struct {
+	listener net.Listener
+	values   map[string]*redisValue
+	mutex    *sync.Mutex
+}
+
+// Value kept against key, along with when it expires
+type redisValue struct {
+	data    []byte
+	expires time.Time
+}
+
+// NewRedis - Starts serving on loopback interface, at random port
+func NewRedis() *Redis {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		panic(err)
+	}
+
+	server := &Redis{
+		listener: listener,
+		values:   make(map[string]*redisValue),
+		mutex:    &sync.Mutex{},
+	}
+
+	go server.serve()
+
+	return server
+}
+
+// URL - URL of server, to be used for creating client
+func (s *Redis) URL() string {
+	return fmt.Sprintf("redis://%s", s.listener.Addr().String())
+}
+
+// Close - Stops accepting new connections
+func (s *Redis) Close() {
+	s.listener.Close()
+}
+
+// Accepts connections, until listener is closed
+func (s *Redis) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+
+		go s.handle(conn)
+	}
+}
+
+// Reads commands from connection & writes replies, until connection is closed
+func (s *Redis) handle(conn net.Conn) {
+	defer conn.Close()
+
+	reader := bufio.NewReader(conn)
+
+	for {
+		args, err := readCommand(reader)
+		if err != nil {
+			return
+		}
+
+		if _, err := io.WriteString(conn, s.exec(args)); err != nil {
+			return
+		}
+	}
+}
+
+// Reads command, sent as array of bulk strings
+func readCommand(reader *bufio.Reader) ([]string, error) {
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+
+	line = strings.TrimSuffix(line, "\r\n")
+	if !strings.HasPrefix(line, "*") {
+		return nil, fmt.Errorf("unexpected command `%s`", line)
+	}
+
+	size, err := strconv.Atoi(line[1:])
+	if err != nil {
+		return nil, err
+	}
+
+	args := make([]string, 0, size)
+
+	for i := 0; i < size; i++ {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+
+		length, err := strconv.Atoi(strings.TrimSuffix(line, "\r\n")[1:])
+		if err != nil {
+			return nil, err
+		}
+
+		buffer := make([]byte, length+2)
+		if _, err := io.ReadFull(reader, buffer); err != nil {
+			return nil, err
+		}
+
+		args = append(args, string(buffer[:length]))
+	}
+
+	return args, nil
+}
+
+// Encoders of replies
+func simple(s string) string {
+	return fmt.Sprintf("+%s\r\n", s)
+}
+
+func failure(s string) string {
+	return fmt.Sprintf("-%s\r\n", s)
+}
+
+func integer(n int64) string {
+	return fmt.Sprintf(":%d\r\n", n)
+}
+
+func bulk(data []byte) string {
+	if data == nil {
+		return "$-1\r\n"
+	}
+
+	return fmt.Sprintf("$%d\r\n%s\r\n", len(data), data)
+}
+
+// Value kept against key, nil if not present or expired
+func (s *Redis) get(key string) *redisValue {
+	v, ok := s.values[key]
+	if !ok {
+		return nil
+	}
+
+	if !v.expires.IsZero() && time.Now().After(v.expires) {
+		delete(s.values, key)
+		return nil
+	}
+
+	return v
+}
+
+// Executes command, returning encoded reply
+func (s *Redis) exec(args []string) string {
+	if len(args) == 0 {
+		return failure("ERR empty command")
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	switch strings.ToUpper(args[0]) {
+
+	case "PING":
+		return simple("PONG")
+
+	case "AUTH", "SELECT":
+		return simple("OK")
+
+	case "GET":
+		if len(args) != 2 {
+			return failure("ERR wrong number of arguments")
+		}
+
+		if v := s.get(args[1]); v != nil {
+			return bulk(v.data)
+		}
+
+		return bulk(nil)
+
+	case "SET":
+		if len(args) < 3 {
+			return failure("ERR wrong number of arguments")
+		}
+
+		v := &redisValue{data: []byte(args[2])}
+		nx := false
+
+		for i := 3; i < len(args); i++ {
+			switch strings.ToUpper(args[i]) {
+			case "NX":
+				nx = true
+			case "PX":
+				if i+1 >= len(args) {
+					return failure("ERR syntax error")
+				}
+
+				ms, err := strconv.ParseInt(args[i+1], 10, 64)
+				if err != nil {
+					return failure("ERR value is not an integer")
+				}
+
+				v.expires = time.Now().Add(time.Millisecond * time.Duration(ms))
+				i++
+			}
+		}
+
+		if nx && s.get(args[1]) != nil {
+			return bulk(nil)
+		}
+
+		s.values[args[1]] = v
+		return simple("OK")
+
+	case "DEL":
+		var count int64
+
+		for _, k := range args[1:] {
+			if s.get(k) != nil {
+				delete(s.values, k)
+				count++
+			}
+		}
+
+		return integer(count)
+
+	case "EVAL":
+		if len(args) != 5 || args[2] != "1" {
+			return failure("ERR unsupported script")
+		}
+
+		return s.eval(args[1], args[3], args[4])
+
+	}
+
+	return failure(fmt.Sprintf("ERR unknown command `%s`", args[0]))
+}
+
+// Runs one of known scripts, with given key & argument
+func (s *Redis) eval(script string, key string, arg string) string {
+	switch {
+
+	case strings.Contains(script, "'INCR'"):
+		v := s.get(key)
+		if v == nil {
+			ms, err := strconv.ParseInt(arg, 10, 64)
+			if err != nil {
+				return failure("ERR value is not an integer")
+			}
+
+			v = &redisValue{data: []byte("0"), expires: time.Now().Add(time.Millisecond * time.Duration(ms))}
+			s.values[key] = v
+		}
+
+		n, err := strconv.ParseInt(string(v.data), 10, 64)
+		if err != nil {
+			return failure("ERR value is not an integer")
+		}
+
+		v.data = []byte(strconv.FormatInt(n+1, 10))
+		return integer(n + 1)
+
+	case strings.Contains(script, "'DEL'"):
+		if v := s.get(key); v != nil && string(v.data) == arg {
+			delete(s.values, key)
+			return integer(1)
+		}
+
+		return integer(0)
+
+	}
+
+	return failure("ERR unsupported script")
+}
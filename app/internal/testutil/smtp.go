@@ -0,0 +1,270 @@
+package testutil
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"net"
+	"net/textproto"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Mail - One mail, as received by SMTP stand-in
+type Mail struct {
+	From string
+	To   []string
+	Data string
+	// Username, client authenticated as, empty when it didn't
+	User string
+	// Whether mail was received over TLS or not
+	TLS bool
+}
+
+// SMTP - SMTP server stand-in, keeping received mails in memory
+//
+// Understands only commands sent by `net/smtp` i.e. EHLO, STARTTLS, AUTH PLAIN,
+// MAIL, RCPT, DATA, RSET, NOOP & QUIT, where STARTTLS is offered, when started
+// with TLS, using self-signed certificate for `127.0.0.1`
+type SMTP struct {
+	listener net.Listener
+	tls      *tls.Config
+	pool     *x509.CertPool
+	mails    []*Mail
+	mutex    *sync.Mutex
+}
+
+// NewSMTP - Starts serving on loopback interface, at random port, offering
+// STARTTLS, when asked to
+func NewSMTP(starttls bool) *SMTP {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		panic(err)
+	}
+
+	server := &SMTP{
+		listener: listener,
+		mails:    make([]*Mail, 0),
+		mutex:    &sync.Mutex{},
+	}
+
+	if starttls {
+		server.tls, server.pool = selfSigned()
+	}
+
+	go server.serve()
+
+	return server
+}
+
+// Self-signed certificate for `127.0.0.1`, along with pool, it can be verified using
+func selfSigned() (*tls.Config, *x509.CertPool) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		panic(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "127.0.0.1"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		IsCA:         true,
+
+		BasicConstraintsValid: true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		panic(err)
+	}
+
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		panic(err)
+	}
+
+	pool := x509.NewCertPool()
+	pool.AddCert(cert)
+
+	return &tls.Config{Certificates: []tls.Certificate{{Certificate: [][]byte{der}, PrivateKey: key}}}, pool
+}
+
+// Addr - Host & port, server is listening on
+func (s *SMTP) Addr() string {
+	return s.listener.Addr().String()
+}
+
+// CertPool - Pool, certificate of server can be verified using, nil when it's
+// not offering STARTTLS
+func (s *SMTP) CertPool() *x509.CertPool {
+	return s.pool
+}
+
+// Mails - Mails received so far
+func (s *SMTP) Mails() []*Mail {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return append([]*Mail{}, s.mails...)
+}
+
+// Close - Stops accepting new connections
+func (s *SMTP) Close() {
+	s.listener.Close()
+}
+
+// Accepts connections, until listener is closed
+func (s *SMTP) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+
+		go s.handle(conn)
+	}
+}
+
+// Reads commands from connection & writes replies, until client quits or
+// connection is closed
+func (s *SMTP) handle(conn net.Conn) {
+	defer func() {
+		conn.Close()
+	}()
+
+	text := textproto.NewConn(conn)
+	if err := text.PrintfLine("220 127.0.0.1 ESMTP"); err != nil {
+		return
+	}
+
+	mail := &Mail{}
+	secure := false
+
+	for {
+		line, err := text.ReadLine()
+		if err != nil {
+			return
+		}
+
+		command, arg := line, ""
+		if i := strings.Index(line, " "); i != -1 {
+			command, arg = line[:i], line[i+1:]
+		}
+
+		var reply string
+
+		switch strings.ToUpper(command) {
+
+		case "EHLO", "HELO":
+			extensions := []string{"127.0.0.1", "AUTH PLAIN"}
+			if s.tls != nil && !secure {
+				extensions = append(extensions, "STARTTLS")
+			}
+
+			for _, v := range extensions[:len(extensions)-1] {
+				if err := text.PrintfLine("250-%s", v); err != nil {
+					return
+				}
+			}
+			reply = "250 " + extensions[len(extensions)-1]
+
+		case "STARTTLS":
+			if s.tls == nil || secure {
+				reply = "502 not offered"
+				break
+			}
+
+			if err := text.PrintfLine("220 ready"); err != nil {
+				return
+			}
+
+			_conn := tls.Server(conn, s.tls)
+			if err := _conn.Handshake(); err != nil {
+				return
+			}
+
+			conn, text, secure = _conn, textproto.NewConn(_conn), true
+			continue
+
+		case "AUTH":
+			parts := strings.Fields(arg)
+			if len(parts) != 2 || strings.ToUpper(parts[0]) != "PLAIN" {
+				reply = "504 unsupported mechanism"
+				break
+			}
+
+			decoded, err := base64.StdEncoding.DecodeString(parts[1])
+			if fields := strings.Split(string(decoded), "\x00"); err != nil || len(fields) != 3 {
+				reply = "501 bad credentials"
+			} else {
+				mail.User = fields[1]
+				reply = "235 authenticated"
+			}
+
+		case "MAIL":
+			mail.From = address(arg)
+			reply = "250 ok"
+
+		case "RCPT":
+			mail.To = append(mail.To, address(arg))
+			reply = "250 ok"
+
+		case "DATA":
+			if err := text.PrintfLine("354 go ahead"); err != nil {
+				return
+			}
+
+			data, err := text.ReadDotBytes()
+			if err != nil {
+				return
+			}
+
+			mail.Data, mail.TLS = string(data), secure
+
+			s.mutex.Lock()
+			s.mails = append(s.mails, mail)
+			s.mutex.Unlock()
+
+			mail = &Mail{User: mail.User}
+			reply = "250 queued"
+
+		case "RSET":
+			mail = &Mail{User: mail.User}
+			reply = "250 ok"
+
+		case "NOOP":
+			reply = "250 ok"
+
+		case "QUIT":
+			text.PrintfLine("221 bye")
+			return
+
+		default:
+			reply = fmt.Sprintf("502 unknown command `%s`", command)
+		}
+
+		if err := text.PrintfLine("%s", reply); err != nil {
+			return
+		}
+	}
+}
+
+// Address in `FROM:<...>`/ `TO:<...>` argument
+func address(arg string) string {
+	if i, j := strings.Index(arg, "<"), strings.LastIndex(arg, ">"); i != -1 && j > i {
+		return arg[i+1 : j]
+	}
+
+	return arg
+}
@@ -0,0 +1,177 @@
+package testutil
+
+import (
+	"math/big"
+	"net/http/httptest"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gin-gonic/gin"
+	"github.com/maticnetwork/deposits-withdrawals-tracking/app/client"
+)
+
+// Workers - `check-point-tracker`, `state-id-manager` & `pos-exit-checker`,
+// each served over HTTP, responding as per state set using its methods
+type Workers struct {
+	CheckPointTracker *httptest.Server
+	StateIDManager    *httptest.Server
+	POSExitChecker    *httptest.Server
+
+	mutex        sync.Mutex
+	checkPointed uint64
+	lastStateID  uint64
+	states       map[string]*client.CommittedState
+	exited       map[string]bool
+	exitable     map[string]*client.TransactionState
+}
+
+// NewWorkers - Starts serving workers, where no child chain block is checkpointed,
+// no state is synced & nothing is exited yet
+func NewWorkers() *Workers {
+	gin.SetMode(gin.ReleaseMode)
+
+	w := &Workers{
+		states:   make(map[string]*client.CommittedState),
+		exited:   make(map[string]bool),
+		exitable: make(map[string]*client.TransactionState),
+	}
+
+	checkPointTracker := gin.New()
+	checkPointTracker.POST("/", func(c *gin.Context) {
+		var payload client.CheckPointed
+
+		if err := c.ShouldBindJSON(&payload); err != nil {
+			c.JSON(400, gin.H{"msg": "Bad Payload"})
+			return
+		}
+
+		blockNumber, ok := big.NewInt(0).SetString(payload.BlockNumber, 10)
+		if !ok {
+			c.JSON(400, gin.H{"msg": "Bad Payload"})
+			return
+		}
+
+		w.mutex.Lock()
+		checkPointed := blockNumber.Uint64() <= w.checkPointed
+		w.mutex.Unlock()
+
+		if checkPointed {
+			c.JSON(200, gin.H{"code": 1, "msg": "Check Pointed"})
+		} else {
+			c.JSON(200, gin.H{"code": 0, "msg": "Not Check Pointed"})
+		}
+	})
+
+	stateIDManager := gin.New()
+	stateIDManager.GET("/", func(c *gin.Context) {
+		w.mutex.Lock()
+		defer w.mutex.Unlock()
+
+		c.JSON(200, gin.H{"id": new(big.Int).SetUint64(w.lastStateID).String()})
+	})
+	stateIDManager.GET("/state/:id", func(c *gin.Context) {
+		w.mutex.Lock()
+		defer w.mutex.Unlock()
+
+		state, ok := w.states[c.Param("id")]
+		if !ok {
+			c.JSON(404, gin.H{"msg": "Not Found"})
+			return
+		}
+
+		c.JSON(200, state)
+	})
+
+	posExitChecker := gin.New()
+	posExitChecker.GET("/health", func(c *gin.Context) {
+		c.JSON(200, gin.H{"msg": "OK"})
+	})
+	posExitChecker.POST("/", func(c *gin.Context) {
+		var payload client.POSExited
+
+		if err := c.ShouldBindJSON(&payload); err != nil {
+			c.JSON(400, gin.H{"msg": "Bad Payload"})
+			return
+		}
+
+		w.mutex.Lock()
+		exited := w.exited[common.HexToHash(payload.TransactionHash).Hex()]
+		w.mutex.Unlock()
+
+		if exited {
+			c.JSON(200, gin.H{"code": 1, "msg": "Exited"})
+		} else {
+			c.JSON(200, gin.H{"code": 0, "msg": "Not Exited"})
+		}
+	})
+	posExitChecker.POST("/exit-time", func(c *gin.Context) {
+		var payload client.CheckExitable
+
+		if err := c.ShouldBindJSON(&payload); err != nil {
+			c.JSON(400, gin.H{"msg": "Bad Payload"})
+			return
+		}
+
+		w.mutex.Lock()
+		state, ok := w.exitable[payload.BurnTxHash.Hex()]
+		w.mutex.Unlock()
+
+		if !ok {
+			c.JSON(404, gin.H{"msg": "Not Found"})
+			return
+		}
+
+		c.JSON(200, state)
+	})
+
+	w.CheckPointTracker = httptest.NewServer(checkPointTracker)
+	w.StateIDManager = httptest.NewServer(stateIDManager)
+	w.POSExitChecker = httptest.NewServer(posExitChecker)
+
+	return w
+}
+
+// CheckPoint - Marks all child chain blocks upto given one as checkpointed
+func (w *Workers) CheckPoint(blockNumber uint64) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	w.checkPointed = blockNumber
+}
+
+// Sync - Marks given state sync as committed on child chain, moving
+// `lastStateId` ahead, when required
+func (w *Workers) Sync(state *client.CommittedState) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	w.states[state.ID] = state
+
+	if id, ok := big.NewInt(0).SetString(state.ID, 10); ok && id.Uint64() > w.lastStateID {
+		w.lastStateID = id.Uint64()
+	}
+}
+
+// Exit - Marks given POS burn tx as exited on root chain
+func (w *Workers) Exit(burnTxHash common.Hash) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	w.exited[burnTxHash.Hex()] = true
+}
+
+// Exitable - Sets what's to be responded with, when asked about exit time of
+// Plasma withdraw, given its burn tx hash
+func (w *Workers) Exitable(burnTxHash common.Hash, state *client.TransactionState) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	w.exitable[burnTxHash.Hex()] = state
+}
+
+// Close - Stops serving all workers
+func (w *Workers) Close() {
+	w.CheckPointTracker.Close()
+	w.StateIDManager.Close()
+	w.POSExitChecker.Close()
+}
@@ -2,17 +2,36 @@ package main
 
 import (
 	t "app/tracker"
+	"logger"
+	"os"
 
-	"log"
 	"path/filepath"
 )
 
 func main() {
 	absPath, err := filepath.Abs(".env")
 	if err != nil {
-		log.Fatalln("[!] ", err)
+		logger.New("bridge-api").Fatal("Failed to resolve config file path", logger.Fields{"error": err})
 		return
 	}
 
-	t.Run(absPath)
+	// Managing API keys, instead of running service
+	if len(os.Args) > 1 && os.Args[1] == "keys" {
+		t.RunKeys(absPath, os.Args[2:])
+		return
+	}
+
+	// Reconciling transfers across bridge in given window, instead of running service
+	if len(os.Args) > 1 && os.Args[1] == "reconcile" {
+		t.RunReconcile(absPath, os.Args[2:])
+		return
+	}
+
+	// Printing effective config & problems in it, instead of running service
+	if len(os.Args) > 2 && os.Args[1] == "config" && os.Args[2] == "check" {
+		t.CheckConfig(absPath, os.Args[3:])
+		return
+	}
+
+	t.Run(absPath, os.Args[1:])
 }
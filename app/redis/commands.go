@@ -0,0 +1,94 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Increments counter & sets its expiry, only when it's created, so that
+// counter lives for one window
+const incrScript = `local n = redis.call('INCR', KEYS[1])
+if n == 1 then redis.call('PEXPIRE', KEYS[1], ARGV[1]) end
+return n`
+
+// Deletes key, only when it's still holding given value, so that lock
+// acquired by someone else doesn't get released
+const releaseScript = `if redis.call('GET', KEYS[1]) == ARGV[1] then return redis.call('DEL', KEYS[1]) end
+return 0`
+
+// Milliseconds in given duration, as expected by `PX`/ `PEXPIRE`
+func millis(ttl time.Duration) string {
+	return strconv.FormatInt(int64(ttl/time.Millisecond), 10)
+}
+
+// Ping - Checks whether server is reachable
+func (c *Client) Ping(ctx context.Context) error {
+	_, err := c.Do(ctx, "PING")
+	return err
+}
+
+// Get - Value kept against key, false if not present
+func (c *Client) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	reply, err := c.Do(ctx, "GET", key)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if reply == nil {
+		return nil, false, nil
+	}
+
+	value, ok := reply.([]byte)
+	if !ok {
+		return nil, false, fmt.Errorf("unexpected reply %v", reply)
+	}
+
+	return value, true, nil
+}
+
+// Set - Keeps value against key, which expires after given duration
+func (c *Client) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	_, err := c.Do(ctx, "SET", key, string(value), "PX", millis(ttl))
+	return err
+}
+
+// SetNX - Keeps value against key, which expires after given duration, only
+// when key is not already present, returning whether it was kept or not
+func (c *Client) SetNX(ctx context.Context, key string, value []byte, ttl time.Duration) (bool, error) {
+	reply, err := c.Do(ctx, "SET", key, string(value), "PX", millis(ttl), "NX")
+	if err != nil {
+		return false, err
+	}
+
+	return reply != nil, nil
+}
+
+// Del - Deletes given keys
+func (c *Client) Del(ctx context.Context, keys ...string) error {
+	_, err := c.Do(ctx, append([]string{"DEL"}, keys...)...)
+	return err
+}
+
+// IncrWindow - Increments counter kept against key, which expires after given window,
+// counted from its first increment, returning incremented value
+func (c *Client) IncrWindow(ctx context.Context, key string, window time.Duration) (int64, error) {
+	reply, err := c.Do(ctx, "EVAL", incrScript, "1", key, millis(window))
+	if err != nil {
+		return 0, err
+	}
+
+	count, ok := reply.(int64)
+	if !ok {
+		return 0, fmt.Errorf("unexpected reply %v", reply)
+	}
+
+	return count, nil
+}
+
+// Release - Deletes key, only when it's still holding given value
+func (c *Client) Release(ctx context.Context, key string, value []byte) error {
+	_, err := c.Do(ctx, "EVAL", releaseScript, "1", key, string(value))
+	return err
+}
@@ -0,0 +1,279 @@
+// Package redis - Minimal Redis client, speaking RESP2 over TCP, supporting only
+// what bridge API needs i.e. plain commands & Lua scripts, with pool of connections
+package redis
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Maximum number of idle connections kept around, for being reused
+const maxIdle = 16
+
+// Time given to each command, when context has no deadline
+const defaultTimeout = time.Second * time.Duration(5)
+
+// Error - Error reply sent by Redis server
+type Error string
+
+func (e Error) Error() string {
+	return string(e)
+}
+
+// Client - Redis client, safe for concurrent use
+type Client struct {
+	addr     string
+	password string
+	db       int
+	tls      *tls.Config
+	idle     chan *conn
+}
+
+// One connection to server, along with buffered reader
+type conn struct {
+	net.Conn
+	reader *bufio.Reader
+}
+
+// New - Creates client given URL of form `redis://[:password@]host:port[/db]`, where
+// `rediss://` scheme makes it talk to server over TLS
+//
+// Connection is established lazily, when first command is sent
+func New(rawURL string) (*Client, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	if u.Scheme != "redis" && u.Scheme != "rediss" {
+		return nil, fmt.Errorf("unsupported scheme `%s`, expected `redis` or `rediss`", u.Scheme)
+	}
+
+	client := &Client{
+		addr: u.Host,
+		idle: make(chan *conn, maxIdle),
+	}
+
+	if u.Scheme == "rediss" {
+		client.tls = &tls.Config{ServerName: u.Hostname()}
+	}
+
+	if !strings.Contains(client.addr, ":") {
+		client.addr = net.JoinHostPort(client.addr, "6379")
+	}
+
+	if u.User != nil {
+		client.password, _ = u.User.Password()
+	}
+
+	if db := strings.Trim(u.Path, "/"); db != "" {
+		client.db, err = strconv.Atoi(db)
+		if err != nil {
+			return nil, fmt.Errorf("bad database index `%s`", db)
+		}
+	}
+
+	return client, nil
+}
+
+// Picks up idle connection, otherwise opens new one, authenticating
+// & selecting database, if required
+func (c *Client) get(ctx context.Context) (*conn, error) {
+	select {
+	case cn := <-c.idle:
+		return cn, nil
+	default:
+	}
+
+	dialer := net.Dialer{Timeout: defaultTimeout}
+
+	_conn, err := dialer.DialContext(ctx, "tcp", c.addr)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.tls != nil {
+		_tls := tls.Client(_conn, c.tls)
+
+		deadline, ok := ctx.Deadline()
+		if !ok {
+			deadline = time.Now().Add(defaultTimeout)
+		}
+
+		if err := _tls.SetDeadline(deadline); err != nil {
+			_conn.Close()
+			return nil, err
+		}
+
+		if err := _tls.Handshake(); err != nil {
+			_conn.Close()
+			return nil, err
+		}
+
+		_conn = _tls
+	}
+
+	cn := &conn{Conn: _conn, reader: bufio.NewReader(_conn)}
+
+	if c.password != "" {
+		if _, err := cn.do(ctx, "AUTH", c.password); err != nil {
+			cn.Close()
+			return nil, err
+		}
+	}
+
+	if c.db != 0 {
+		if _, err := cn.do(ctx, "SELECT", strconv.Itoa(c.db)); err != nil {
+			cn.Close()
+			return nil, err
+		}
+	}
+
+	return cn, nil
+}
+
+// Puts connection back for reuse, closing it when enough are already idle
+func (c *Client) put(cn *conn) {
+	select {
+	case c.idle <- cn:
+	default:
+		cn.Close()
+	}
+}
+
+// Do - Sends command to server, returning its reply, which is either of
+// string, int64, []byte, nil or []interface{}, where error reply is returned as `Error`
+func (c *Client) Do(ctx context.Context, args ...string) (interface{}, error) {
+	cn, err := c.get(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	reply, err := cn.do(ctx, args...)
+	if err != nil {
+		// Error reply doesn't leave connection in bad state
+		if _, ok := err.(Error); ok {
+			c.put(cn)
+			return nil, err
+		}
+
+		cn.Close()
+		return nil, err
+	}
+
+	c.put(cn)
+	return reply, nil
+}
+
+// Close - Closes all idle connections
+func (c *Client) Close() {
+	for {
+		select {
+		case cn := <-c.idle:
+			cn.Close()
+		default:
+			return
+		}
+	}
+}
+
+// Writes command, encoded as array of bulk strings & reads reply
+func (cn *conn) do(ctx context.Context, args ...string) (interface{}, error) {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(defaultTimeout)
+	}
+
+	if err := cn.SetDeadline(deadline); err != nil {
+		return nil, err
+	}
+
+	var builder strings.Builder
+
+	builder.WriteString(fmt.Sprintf("*%d\r\n", len(args)))
+	for _, v := range args {
+		builder.WriteString(fmt.Sprintf("$%d\r\n%s\r\n", len(v), v))
+	}
+
+	if _, err := io.WriteString(cn, builder.String()); err != nil {
+		return nil, err
+	}
+
+	return cn.read()
+}
+
+// Reads one reply, recursively in case of array
+func (cn *conn) read() (interface{}, error) {
+	line, err := cn.reader.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+
+	line = strings.TrimSuffix(line, "\r\n")
+	if len(line) == 0 {
+		return nil, errors.New("empty reply")
+	}
+
+	switch line[0] {
+
+	case '+':
+		return line[1:], nil
+
+	case '-':
+		return nil, Error(line[1:])
+
+	case ':':
+		return strconv.ParseInt(line[1:], 10, 64)
+
+	case '$':
+		size, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, err
+		}
+
+		if size < 0 {
+			return nil, nil
+		}
+
+		buffer := make([]byte, size+2)
+		if _, err := io.ReadFull(cn.reader, buffer); err != nil {
+			return nil, err
+		}
+
+		return buffer[:size], nil
+
+	case '*':
+		size, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, err
+		}
+
+		if size < 0 {
+			return nil, nil
+		}
+
+		replies := make([]interface{}, 0, size)
+		for i := 0; i < size; i++ {
+			reply, err := cn.read()
+			if err != nil {
+				return nil, err
+			}
+
+			replies = append(replies, reply)
+		}
+
+		return replies, nil
+
+	}
+
+	return nil, fmt.Errorf("unexpected reply `%s`", line)
+}
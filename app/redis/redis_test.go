@@ -0,0 +1,82 @@
+package redis_test
+
+import (
+	"app/internal/testutil"
+	"app/redis"
+	"context"
+	"testing"
+	"time"
+)
+
+func TestNew(t *testing.T) {
+	for _, v := range []string{"redis://localhost", "redis://:password@localhost:6380/1", "rediss://localhost:6380"} {
+		if _, err := redis.New(v); err != nil {
+			t.Fatalf("expected `%s` to be accepted, found %v", v, err)
+		}
+	}
+
+	for _, v := range []string{"http://localhost", "redis://localhost/db"} {
+		if _, err := redis.New(v); err == nil {
+			t.Fatalf("expected `%s` to be rejected", v)
+		}
+	}
+}
+
+func TestCommands(t *testing.T) {
+	server := testutil.NewRedis()
+	defer server.Close()
+
+	client, err := redis.New(server.URL())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	ctx := context.Background()
+
+	if err := client.Ping(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := client.Set(ctx, "key", []byte("value"), time.Minute); err != nil {
+		t.Fatal(err)
+	}
+
+	if v, ok, err := client.Get(ctx, "key"); err != nil || !ok || string(v) != "value" {
+		t.Fatalf("expected value to be kept, found %s, %v, %v", v, ok, err)
+	}
+
+	if ok, err := client.SetNX(ctx, "key", []byte("other"), time.Minute); err != nil || ok {
+		t.Fatalf("expected present key not to be overwritten, found %v, %v", ok, err)
+	}
+
+	if err := client.Release(ctx, "key", []byte("other")); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok, _ := client.Get(ctx, "key"); !ok {
+		t.Fatal("expected key holding other value not to be released")
+	}
+
+	if err := client.Release(ctx, "key", []byte("value")); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok, _ := client.Get(ctx, "key"); ok {
+		t.Fatal("expected key holding same value to be released")
+	}
+
+	for i := int64(1); i <= 2; i++ {
+		if n, err := client.IncrWindow(ctx, "counter", time.Minute); err != nil || n != i {
+			t.Fatalf("expected counter to be %d, found %d, %v", i, n, err)
+		}
+	}
+
+	if err := client.Del(ctx, "counter"); err != nil {
+		t.Fatal(err)
+	}
+
+	if n, _ := client.IncrWindow(ctx, "counter", time.Minute); n != 1 {
+		t.Fatalf("expected deleted counter to start over, found %d", n)
+	}
+}
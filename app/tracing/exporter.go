@@ -0,0 +1,221 @@
+package tracing
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"logger"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Spans are exported in batches of at max these many, at least once
+// every `exportInterval`
+const (
+	maxBatchSize   = 512
+	maxQueueSize   = 4096
+	exportInterval = time.Second * time.Duration(5)
+)
+
+var tracingLog = logger.New("tracing")
+
+// Exporter state, set up using `Configure`
+var (
+	endpoint    string
+	serviceName string
+	queue       []*Span
+	queueMutex  = &sync.Mutex{}
+	httpClient  = &http.Client{Timeout: time.Second * time.Duration(10)}
+	stop        chan struct{}
+	stopped     chan struct{}
+)
+
+// Whether spans are to be recorded or not
+func enabled() bool {
+	queueMutex.Lock()
+	defer queueMutex.Unlock()
+
+	return endpoint != ""
+}
+
+// Configure - Sets OTLP/HTTP collector endpoint ( e.g. `http://localhost:4318` ) where
+// spans are to be exported, on behalf of given service & starts exporting them
+//
+// Empty endpoint disables exporting
+func Configure(collector string, service string) {
+	queueMutex.Lock()
+	defer queueMutex.Unlock()
+
+	endpoint = strings.TrimSuffix(collector, "/")
+	serviceName = service
+
+	if endpoint == "" || stop != nil {
+		return
+	}
+
+	stop = make(chan struct{})
+	stopped = make(chan struct{})
+
+	go func(stop chan struct{}, stopped chan struct{}) {
+		defer close(stopped)
+
+		for {
+			select {
+			case <-stop:
+				export()
+				return
+			case <-time.After(exportInterval):
+				export()
+			}
+		}
+	}(stop, stopped)
+}
+
+// Shutdown - Exports all queued spans & stops exporting
+func Shutdown() {
+	queueMutex.Lock()
+	_stop, _stopped := stop, stopped
+	stop = nil
+	queueMutex.Unlock()
+
+	if _stop == nil {
+		return
+	}
+
+	close(_stop)
+	<-_stopped
+}
+
+// Queues ended span for export, dropping it when queue is already full
+// i.e. collector is not keeping up
+func enqueue(span *Span) {
+	queueMutex.Lock()
+	defer queueMutex.Unlock()
+
+	if len(queue) >= maxQueueSize {
+		return
+	}
+
+	queue = append(queue, span)
+}
+
+// Exports all queued spans, in batches
+func export() {
+	for {
+		queueMutex.Lock()
+		size := len(queue)
+		if size > maxBatchSize {
+			size = maxBatchSize
+		}
+		batch := queue[:size]
+		queue = queue[size:]
+		_endpoint, _service := endpoint, serviceName
+		queueMutex.Unlock()
+
+		if len(batch) == 0 {
+			return
+		}
+
+		if err := send(_endpoint, _service, batch); err != nil {
+			tracingLog.Warn("Failed to export spans", logger.Fields{"error": err, "count": len(batch)})
+			return
+		}
+	}
+}
+
+// Converts attribute value to OTLP `AnyValue`
+func anyValue(v interface{}) map[string]interface{} {
+	switch _v := v.(type) {
+	case string:
+		return map[string]interface{}{"stringValue": _v}
+	case bool:
+		return map[string]interface{}{"boolValue": _v}
+	case int:
+		return map[string]interface{}{"intValue": fmt.Sprintf("%d", _v)}
+	case int64:
+		return map[string]interface{}{"intValue": fmt.Sprintf("%d", _v)}
+	case uint64:
+		return map[string]interface{}{"intValue": fmt.Sprintf("%d", _v)}
+	case float64:
+		return map[string]interface{}{"doubleValue": _v}
+	}
+
+	return map[string]interface{}{"stringValue": fmt.Sprintf("%v", v)}
+}
+
+// Converts attributes to OTLP `KeyValue`(s)
+func keyValues(attrs []Attribute) []map[string]interface{} {
+	_attrs := make([]map[string]interface{}, 0, len(attrs))
+
+	for _, v := range attrs {
+		_attrs = append(_attrs, map[string]interface{}{"key": v.Key, "value": anyValue(v.Value)})
+	}
+
+	return _attrs
+}
+
+// Sends batch of spans to collector, JSON encoded as `ExportTraceServiceRequest`
+func send(endpoint string, service string, batch []*Span) error {
+	spans := make([]map[string]interface{}, 0, len(batch))
+
+	for _, s := range batch {
+		s.mutex.Lock()
+
+		span := map[string]interface{}{
+			"traceId":           hex.EncodeToString(s.traceID[:]),
+			"spanId":            hex.EncodeToString(s.spanID[:]),
+			"name":              s.name,
+			"kind":              int(s.kind),
+			"startTimeUnixNano": fmt.Sprintf("%d", s.start.UnixNano()),
+			"endTimeUnixNano":   fmt.Sprintf("%d", s.end.UnixNano()),
+			"attributes":        keyValues(s.attrs),
+		}
+
+		if s.parentID != [8]byte{} {
+			span["parentSpanId"] = hex.EncodeToString(s.parentID[:])
+		}
+
+		if s.err != nil {
+			span["status"] = map[string]interface{}{"code": 2, "message": s.err.Error()}
+		}
+
+		s.mutex.Unlock()
+
+		spans = append(spans, span)
+	}
+
+	body, err := json.Marshal(map[string]interface{}{
+		"resourceSpans": []interface{}{
+			map[string]interface{}{
+				"resource": map[string]interface{}{
+					"attributes": keyValues([]Attribute{Attr("service.name", service)}),
+				},
+				"scopeSpans": []interface{}{
+					map[string]interface{}{
+						"scope": map[string]interface{}{"name": service},
+						"spans": spans,
+					},
+				},
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	resp, err := httpClient.Post(endpoint+"/v1/traces", "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("collector responded with %d", resp.StatusCode)
+	}
+
+	return nil
+}
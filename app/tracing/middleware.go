@@ -0,0 +1,34 @@
+package tracing
+
+import (
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Middleware - Starts server span for each request, as child of trace context
+// received in `traceparent` header, if any
+func Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := Extract(c.Request.Context(), c.Request.Header)
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+
+		ctx, span := Start(ctx, fmt.Sprintf("%s %s", c.Request.Method, route), KindServer,
+			Attr("http.method", c.Request.Method),
+			Attr("http.route", route))
+		defer span.End()
+
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+
+		span.SetAttributes(Attr("http.status_code", c.Writer.Status()))
+		if c.Writer.Status() >= 500 {
+			span.RecordError(fmt.Errorf("responded with %d", c.Writer.Status()))
+		}
+	}
+}
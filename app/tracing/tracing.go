@@ -0,0 +1,231 @@
+// Package tracing - Minimal tracing, where spans are exported to OpenTelemetry
+// collector using OTLP/HTTP ( JSON encoded ) & trace context is propagated to
+// other services using W3C `traceparent` header
+//
+// When no collector endpoint is configured, spans are not recorded, but
+// still carry trace context so that it can be propagated
+package tracing
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// TraceParentHeader - W3C trace context header, carrying trace ID &
+// parent span ID across services
+const TraceParentHeader = "traceparent"
+
+// Kind - Kind of span, as defined by OpenTelemetry
+type Kind int
+
+// Span kinds, having same numeric values as in OTLP
+const (
+	KindInternal Kind = 1
+	KindServer   Kind = 2
+	KindClient   Kind = 3
+)
+
+// Attribute - Key value pair, attached to span
+type Attribute struct {
+	Key   string
+	Value interface{}
+}
+
+// Attr - Creates attribute, where value is expected to be either of
+// string, bool, int, int64, uint64 or float64, anything else is stringified
+func Attr(key string, value interface{}) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+// Span - One timed operation, being part of trace
+type Span struct {
+	name     string
+	kind     Kind
+	traceID  [16]byte
+	spanID   [8]byte
+	parentID [8]byte
+	start    time.Time
+	end      time.Time
+	attrs    []Attribute
+	err      error
+	sampled  bool
+	mutex    sync.Mutex
+}
+
+type spanKey struct{}
+
+// remote - Trace context received from another service
+type remote struct {
+	traceID [16]byte
+	spanID  [8]byte
+}
+
+type remoteKey struct{}
+
+// Random bytes to be used as trace/ span IDs
+func randomID(buffer []byte) {
+	rand.Read(buffer)
+}
+
+// FromContext - Span carried by context, nil if none
+func FromContext(ctx context.Context) *Span {
+	if ctx == nil {
+		return nil
+	}
+
+	span, _ := ctx.Value(spanKey{}).(*Span)
+	return span
+}
+
+// Start - Starts new span as child of span carried by context, if any, otherwise as
+// child of remote parent extracted from incoming request, otherwise as root of new trace
+//
+// Returned context carries new span, which must be ended by caller
+func Start(ctx context.Context, name string, kind Kind, attrs ...Attribute) (context.Context, *Span) {
+	span := &Span{
+		name:    name,
+		kind:    kind,
+		start:   time.Now(),
+		attrs:   attrs,
+		sampled: enabled(),
+	}
+
+	randomID(span.spanID[:])
+
+	if parent := FromContext(ctx); parent != nil {
+		span.traceID = parent.traceID
+		span.parentID = parent.spanID
+	} else if parent, ok := ctx.Value(remoteKey{}).(*remote); ok {
+		span.traceID = parent.traceID
+		span.parentID = parent.spanID
+	} else {
+		randomID(span.traceID[:])
+	}
+
+	return context.WithValue(ctx, spanKey{}, span), span
+}
+
+// SetAttributes - Attaches attributes to span
+func (s *Span) SetAttributes(attrs ...Attribute) {
+	if s == nil {
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.attrs = append(s.attrs, attrs...)
+}
+
+// RecordError - Marks span as failed, given error is non-nil
+func (s *Span) RecordError(err error) {
+	if s == nil || err == nil {
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.err = err
+}
+
+// End - Ends span, queueing it for export
+func (s *Span) End() {
+	if s == nil {
+		return
+	}
+
+	s.mutex.Lock()
+	s.end = time.Now()
+	s.mutex.Unlock()
+
+	if s.sampled {
+		enqueue(s)
+	}
+}
+
+// TraceID - Hex encoded trace ID of span
+func (s *Span) TraceID() string {
+	if s == nil {
+		return ""
+	}
+
+	return hex.EncodeToString(s.traceID[:])
+}
+
+// Inject - Puts trace context carried by context, in given HTTP headers
+func Inject(ctx context.Context, header http.Header) {
+	span := FromContext(ctx)
+	if span == nil {
+		return
+	}
+
+	flags := "00"
+	if span.sampled {
+		flags = "01"
+	}
+
+	header.Set(TraceParentHeader, fmt.Sprintf("00-%s-%s-%s", hex.EncodeToString(span.traceID[:]), hex.EncodeToString(span.spanID[:]), flags))
+}
+
+// Extract - Reads trace context from given HTTP headers, returning context
+// which carries it, so that next span started becomes its child
+func Extract(ctx context.Context, header http.Header) context.Context {
+	parts := strings.Split(header.Get(TraceParentHeader), "-")
+	if len(parts) != 4 || len(parts[1]) != 32 || len(parts[2]) != 16 {
+		return ctx
+	}
+
+	var _remote remote
+
+	if _, err := hex.Decode(_remote.traceID[:], []byte(parts[1])); err != nil {
+		return ctx
+	}
+	if _, err := hex.Decode(_remote.spanID[:], []byte(parts[2])); err != nil {
+		return ctx
+	}
+
+	return context.WithValue(ctx, remoteKey{}, &_remote)
+}
+
+// Transport - Wraps given transport, so that each outgoing request gets its own
+// client span, which is child of span carried by request's context, while trace
+// context is forwarded in `traceparent` header
+func Transport(base http.RoundTripper) http.RoundTripper {
+	return &tracingTransport{base: base}
+}
+
+type tracingTransport struct {
+	base http.RoundTripper
+}
+
+// RoundTrip - Sends request, tracing it as client span
+func (t *tracingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	url := req.URL.String()
+
+	ctx, span := Start(req.Context(), fmt.Sprintf("%s %s", req.Method, url), KindClient, Attr("http.method", req.Method), Attr("http.url", url))
+	defer span.End()
+
+	// Given request must not be modified
+	req = req.Clone(ctx)
+	Inject(ctx, req.Header)
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	span.SetAttributes(Attr("http.status_code", resp.StatusCode))
+	if resp.StatusCode != http.StatusOK {
+		span.RecordError(fmt.Errorf("responded with %d", resp.StatusCode))
+	}
+
+	return resp, nil
+}
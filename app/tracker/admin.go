@@ -0,0 +1,476 @@
+package tracker
+
+import (
+	"app/nft"
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"logger"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/gin-gonic/gin"
+	"github.com/maticnetwork/deposits-withdrawals-tracking/app/client"
+	"gorm.io/gorm"
+)
+
+// Each tx, rechecked in background by `POST /admin/recheck`, is given this long
+const bulkRecheckTimeout = time.Second * time.Duration(30)
+
+// Middleware authenticating admin using token, present in `Authorization` header
+func adminAuth() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token := getConfig().AdminToken
+		if token == "" {
+			c.AbortWithStatusJSON(403, gin.H{
+				"msg": "Admin API Disabled",
+			})
+			return
+		}
+
+		got := strings.TrimPrefix(c.GetHeader(client.AdminTokenHeader), "Bearer ")
+		if subtle.ConstantTimeCompare([]byte(got), []byte(token)) != 1 {
+			c.AbortWithStatusJSON(401, gin.H{
+				"msg": "Unauthorized",
+			})
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// Status checking function behind one endpoint, which can be run once again
+// by admin, along with chain, in whose table it persists statuses
+type recheckable struct {
+	chain string
+	// Finds out status of tx, where burn tx hash is required only
+	// for Plasma confirm withdraw tx
+	status func(ctx context.Context, txHash common.Hash, burnTxHash common.Hash) *TransactionState
+}
+
+// Status checking functions, which can be run once again by admin, keyed by endpoint
+func recheckables(rootClient *ethclient.Client, childClient *ethclient.Client, db *gorm.DB, _nft *nft.Nft) map[string]*recheckable {
+	posBurn := &recheckable{chain: "child", status: func(ctx context.Context, h common.Hash, _ common.Hash) *TransactionState {
+		return getPOSBurnStatus(ctx, childClient, db, h)
+	}}
+	posExit := &recheckable{chain: "root", status: func(ctx context.Context, h common.Hash, _ common.Hash) *TransactionState {
+		return getPOSExitStatus(ctx, rootClient, db, h)
+	}}
+
+	return map[string]*recheckable{
+		client.ApprovalEndpoint: {chain: "root", status: func(ctx context.Context, h common.Hash, _ common.Hash) *TransactionState {
+			return getApprovalStatus(ctx, rootClient, db, h)
+		}},
+		client.DepositEndpoint: {chain: "root", status: func(ctx context.Context, h common.Hash, _ common.Hash) *TransactionState {
+			return getDepositStatus(ctx, rootClient, db, h)
+		}},
+		client.POSBurnEndpoint: posBurn,
+		"/v1/pos-withdraw":     posBurn,
+		client.POSExitEndpoint: posExit,
+		"/v1/exit":             posExit,
+		client.PlasmaBurnEndpoint: {chain: "child", status: func(ctx context.Context, h common.Hash, _ common.Hash) *TransactionState {
+			return getCheckPointStatus(ctx, childClient, db, h)
+		}},
+		client.PlasmaConfirmEndpoint: {chain: "root", status: func(ctx context.Context, h common.Hash, burnTxHash common.Hash) *TransactionState {
+			return getPlasmaConfirmStatus(ctx, rootClient, db, burnTxHash, h, _nft)
+		}},
+		client.PlasmaExitEndpoint: {chain: "root", status: func(ctx context.Context, h common.Hash, _ common.Hash) *TransactionState {
+			return getPlasmaExitStatus(ctx, rootClient, db, h)
+		}},
+	}
+}
+
+// Persisted rows & history of status of tx
+func adminTx(ctx context.Context, db *gorm.DB, txHash common.Hash) (*client.AdminTxResponse, error) {
+	resp := &client.AdminTxResponse{History: make([]*client.TxStatusChange, 0)}
+
+	if v := findRootChainTx(ctx, db, txHash); v != nil {
+		resp.RootChain = &client.TxRow{Chain: "root", TxHash: v.TransactionHash, Code: v.Code, Message: v.Message}
+	}
+	if v := findChildChainTx(ctx, db, txHash); v != nil {
+		resp.ChildChain = &client.TxRow{Chain: "child", TxHash: v.TransactionHash, Code: v.Code, Message: v.Message}
+	}
+
+	history, err := findStatusHistory(ctx, db, txHash)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, v := range history {
+		resp.History = append(resp.History, &client.TxStatusChange{
+			Chain:     v.Chain,
+			TxHash:    v.TransactionHash,
+			Code:      v.Code,
+			Message:   v.Message,
+			RequestID: v.RequestID,
+			CreatedAt: v.CreatedAt,
+		})
+	}
+
+	return resp, nil
+}
+
+// Deletes persisted status of tx, from given chain's table, where empty
+// chain means from both, returning number of rows deleted
+func deleteTxStatus(ctx context.Context, db *gorm.DB, chain string, txHash common.Hash) (int64, error) {
+	var deleted int64
+
+	if chain == "" || chain == "root" {
+		result := db.WithContext(ctx).Where("txhash = ?", txHash.Hex()).Delete(&RootChain{})
+		if result.Error != nil {
+			return deleted, result.Error
+		}
+
+		deleted += result.RowsAffected
+	}
+
+	if chain == "" || chain == "child" {
+		result := db.WithContext(ctx).Where("txhash = ?", txHash.Hex()).Delete(&ChildChain{})
+		if result.Error != nil {
+			return deleted, result.Error
+		}
+
+		deleted += result.RowsAffected
+	}
+
+	return deleted, nil
+}
+
+// Evicts cached statuses of tx, served by endpoints accepting tx hash only, along
+// with that of Plasma confirm withdraw tx, when burn tx hash is known
+//
+// Statuses cached by `/v2/withdraw` are keyed by all tx hashes of flow,
+// so they're left to expire on their own
+func invalidateCachedStatus(ctx context.Context, txHash common.Hash, burnTxHash common.Hash) {
+	keys := make([]string, 0)
+
+	for _, v := range []string{client.ApprovalEndpoint, client.DepositEndpoint, client.POSBurnEndpoint, "/v1/pos-withdraw", client.POSExitEndpoint, "/v1/exit", client.PlasmaBurnEndpoint, client.PlasmaExitEndpoint} {
+		keys = append(keys, "status:"+strings.Join([]string{v, txHash.Hex()}, "/"))
+	}
+
+	if !isEmptyTxHash(burnTxHash) {
+		keys = append(keys, "status:"+strings.Join([]string{client.PlasmaConfirmEndpoint, burnTxHash.Hex(), txHash.Hex()}, "/"))
+	}
+
+	if err := sharedCache.Delete(ctx, keys...); err != nil {
+		recordSharedCacheFailure("delete", err)
+	}
+}
+
+// Finds out status of tx once again, ignoring cached & persisted one, using given
+// status checking function
+//
+// If freshly found out status is not degraded, but persisted row still disagrees
+// with it, row is deleted, given that status checking function didn't overwrite it,
+// meaning it's stale
+func recheckTx(ctx context.Context, db *gorm.DB, r *recheckable, txHash common.Hash, burnTxHash common.Hash) *TransactionState {
+	status := r.status(withoutCache(ctx), txHash, burnTxHash)
+	if status == nil {
+		status = erroredStatus(fmt.Errorf("failed to find out status"))
+	}
+
+	if !status.Degraded {
+		stale := false
+
+		switch r.chain {
+		case "root":
+			v := findRootChainTx(ctx, db, txHash)
+			stale = v != nil && v.Code != status.Code
+		case "child":
+			v := findChildChainTx(ctx, db, txHash)
+			stale = v != nil && v.Code != status.Code
+		}
+
+		if stale {
+			if _, err := deleteTxStatus(ctx, db, r.chain, txHash); err != nil {
+				dbLog.Ctx(ctx).Error("Failed to delete stale tx status", logger.Fields{"error": err, "txHash": txHash.Hex()})
+			}
+		}
+	}
+
+	invalidateCachedStatus(ctx, txHash, burnTxHash)
+
+	return status
+}
+
+// Statuses, which transfers move on from, without being asked about, along with chain,
+// in whose table it's persisted & endpoint, whose status checking function finds it out
+var nonTerminalStates = []stuckState{
+	{code: 1, name: "en_route", chain: "root", endpoint: client.DepositEndpoint},
+	{code: -13, name: "exit_not_processed", chain: "root", endpoint: client.PlasmaExitEndpoint},
+	{code: -3, name: "burnt", chain: "child", endpoint: client.PlasmaBurnEndpoint},
+	{code: -4, name: "checkpointed", chain: "child", endpoint: client.PlasmaBurnEndpoint},
+}
+
+// Persisted non-terminal status of tx, along with how it's to be rechecked
+type nonTerminalTx struct {
+	stuckState
+	txHash common.Hash
+}
+
+// Persisted non-terminal statuses i.e. deposits en route, burn tx(s) on child chain,
+// which are yet to be checkpointed or exited & Plasma exits yet to be processed,
+// from both tables, at max `limit` many
+func findNonTerminalTxs(ctx context.Context, db *gorm.DB, limit int) ([]*nonTerminalTx, error) {
+	found := make([]*nonTerminalTx, 0)
+
+	for _, chain := range []string{"root", "child"} {
+		table := RootChain{}.TableName()
+		if chain == "child" {
+			table = ChildChain{}.TableName()
+		}
+
+		codes := make([]int, 0)
+		for _, v := range nonTerminalStates {
+			if v.chain == chain {
+				codes = append(codes, v.code)
+			}
+		}
+
+		var rows []stuckRow
+
+		if err := db.WithContext(ctx).Table(table).Where("code in ?", codes).Order("txhash").Limit(limit - len(found)).Find(&rows).Error; err != nil {
+			return nil, err
+		}
+
+		for _, row := range rows {
+			for _, v := range nonTerminalStates {
+				if v.chain == chain && v.code == row.Code {
+					found = append(found, &nonTerminalTx{stuckState: v, txHash: common.HexToHash(row.TransactionHash)})
+					break
+				}
+			}
+		}
+
+		if len(found) >= limit {
+			break
+		}
+	}
+
+	if len(found) > limit {
+		found = found[:limit]
+	}
+
+	return found, nil
+}
+
+// Finds out status of each persisted non-terminal tx once again, using status checking
+// function of its chain & code, same as `POST /admin/tx/:hash/recheck` does
+func recheckNonTerminalTxs(ctx context.Context, db *gorm.DB, _recheckables map[string]*recheckable, found []*nonTerminalTx) map[common.Hash]*TransactionState {
+	hashes := make([]common.Hash, 0, len(found))
+	for _, v := range found {
+		hashes = append(hashes, v.txHash)
+	}
+
+	return resolveStatuses(ctx, hashes, func(ctx context.Context, i int) *TransactionState {
+		r, ok := _recheckables[found[i].endpoint]
+		if !ok {
+			return nil
+		}
+
+		_ctx, cancel := context.WithTimeout(ctx, bulkRecheckTimeout)
+		defer cancel()
+
+		return recheckTx(_ctx, db, r, hashes[i], common.Hash{})
+	})
+}
+
+// Registers admin endpoints, for inspecting & correcting persisted statuses,
+// where each action is recorded in audit log
+func registerAdminRoutes(router *gin.Engine, rootClient *ethclient.Client, childClient *ethclient.Client, db *gorm.DB, _nft *nft.Nft) {
+	_recheckables := recheckables(rootClient, childClient, db, _nft)
+
+	admin := router.Group("/admin", adminAuth())
+
+	{
+
+		// Persisted rows & history of status of tx
+		admin.GET("/tx/:hash", func(c *gin.Context) {
+			txHash := common.HexToHash(c.Param("hash"))
+
+			recordAudit(c, db, "view", txHash.Hex(), nil)
+
+			resp, err := adminTx(c.Request.Context(), db, txHash)
+			if err != nil {
+				c.JSON(500, gin.H{
+					"msg": "Failed to Look Up Tx",
+				})
+				return
+			}
+
+			c.JSON(200, resp)
+		})
+
+		// Finds out status of tx once again, bypassing cache & persisted status
+		admin.POST("/tx/:hash/recheck", func(c *gin.Context) {
+			txHash := common.HexToHash(c.Param("hash"))
+
+			var payload client.RecheckRequest
+
+			if err := c.ShouldBindJSON(&payload); err != nil {
+				c.JSON(400, gin.H{
+					"msg": "Bad Payload",
+				})
+				return
+			}
+
+			r, ok := _recheckables[payload.Endpoint]
+			if !ok {
+				c.JSON(400, gin.H{
+					"msg": "Unknown Endpoint",
+				})
+				return
+			}
+
+			if payload.Endpoint == client.PlasmaConfirmEndpoint && isEmptyTxHash(payload.BurnTxHash) {
+				c.JSON(400, gin.H{
+					"msg": "Burn Tx Hash Required",
+				})
+				return
+			}
+
+			before, err := adminTx(c.Request.Context(), db, txHash)
+			if err != nil {
+				c.JSON(500, gin.H{
+					"msg": "Failed to Look Up Tx",
+				})
+				return
+			}
+
+			status := recheckTx(c.Request.Context(), db, r, txHash, payload.BurnTxHash)
+
+			after, err := adminTx(c.Request.Context(), db, txHash)
+			if err != nil {
+				c.JSON(500, gin.H{
+					"msg": "Failed to Look Up Tx",
+				})
+				return
+			}
+
+			recordAudit(c, db, "recheck", txHash.Hex(), gin.H{"endpoint": payload.Endpoint, "burnTxHash": payload.BurnTxHash.Hex(), "code": status.Code, "degraded": status.Degraded})
+
+			c.JSON(200, &client.RecheckResponse{Before: before, Status: status, After: after})
+		})
+
+		// Deletes persisted status of tx, from `chain` i.e. `root`/ `child`
+		// table if given, otherwise from both
+		admin.DELETE("/tx/:hash", func(c *gin.Context) {
+			txHash := common.HexToHash(c.Param("hash"))
+
+			chain := c.Query("chain")
+			if !(chain == "" || chain == "root" || chain == "child") {
+				c.JSON(400, gin.H{
+					"msg": "Bad Chain",
+				})
+				return
+			}
+
+			deleted, err := deleteTxStatus(c.Request.Context(), db, chain, txHash)
+			if err != nil {
+				c.JSON(500, gin.H{
+					"msg": "Failed to Delete Tx",
+				})
+				return
+			}
+
+			invalidateCachedStatus(c.Request.Context(), txHash, common.Hash{})
+
+			recordAudit(c, db, "delete", txHash.Hex(), gin.H{"chain": chain, "deleted": deleted})
+
+			c.JSON(200, &client.DeleteResponse{Deleted: deleted})
+		})
+
+		// Rechecks all persisted non-terminal statuses, in background, after
+		// responding with their count
+		admin.POST("/recheck", func(c *gin.Context) {
+			limit := getConfig().BulkRecheckLimit
+
+			if v := c.Query("limit"); v != "" {
+				_limit, err := strconv.ParseUint(v, 10, 32)
+				if err != nil || _limit == 0 {
+					c.JSON(400, gin.H{
+						"msg": "Bad Limit",
+					})
+					return
+				}
+
+				limit = int(_limit)
+			}
+
+			rows, err := findNonTerminalTxs(c.Request.Context(), db, limit)
+			if err != nil {
+				c.JSON(500, gin.H{
+					"msg": "Failed to Look Up Txs",
+				})
+				return
+			}
+
+			recordAudit(c, db, "bulk-recheck", "non-terminal", gin.H{"limit": limit, "count": len(rows)})
+
+			// Background work is correlated with this request, using its ID
+			ctx := logger.WithRequestID(context.Background(), logger.RequestID(c.Request.Context()))
+
+			inBackground(func() {
+				statuses := recheckNonTerminalTxs(ctx, db, _recheckables, rows)
+
+				changed := 0
+				for _, v := range rows {
+					if status, ok := statuses[v.txHash]; ok && !status.Degraded && status.Code != v.code {
+						changed++
+					}
+				}
+
+				appLog.Ctx(ctx).Info("Rechecked non-terminal tx statuses", logger.Fields{"count": len(rows), "changed": changed})
+			})
+
+			c.JSON(202, &client.BulkRecheckResponse{Count: len(rows)})
+		})
+
+		// Recent admin actions, latest first
+		admin.GET("/audit", func(c *gin.Context) {
+			limit := 50
+
+			if v := c.Query("limit"); v != "" {
+				_limit, err := strconv.ParseUint(v, 10, 32)
+				if err != nil || _limit == 0 {
+					c.JSON(400, gin.H{
+						"msg": "Bad Limit",
+					})
+					return
+				}
+
+				limit = int(_limit)
+			}
+
+			var entries []AdminAudit
+
+			if err := db.WithContext(c.Request.Context()).Order("id desc").Limit(limit).Find(&entries).Error; err != nil {
+				c.JSON(500, gin.H{
+					"msg": "Failed to Look Up Audit Log",
+				})
+				return
+			}
+
+			resp := make([]*client.AuditEntry, 0, len(entries))
+			for _, v := range entries {
+				resp = append(resp, &client.AuditEntry{
+					ID:        v.ID,
+					Actor:     v.Actor,
+					Action:    v.Action,
+					Target:    v.Target,
+					Details:   v.Details,
+					RequestID: v.RequestID,
+					IP:        v.IP,
+					CreatedAt: v.CreatedAt,
+				})
+			}
+
+			c.JSON(200, resp)
+		})
+
+	}
+}
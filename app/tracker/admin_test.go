@@ -0,0 +1,85 @@
+package tracker
+
+import (
+	"app/internal/testutil"
+	"context"
+	"database/sql/driver"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/maticnetwork/deposits-withdrawals-tracking/app/client"
+)
+
+func TestNonTerminalTxsRechecked(t *testing.T) {
+	db, _db := testutil.NewDB()
+
+	columns := []string{"txhash", "code", "msg"}
+	_db.Respond = func(query string, args []driver.Value) (*testutil.Rows, error) {
+		switch {
+		case strings.Contains(query, `FROM "root_chain" WHERE code in`):
+			return &testutil.Rows{Columns: columns, Values: [][]driver.Value{
+				{common.HexToHash("0x01").Hex(), int64(1), "En Route"},
+				{common.HexToHash("0x02").Hex(), int64(-13), "Plasma exit called, but not exited"},
+			}}, nil
+		case strings.Contains(query, `FROM "child_chain" WHERE code in`):
+			return &testutil.Rows{Columns: columns, Values: [][]driver.Value{
+				{common.HexToHash("0x03").Hex(), int64(-3), "Burnt"},
+				{common.HexToHash("0x04").Hex(), int64(-4), "Checkpointed"},
+			}}, nil
+		}
+
+		return nil, nil
+	}
+
+	ctx := context.Background()
+
+	found, err := findNonTerminalTxs(ctx, db, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(found) != 4 {
+		t.Fatalf("expected non-terminal txs from both tables, found %d", len(found))
+	}
+
+	if limited, _ := findNonTerminalTxs(ctx, db, 1); len(limited) != 1 {
+		t.Fatalf("expected at max 1 non-terminal tx, found %d", len(limited))
+	}
+
+	// Each tx is rechecked using status checking function of its endpoint
+	mutex := sync.Mutex{}
+	rechecked := make(map[common.Hash]string)
+
+	_recheckables := make(map[string]*recheckable)
+	for _, v := range []string{client.DepositEndpoint, client.PlasmaExitEndpoint, client.PlasmaBurnEndpoint} {
+		endpoint := v
+
+		_recheckables[endpoint] = &recheckable{chain: "root", status: func(ctx context.Context, h common.Hash, _ common.Hash) *TransactionState {
+			mutex.Lock()
+			defer mutex.Unlock()
+
+			rechecked[h] = endpoint
+			return &TransactionState{Code: 0, Message: "Done"}
+		}}
+	}
+
+	statuses := recheckNonTerminalTxs(ctx, db, _recheckables, found)
+	if len(statuses) != 4 {
+		t.Fatalf("expected all non-terminal txs to be rechecked, found %d", len(statuses))
+	}
+
+	expected := map[common.Hash]string{
+		common.HexToHash("0x01"): client.DepositEndpoint,
+		common.HexToHash("0x02"): client.PlasmaExitEndpoint,
+		common.HexToHash("0x03"): client.PlasmaBurnEndpoint,
+		common.HexToHash("0x04"): client.PlasmaBurnEndpoint,
+	}
+
+	for k, v := range expected {
+		if rechecked[k] != v {
+			t.Fatalf("expected %s to be rechecked using %s, found %s", k.Hex(), v, rechecked[k])
+		}
+	}
+}
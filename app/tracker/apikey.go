@@ -0,0 +1,395 @@
+package tracker
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"logger"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/gin-contrib/cors"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// APIKeyHeader - HTTP header carrying API key, issued to partner dApp
+const APIKeyHeader = "X-API-Key"
+
+// API keys are looked up in DB at max once in this duration, while allowed
+// origins of all keys are reloaded as often
+const apiKeyTTL = time.Second * time.Duration(30)
+
+// Accumulated usage is written to DB this often
+const usageFlushInterval = time.Second * time.Duration(10)
+
+// APIKey - Key issued to partner dApp, where only SHA256 hash of key is persisted,
+// along with its prefix, so that it can be identified
+type APIKey struct {
+	ID             uint      `gorm:"column:id;primaryKey"`
+	Name           string    `gorm:"column:name;type:varchar;not null"`
+	Prefix         string    `gorm:"column:prefix;type:varchar(16);not null"`
+	Hash           string    `gorm:"column:hash;type:char(64);uniqueIndex;not null"`
+	RateLimit      int64     `gorm:"column:rate_limit;not null;default:0"`
+	DailyQuota     int64     `gorm:"column:daily_quota;not null;default:0"`
+	AllowedOrigins string    `gorm:"column:allowed_origins;type:varchar;not null;default:''"`
+	Disabled       bool      `gorm:"column:disabled;not null;default:false"`
+	CreatedAt      time.Time `gorm:"column:created_at"`
+}
+
+// TableName - Overriding default table name
+func (APIKey) TableName() string {
+	return "api_keys"
+}
+
+// Origins - Origins, browser requests from which are allowed to use this key,
+// where empty means any origin
+func (a *APIKey) Origins() []string {
+	origins := make([]string, 0)
+
+	for _, v := range strings.Split(a.AllowedOrigins, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			origins = append(origins, v)
+		}
+	}
+
+	return origins
+}
+
+// Whether browser request from given origin can use this key or not
+func (a *APIKey) allows(origin string) bool {
+	origins := a.Origins()
+	if origin == "" || len(origins) == 0 {
+		return true
+	}
+
+	for _, v := range origins {
+		if v == origin {
+			return true
+		}
+	}
+
+	return false
+}
+
+// APIUsage - Number of requests made using API key, per day
+type APIUsage struct {
+	KeyID     uint   `gorm:"column:key_id;primaryKey"`
+	Day       string `gorm:"column:day;type:char(10);primaryKey"`
+	Requests  int64  `gorm:"column:requests;not null;default:0"`
+	Throttled int64  `gorm:"column:throttled;not null;default:0"`
+}
+
+// TableName - Overriding default table name
+func (APIUsage) TableName() string {
+	return "api_usage"
+}
+
+// Hex encoded SHA256 hash of API key, which is what gets persisted
+func hashAPIKey(key string) string {
+	hash := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(hash[:])
+}
+
+// Generates new API key, which is to be handed over to partner, while
+// only its hash & prefix are persisted
+func generateAPIKey() (string, error) {
+	buffer := make([]byte, 24)
+	if _, err := rand.Read(buffer); err != nil {
+		return "", err
+	}
+
+	return "bk_" + hex.EncodeToString(buffer), nil
+}
+
+// Cached API keys, looked up by their hash, where unknown ones are cached too,
+// so that bad keys don't hit DB every time
+var apiKeyCache = newTTLCache("api_keys", cacheSize)
+
+// Looks up API key, served from cache if present, returns nil if key is unknown
+func findAPIKey(ctx context.Context, db *gorm.DB, key string) (*APIKey, error) {
+	hash := hashAPIKey(key)
+
+	if v, ok := apiKeyCache.get(hash); ok {
+		apiKey, _ := v.(*APIKey)
+		return apiKey, nil
+	}
+
+	var apiKey APIKey
+
+	if err := db.WithContext(ctx).Where("hash = ?", hash).First(&apiKey).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			apiKeyCache.set(hash, (*APIKey)(nil), apiKeyTTL)
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	apiKeyCache.set(hash, &apiKey, apiKeyTTL)
+	return &apiKey, nil
+}
+
+// Origins allowed by all enabled API keys, reloaded once `apiKeyTTL` is over
+var (
+	origins        map[string]bool
+	anyOrigin      bool
+	originsExpires time.Time
+	originsMutex   = &sync.Mutex{}
+)
+
+// Whether browser requests from given origin are to be allowed or not, where all
+// origins are allowed, when API key is not required, otherwise only those, allowed
+// by at least one enabled API key
+//
+// Actual request is checked once again, against origins allowed by key it carries
+func allowedOrigin(db *gorm.DB) func(string) bool {
+	return func(origin string) bool {
+		if !getConfig().RequireAPIKey {
+			return true
+		}
+
+		originsMutex.Lock()
+		defer originsMutex.Unlock()
+
+		if time.Now().After(originsExpires) {
+			var keys []APIKey
+
+			if err := db.Where("disabled = ?", false).Find(&keys).Error; err != nil {
+				appLog.Warn("Failed to load allowed origins", logger.Fields{"error": err})
+			} else {
+				origins = make(map[string]bool)
+				anyOrigin = false
+
+				for _, k := range keys {
+					if len(k.Origins()) == 0 {
+						anyOrigin = true
+					}
+
+					for _, v := range k.Origins() {
+						origins[v] = true
+					}
+				}
+
+				originsExpires = time.Now().Add(apiKeyTTL)
+			}
+		}
+
+		return anyOrigin || origins[origin]
+	}
+}
+
+// CORS middleware, allowing origins, as decided by `allowedOrigin`, where API key
+// & request ID headers can be sent, while rate limiting headers can be read
+func corsMiddleware(db *gorm.DB) gin.HandlerFunc {
+	config := cors.DefaultConfig()
+
+	config.AllowOriginFunc = allowedOrigin(db)
+	config.AddAllowHeaders(APIKeyHeader, logger.RequestIDHeader, "traceparent")
+	config.AddExposeHeaders(logger.RequestIDHeader, "Retry-After")
+
+	return cors.New(config)
+}
+
+// Key, under which authenticated API key is kept in gin context
+const apiKeyContextKey = "apiKey"
+
+// API key used for making this request, nil if anonymous
+func apiKeyOf(c *gin.Context) *APIKey {
+	v, ok := c.Get(apiKeyContextKey)
+	if !ok {
+		return nil
+	}
+
+	apiKey, _ := v.(*APIKey)
+	return apiKey
+}
+
+// Middleware authenticating request using API key, present in `X-API-Key` header,
+// enforcing origins allowed & daily quota of key, while accounting its usage
+//
+// Requests without key are let through as anonymous, unless key is required
+func authenticate(db *gorm.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(APIKeyHeader)
+
+		if key == "" {
+			if getConfig().RequireAPIKey {
+				c.AbortWithStatusJSON(401, gin.H{
+					"msg": "Missing API Key",
+				})
+				return
+			}
+
+			c.Next()
+			return
+		}
+
+		apiKey, err := findAPIKey(c.Request.Context(), db, key)
+		if err != nil {
+			appLog.Ctx(c.Request.Context()).Error("Failed to look up API key", logger.Fields{"error": err})
+			c.AbortWithStatusJSON(503, gin.H{
+				"msg": "Service Unavailable",
+			})
+			return
+		}
+
+		if apiKey == nil || apiKey.Disabled {
+			c.AbortWithStatusJSON(401, gin.H{
+				"msg": "Invalid API Key",
+			})
+			return
+		}
+
+		if !apiKey.allows(c.GetHeader("Origin")) {
+			c.AbortWithStatusJSON(403, gin.H{
+				"msg": "Origin Not Allowed",
+			})
+			return
+		}
+
+		c.Set(apiKeyContextKey, apiKey)
+
+		if apiKey.DailyQuota > 0 {
+			day := time.Now().UTC().Format("2006-01-02")
+
+			count, err := sharedCache.IncrWindow(c.Request.Context(), strings.Join([]string{"quota", fmt.Sprintf("%d", apiKey.ID), day}, ":"), time.Hour*time.Duration(24))
+			if err != nil {
+				recordSharedCacheFailure("incr", err)
+			} else if count > apiKey.DailyQuota {
+				recordUsage(apiKey.ID, false)
+				metrics.GetOrRegisterCounter("bridge/http/quota_exceeded", registry).Inc(1)
+
+				c.AbortWithStatusJSON(429, gin.H{
+					"msg": "Daily Quota Exceeded",
+				})
+				return
+			}
+		}
+
+		c.Next()
+
+		recordUsage(apiKey.ID, c.Writer.Status() != 429)
+	}
+}
+
+// Usage of API keys, accumulated since last flush, per key & day
+type usage struct {
+	requests  int64
+	throttled int64
+}
+
+var (
+	usages      = make(map[uint]map[string]*usage)
+	usagesMutex = &sync.Mutex{}
+)
+
+// Accounts one request made using API key, where throttled ones
+// are accounted separately
+func recordUsage(keyID uint, served bool) {
+	usagesMutex.Lock()
+	defer usagesMutex.Unlock()
+
+	day := time.Now().UTC().Format("2006-01-02")
+
+	if _, ok := usages[keyID]; !ok {
+		usages[keyID] = make(map[string]*usage)
+	}
+
+	_usage, ok := usages[keyID][day]
+	if !ok {
+		_usage = &usage{}
+		usages[keyID][day] = _usage
+	}
+
+	if served {
+		_usage.requests++
+	} else {
+		_usage.throttled++
+	}
+}
+
+// Puts usage, which couldn't be written to DB, back to be written in next flush,
+// adding to what's accumulated meanwhile
+func requeueUsage(keyID uint, day string, v *usage) {
+	usagesMutex.Lock()
+	defer usagesMutex.Unlock()
+
+	if _, ok := usages[keyID]; !ok {
+		usages[keyID] = make(map[string]*usage)
+	}
+
+	_usage, ok := usages[keyID][day]
+	if !ok {
+		usages[keyID][day] = v
+		return
+	}
+
+	_usage.requests += v.requests
+	_usage.throttled += v.throttled
+}
+
+// Writes accumulated usage of API keys to DB, adding to what's already there,
+// where usage failed to be written is kept for next flush
+func flushUsage(db *gorm.DB) {
+	usagesMutex.Lock()
+	_usages := usages
+	usages = make(map[uint]map[string]*usage)
+	usagesMutex.Unlock()
+
+	for keyID, days := range _usages {
+		for day, v := range days {
+
+			if err := db.Clauses(clause.OnConflict{
+				Columns: []clause.Column{{Name: "key_id"}, {Name: "day"}},
+				DoUpdates: clause.Assignments(map[string]interface{}{
+					"requests":  gorm.Expr("api_usage.requests + ?", v.requests),
+					"throttled": gorm.Expr("api_usage.throttled + ?", v.throttled),
+				}),
+			}).Create(&APIUsage{
+				KeyID:     keyID,
+				Day:       day,
+				Requests:  v.requests,
+				Throttled: v.throttled,
+			}).Error; err != nil {
+				dbLog.Error("Failed to write API key usage", logger.Fields{"error": err, "keyId": keyID, "day": day})
+				requeueUsage(keyID, day, v)
+			}
+
+		}
+	}
+}
+
+// Starts writing accumulated usage of API keys to DB, periodically, returning
+// function to be invoked for stopping it, which writes out what's left
+func startUsageAccounting(db *gorm.DB) func() {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(usageFlushInterval):
+			}
+
+			flushUsage(db)
+		}
+	}()
+
+	return func() {
+		cancel()
+		<-done
+
+		flushUsage(db)
+	}
+}
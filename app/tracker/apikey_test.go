@@ -0,0 +1,64 @@
+package tracker
+
+import (
+	"app/internal/testutil"
+	"database/sql/driver"
+	"errors"
+	"io/ioutil"
+	"logger"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+// Usage accumulated for given key, not yet written to DB
+func pendingUsage(keyID uint) (int64, int64) {
+	usagesMutex.Lock()
+	defer usagesMutex.Unlock()
+
+	var requests, throttled int64
+	for _, v := range usages[keyID] {
+		requests += v.requests
+		throttled += v.throttled
+	}
+
+	return requests, throttled
+}
+
+func TestUsageRequeuedOnFailure(t *testing.T) {
+	logger.SetOutput(ioutil.Discard)
+
+	db, _db := testutil.NewDB()
+
+	var failing int32 = 1
+	_db.Respond = func(query string, args []driver.Value) (*testutil.Rows, error) {
+		if strings.Contains(query, `INSERT INTO "api_usage"`) && atomic.LoadInt32(&failing) == 1 {
+			return nil, errors.New("connection refused")
+		}
+
+		return nil, nil
+	}
+
+	recordUsage(101, true)
+	recordUsage(101, false)
+	flushUsage(db)
+
+	if requests, throttled := pendingUsage(101); requests != 1 || throttled != 1 {
+		t.Fatalf("expected usage failed to be written to be kept, found %d, %d", requests, throttled)
+	}
+
+	// Usage accumulated meanwhile is added to what's kept
+	recordUsage(101, true)
+	atomic.StoreInt32(&failing, 0)
+
+	stop := startUsageAccounting(db)
+	stop()
+
+	if requests, throttled := pendingUsage(101); requests != 0 || throttled != 0 {
+		t.Fatalf("expected usage to be written out on stop, found %d, %d", requests, throttled)
+	}
+
+	if !_db.Ran(`INSERT INTO "api_usage"`) {
+		t.Fatal("expected usage to be written to DB")
+	}
+}
@@ -1,6 +1,8 @@
 package tracker
 
 import (
+	"app/tracing"
+	"context"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"gorm.io/gorm"
@@ -10,10 +12,13 @@ import (
 //
 // Due to the fact, token approval must be performed before calling `depositFor` on root chain contract,
 // only then root contract can call `transferFrom` on token being deposited
-func getApprovalStatus(client *ethclient.Client, db *gorm.DB, txHash common.Hash) *TransactionState {
+func getApprovalStatus(ctx context.Context, client *ethclient.Client, db *gorm.DB, txHash common.Hash) *TransactionState {
+	ctx, span := tracing.Start(ctx, "getApprovalStatus", tracing.KindInternal, tracing.Attr("tx.hash", txHash.Hex()))
+	defer span.End()
+
 	// If record is present in database & confirmed, then we're simply going to read status from database
 	// and return to client
-	if status := getRootChaintxStatusFromDB(db, txHash); status != nil {
+	if status := getRootChaintxStatusFromDB(ctx, db, txHash); status != nil {
 		if status.Code == 5 || status.Code == 6 {
 			return &TransactionState{
 				Code:    status.Code,
@@ -22,7 +27,10 @@ func getApprovalStatus(client *ethclient.Client, db *gorm.DB, txHash common.Hash
 		}
 	}
 
-	receipt := getTransactionReceipt(client, txHash)
+	receipt, err := getTransactionReceipt(ctx, client, txHash)
+	if err != nil {
+		return receiptFailedStatus(ctx, client, txHash, err, 7, "Pending")
+	}
 	if receipt == nil {
 		return &TransactionState{
 			Code:    7,
@@ -31,7 +39,7 @@ func getApprovalStatus(client *ethclient.Client, db *gorm.DB, txHash common.Hash
 	}
 
 	if receipt.Status == 0 {
-		putRootChainTxStatusInDB(db, txHash, 6, "Failed")
+		putRootChainTxStatusInDB(ctx, db, txHash, 6, "Failed")
 
 		return &TransactionState{
 			Code:    6,
@@ -39,7 +47,7 @@ func getApprovalStatus(client *ethclient.Client, db *gorm.DB, txHash common.Hash
 		}
 	}
 
-	putRootChainTxStatusInDB(db, txHash, 5, "Approved")
+	putRootChainTxStatusInDB(ctx, db, txHash, 5, "Approved")
 
 	return &TransactionState{
 		Code:    5,
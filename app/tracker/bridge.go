@@ -0,0 +1,169 @@
+package tracker
+
+import (
+	"app/tracing"
+	"context"
+	"fmt"
+	"logger"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/maticnetwork/deposits-withdrawals-tracking/app/client"
+	"gorm.io/gorm"
+)
+
+// Topics of events emitted by child chain tokens, when burnt for withdrawing
+var (
+	// ERC20/ ERC721 `Transfer`, emitted by child tokens of both bridges, where
+	// burning is transfer to zero address
+	transferTopic = crypto.Keccak256Hash([]byte("Transfer(address,address,uint256)"))
+	// ERC1155 transfers, child tokens of which exist only on POS bridge
+	transferSingleTopic = crypto.Keccak256Hash([]byte("TransferSingle(address,address,address,uint256,uint256)"))
+	transferBatchTopic  = crypto.Keccak256Hash([]byte("TransferBatch(address,address,address,uint256[],uint256[])"))
+	// `Withdraw`, emitted only by plasma child tokens, along with `LogTransfer`
+	plasmaWithdrawTopic = crypto.Keccak256Hash([]byte("Withdraw(address,address,uint256,uint256,uint256)"))
+)
+
+// Status code responded with, when bridge declared in payload is not the one
+// burn tx was performed on
+const bridgeMismatchCode = -14
+
+// Status code responded with, when bridge is neither declared in payload nor
+// can be inferred from burn tx
+const unknownBridgeCode = -15
+
+// Whether `Transfer`, `TransferSingle` or `TransferBatch` log is transfer to zero
+// address, where ERC721 `Transfer` carries token ID as 4th topic, unlike ERC20 one
+func isBurnTransfer(_log *types.Log) bool {
+	switch _log.Topics[0] {
+	case transferTopic:
+		return (len(_log.Topics) == 3 || len(_log.Topics) == 4) && _log.Topics[2] == (common.Hash{})
+
+	case transferSingleTopic, transferBatchTopic:
+		return len(_log.Topics) == 4 && _log.Topics[3] == (common.Hash{})
+
+	}
+
+	return false
+}
+
+// Addresses of child tokens burnt in tx, along with bridge they're of, as
+// recognised from logs emitted, empty when it can't be recognised
+func burntTokens(receipt *types.Receipt) ([]common.Address, string) {
+	tokens := make([]common.Address, 0, len(receipt.Logs))
+
+	plasma := false
+
+	for _, _log := range receipt.Logs {
+		if len(_log.Topics) == 0 {
+			continue
+		}
+
+		switch _log.Topics[0] {
+		case plasmaWithdrawTopic:
+			plasma = true
+			tokens = append(tokens, _log.Address)
+
+		case transferTopic, transferSingleTopic, transferBatchTopic:
+			if isBurnTransfer(_log) {
+				tokens = append(tokens, _log.Address)
+			}
+
+		}
+	}
+
+	if len(tokens) == 0 {
+		return tokens, ""
+	}
+
+	if plasma {
+		return tokens, client.PlasmaBridge
+	}
+
+	return tokens, client.POSBridge
+}
+
+// Infers bridge, burn tx was performed on, from logs in its receipt
+//
+// Burnt child tokens are looked up in token registry first, if they're mapped
+// on only one of bridges, that's the one, otherwise plasma child tokens are
+// recognised by `Withdraw` event they emit, while POS ones only transfer to zero
+// address
+//
+// Returns empty string when it can't be inferred i.e. tx is not a burn
+func inferBridge(ctx context.Context, db *gorm.DB, receipt *types.Receipt) string {
+	tokens, bridge := burntTokens(receipt)
+	if len(tokens) == 0 {
+		return ""
+	}
+
+	addresses := make([]string, 0, len(tokens))
+	for _, v := range tokens {
+		addresses = append(addresses, v.Hex())
+	}
+
+	var bridges []string
+
+	if err := db.WithContext(ctx).Model(&TokenMapping{}).Distinct("bridge").Where("child_token IN ?", addresses).Pluck("bridge", &bridges).Error; err != nil {
+		dbLog.Ctx(ctx).Error("Failed to look up burnt tokens", logger.Fields{"error": err, "txHash": receipt.TxHash.Hex()})
+		return bridge
+	}
+
+	if len(bridges) == 1 {
+		return bridges[0]
+	}
+
+	return bridge
+}
+
+// Resolves whether withdraw is to be tracked on POS bridge or not, inferring it
+// from burn tx, while `isPoS`, if supplied in payload, must agree with it
+//
+// When bridge can't be resolved, returns status to be responded with, instead
+// e.g. burn tx is pending/ failed, or declared bridge is wrong
+func resolveBridge(ctx context.Context, childClient *ethclient.Client, db *gorm.DB, tx *WithdrawTransaction) (bool, *TransactionState) {
+	ctx, span := tracing.Start(ctx, "resolveBridge", tracing.KindInternal, tracing.Attr("tx.hash", tx.BurnTxHash.Hex()))
+	defer span.End()
+
+	var bridge string
+
+	receipt, err := getTransactionReceipt(ctx, childClient, tx.BurnTxHash)
+	if err != nil {
+		return false, receiptFailedStatus(ctx, childClient, tx.BurnTxHash, err, -1, "Pending")
+	}
+	if receipt != nil && receipt.Status == 1 {
+		bridge = inferBridge(ctx, db, receipt)
+	}
+
+	// Pending/ failed burn tx is tracked as declared, if it is
+	if bridge == "" {
+		if tx.IsPOS != nil {
+			return *tx.IsPOS, nil
+		}
+
+		_state := getBurnStatus(ctx, childClient, db, tx.BurnTxHash)
+		if _state.Code != -3 {
+			return false, _state
+		}
+
+		return false, &TransactionState{
+			Code:    unknownBridgeCode,
+			Message: "Unknown Bridge",
+			Reason:  "Bridge couldn't be inferred from burn tx, supply `isPoS`",
+		}
+	}
+
+	isPOS := bridge == client.POSBridge
+
+	if tx.IsPOS != nil && *tx.IsPOS != isPOS {
+		return false, &TransactionState{
+			Code:    bridgeMismatchCode,
+			Message: "Bridge Mismatch",
+			Reason:  fmt.Sprintf("Burn tx was performed on %s bridge, while `isPoS` is %t", bridge, *tx.IsPOS),
+		}
+	}
+
+	return isPOS, nil
+}
@@ -0,0 +1,154 @@
+package tracker
+
+import (
+	"app/internal/testutil"
+	"context"
+	"database/sql/driver"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/maticnetwork/deposits-withdrawals-tracking/app/client"
+)
+
+var (
+	childToken = common.HexToAddress("0x0000000000000000000000000000000000000a02")
+	account    = common.HexToAddress("0x0000000000000000000000000000000000000c01")
+	operator   = common.HexToAddress("0x0000000000000000000000000000000000000c02")
+)
+
+// Receipt of successful tx, emitting given logs
+func receiptOf(logs ...*types.Log) *types.Receipt {
+	return &types.Receipt{Status: types.ReceiptStatusSuccessful, Logs: logs, BlockNumber: big.NewInt(1)}
+}
+
+// Logs emitted by child tokens, when `account` burns them
+func erc20Burn() *types.Log {
+	return &types.Log{Address: childToken, Topics: []common.Hash{transferTopic, account.Hash(), {}}, Data: common.LeftPadBytes(big.NewInt(100).Bytes(), 32)}
+}
+
+func erc721Burn() *types.Log {
+	return &types.Log{Address: childToken, Topics: []common.Hash{transferTopic, account.Hash(), {}, common.BigToHash(big.NewInt(7))}}
+}
+
+func erc1155Burn() *types.Log {
+	return &types.Log{Address: childToken, Topics: []common.Hash{transferSingleTopic, operator.Hash(), account.Hash(), {}}, Data: make([]byte, 64)}
+}
+
+// Logs emitted by Plasma child token, when `account` burns it
+func plasmaBurn() []*types.Log {
+	logTransferTopic := crypto.Keccak256Hash([]byte("LogTransfer(address,address,address,uint256,uint256,uint256,uint256,uint256)"))
+
+	return []*types.Log{
+		{Address: childToken, Topics: []common.Hash{plasmaWithdrawTopic, childToken.Hash(), account.Hash()}, Data: make([]byte, 96)},
+		{Address: childToken, Topics: []common.Hash{logTransferTopic, childToken.Hash(), account.Hash(), {}}, Data: make([]byte, 160)},
+	}
+}
+
+func TestBurnTransfers(t *testing.T) {
+	erc721Transfer := erc721Burn()
+	erc721Transfer.Topics[2] = operator.Hash()
+
+	for name, v := range map[string]struct {
+		_log  *types.Log
+		burnt bool
+	}{
+		"erc20":              {erc20Burn(), true},
+		"erc721":             {erc721Burn(), true},
+		"erc1155":            {erc1155Burn(), true},
+		"erc721 not to zero": {erc721Transfer, false},
+		"anonymous":          {&types.Log{Address: childToken}, false},
+		"truncated transfer": {&types.Log{Address: childToken, Topics: []common.Hash{transferTopic, account.Hash()}}, false},
+	} {
+		receipt := receiptOf(v._log)
+
+		tokens, bridge := burntTokens(receipt)
+		_log := burnLog(receipt, true)
+
+		if !v.burnt {
+			if len(tokens) != 0 || bridge != "" || _log != nil {
+				t.Fatalf("%s : expected no burn, found %v on `%s`", name, tokens, bridge)
+			}
+			continue
+		}
+
+		if len(tokens) != 1 || tokens[0] != childToken || bridge != client.POSBridge {
+			t.Fatalf("%s : expected POS burn of %s, found %v on `%s`", name, childToken.Hex(), tokens, bridge)
+		}
+
+		if _log != v._log || burner(_log) != account.Hash() {
+			t.Fatalf("%s : expected burn log of %s", name, account.Hex())
+		}
+
+		if burnLog(receipt, false) != nil {
+			t.Fatalf("%s : expected POS burn log not to be picked for Plasma", name)
+		}
+	}
+}
+
+func TestResolveBridge(t *testing.T) {
+	env := newTestEnv(t, nil)
+	defer env.close()
+
+	// Child token, which is mapped on Plasma bridge, though it burns like POS one
+	mapped := common.HexToAddress("0x0000000000000000000000000000000000000b02")
+	env._db.Respond = func(query string, args []driver.Value) (*testutil.Rows, error) {
+		if !strings.Contains(query, "token_mapping") || len(args) == 0 || args[0] != mapped.Hex() {
+			return nil, nil
+		}
+
+		return &testutil.Rows{Columns: []string{"bridge"}, Values: [][]driver.Value{{client.PlasmaBridge}}}, nil
+	}
+
+	mappedBurn := erc20Burn()
+	mappedBurn.Address = mapped
+
+	unknown := &types.Log{Address: childToken, Topics: []common.Hash{crypto.Keccak256Hash([]byte("Unknown()"))}}
+
+	yes, no := true, false
+
+	for name, v := range map[string]struct {
+		logs  []*types.Log
+		isPOS *bool
+		// Expected bridge, when it's resolved, otherwise code of status
+		// responded with
+		pos  bool
+		code int
+	}{
+		"pos erc20":               {logs: []*types.Log{erc20Burn()}, pos: true},
+		"pos erc20 declared":      {logs: []*types.Log{erc20Burn()}, isPOS: &yes, pos: true},
+		"pos erc721":              {logs: []*types.Log{erc721Burn()}, pos: true},
+		"pos erc1155":             {logs: []*types.Log{erc1155Burn()}, pos: true},
+		"plasma":                  {logs: plasmaBurn()},
+		"plasma declared":         {logs: plasmaBurn(), isPOS: &no},
+		"mapped on plasma":        {logs: []*types.Log{mappedBurn}},
+		"unknown log":             {logs: []*types.Log{unknown}, code: unknownBridgeCode},
+		"unknown log declared":    {logs: []*types.Log{unknown}, isPOS: &yes, pos: true},
+		"pos declared plasma":     {logs: []*types.Log{erc20Burn()}, isPOS: &no, code: bridgeMismatchCode},
+		"plasma declared pos":     {logs: plasmaBurn(), isPOS: &yes, code: bridgeMismatchCode},
+		"mapped on plasma as pos": {logs: []*types.Log{mappedBurn}, isPOS: &yes, code: bridgeMismatchCode},
+	} {
+		burnTxHash := env.child.Mine(&testutil.Tx{Logs: v.logs})
+
+		isPOS, status := resolveBridge(context.Background(), env.child.Client(), env.db, &WithdrawTransaction{BurnTxHash: burnTxHash, IsPOS: v.isPOS})
+
+		if v.code != 0 {
+			if status == nil || status.Code != v.code {
+				t.Fatalf("%s : expected status `%d`, found %+v", name, v.code, status)
+			}
+			continue
+		}
+
+		if status != nil || isPOS != v.pos {
+			t.Fatalf("%s : expected POS %t, found %t, with status %+v", name, v.pos, isPOS, status)
+		}
+	}
+
+	// Pending burn tx is neither resolved nor tracked as unknown
+	if _, status := resolveBridge(context.Background(), env.child.Client(), env.db, &WithdrawTransaction{BurnTxHash: common.HexToHash("0x0e")}); status == nil || status.Code != -1 {
+		t.Fatalf("expected pending burn tx to be `-1`, found %+v", status)
+	}
+}
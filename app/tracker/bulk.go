@@ -0,0 +1,268 @@
+package tracker
+
+import (
+	"context"
+	"fmt"
+	"logger"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/gin-gonic/gin"
+)
+
+// Status code, given to tx(s) whose status couldn't be found out, due to
+// some error, specific to that tx
+const erroredCode = 9
+
+// Status to be returned for tx, whose status couldn't be found out due to given error
+func erroredStatus(err error) *TransactionState {
+	return &TransactionState{
+		Code:     erroredCode,
+		Message:  "Errored",
+		Degraded: true,
+		Source:   "bridge-api",
+		Reason:   err.Error(),
+	}
+}
+
+// Slots of worker pool, shared by all requests, where each tx takes
+// one slot, while its status is being found out
+//
+// Nil pool doesn't put any limit
+var (
+	pool      chan struct{}
+	poolMutex = &sync.RWMutex{}
+)
+
+// Sets up worker pool with given many slots, replacing existing one, if any,
+// where lookups holding slots of existing pool, release them to same pool
+func setUpPool(size int) {
+	poolMutex.Lock()
+	defer poolMutex.Unlock()
+
+	pool = make(chan struct{}, size)
+}
+
+// Worker pool in use
+func getPool() chan struct{} {
+	poolMutex.RLock()
+	defer poolMutex.RUnlock()
+
+	return pool
+}
+
+// Waits for free slot in given pool, unless context gets done before that,
+// returning false in that case
+func acquire(ctx context.Context, pool chan struct{}) bool {
+	if pool == nil {
+		return true
+	}
+
+	start := time.Now()
+	defer metrics.GetOrRegisterTimer("bridge/pool/wait", registry).UpdateSince(start)
+
+	select {
+	case pool <- struct{}{}:
+		metrics.GetOrRegisterGauge("bridge/pool/busy", registry).Update(int64(len(pool)))
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// Frees up slot taken in given pool
+func release(pool chan struct{}) {
+	if pool == nil {
+		return
+	}
+
+	<-pool
+	metrics.GetOrRegisterGauge("bridge/pool/busy", registry).Update(int64(len(pool)))
+}
+
+// Binds bulk payload of request, responding with 400 if it's not well formed
+// or number of items in it, as returned by `size`, is out of [MinPayloadSize,
+// MaxPayloadSize]
+//
+// Returns false when request is already responded to
+func bindBulkPayload(c *gin.Context, payload interface{}, size func() int) bool {
+	conf := getConfig()
+
+	if err := c.ShouldBindJSON(payload); err != nil {
+		c.JSON(400, gin.H{
+			"msg": "Bad Payload",
+		})
+		return false
+	}
+
+	// Expecting at least 1 txHash
+	if size() < conf.MinPayloadSize {
+		c.JSON(400, gin.H{
+			"msg": "Empty Payload",
+		})
+		return false
+	}
+
+	// If more than 10 tx hashes are asked to be tracked
+	// we're simply going to not take this request up
+	if size() > conf.MaxPayloadSize {
+		c.JSON(400, gin.H{
+			"msg": "Heavy Payload",
+		})
+		return false
+	}
+
+	return true
+}
+
+// Response body of bulk endpoint, built using statuses found out
+type envelope func(map[common.Hash]*TransactionState) interface{}
+
+// Responds with statuses as is, keyed by tx hash
+func plainEnvelope(statuses map[common.Hash]*TransactionState) interface{} {
+	return statuses
+}
+
+// Handler of bulk endpoint, accepting `{"txHashes": [...]}`, where status of each unique
+// tx hash is found out using `status` & responded with, wrapped in `wrap`
+//
+// Status of same tx hash, asked for concurrently by multiple requests, is found out once
+// & served from cache for a while, as per `statusTTLs`
+func bulkHandler(status func(context.Context, common.Hash) *TransactionState, wrap envelope) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var bulkPayload BulkPayload
+
+		if !bindBulkPayload(c, &bulkPayload, func() int { return len(bulkPayload.TransactionHashes) }) {
+			return
+		}
+
+		hashes := bulkPayload.Unique()
+
+		// Gin context is not to be touched by workers, which may be
+		// still running after request is responded to
+		route := c.FullPath()
+
+		_statuses := resolveStatuses(c.Request.Context(), hashes, func(ctx context.Context, i int) *TransactionState {
+			if isEmptyTxHash(hashes[i]) {
+				return erroredStatus(fmt.Errorf("empty tx hash"))
+			}
+
+			return cachedStatus(ctx, strings.Join([]string{route, hashes[i].Hex()}, "/"), func(ctx context.Context) *TransactionState {
+				return status(ctx, hashes[i])
+			})
+		})
+
+		c.JSON(200, wrap(_statuses))
+	}
+}
+
+// Finds out status of each of given tx(s) concurrently, using `resolve`, which is invoked
+// with index of tx, once it gets slot in worker pool. Returned status is kept against tx hash
+// found at same index of `keys`, unless it's nil, meaning there's nothing to be kept
+//
+// If resolving status of some tx panics, it's returned as errored, while if request
+// deadline is hit before all of them are done, status of whichever are yet to be
+// found out, is returned as timed out
+func resolveStatuses(ctx context.Context, keys []common.Hash, resolve func(context.Context, int) *TransactionState) map[common.Hash]*TransactionState {
+	statuses := make(map[common.Hash]*TransactionState, len(keys))
+	resolved := make([]bool, len(keys))
+
+	mutex := sync.Mutex{}
+	var wg sync.WaitGroup
+
+	// Same pool to be released to, even if it gets replaced meanwhile
+	_pool := getPool()
+
+	for i := range keys {
+
+		i := i
+
+		// Lookups may keep running after deadline is hit, where statuses found
+		// out are persisted using context detached from request, so they're
+		// waited for, during shutdown
+		wg.Add(1)
+		inBackground(func() {
+			defer wg.Done()
+
+			if !acquire(ctx, _pool) {
+				return
+			}
+			defer release(_pool)
+
+			var _tmp *TransactionState
+
+			func() {
+				defer func() {
+					if r := recover(); r != nil {
+						statusLog.Ctx(ctx).Error("Failed to find out tx status", logger.Fields{"txHash": keys[i].Hex(), "error": r})
+						_tmp = erroredStatus(fmt.Errorf("failed to find out status : %v", r))
+					}
+				}()
+
+				_tmp = resolve(ctx, i)
+			}()
+
+			mutex.Lock()
+			defer mutex.Unlock()
+
+			resolved[i] = true
+			if _tmp != nil {
+				statuses[keys[i]] = _tmp
+			}
+		})
+
+	}
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		// Workers which couldn't get slot in pool before deadline, are
+		// done without resolving
+		if ctx.Err() == nil {
+			return statuses
+		}
+	case <-ctx.Done():
+	}
+
+	// Workers still running are going to write into `statuses`, after
+	// we return, so responding with a copy of it
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	_statuses := make(map[common.Hash]*TransactionState, len(keys))
+	for k, v := range statuses {
+		_statuses[k] = v
+	}
+
+	pending := 0
+	for i, k := range keys {
+		if resolved[i] {
+			continue
+		}
+
+		// Same tx asked for more than once, where one of them got resolved
+		if _, ok := _statuses[k]; ok {
+			continue
+		}
+
+		_statuses[k] = timedOutStatus()
+		pending++
+	}
+
+	if pending == 0 {
+		return _statuses
+	}
+
+	metrics.GetOrRegisterCounter("bridge/status/timed_out", registry).Inc(int64(pending))
+	statusLog.Ctx(ctx).Warn("Request deadline hit, responding with partial statuses", logger.Fields{"pending": pending, "total": len(keys)})
+
+	return _statuses
+}
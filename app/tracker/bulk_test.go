@@ -0,0 +1,61 @@
+package tracker
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestWithdrawPayloadSize(t *testing.T) {
+	env := newTestEnv(t, map[string]interface{}{"MaxPayloadSize": 2})
+	defer env.close()
+
+	post := func(body string) int {
+		w := httptest.NewRecorder()
+
+		req := httptest.NewRequest(http.MethodPost, "/v2/withdraw", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		env.router.ServeHTTP(w, req)
+
+		return w.Code
+	}
+
+	tx := `{"txHash":"0x0000000000000000000000000000000000000000000000000000000000000001","isPoS":true}`
+
+	if code := post(`{"withdrawTxObjectArray":[` + strings.Join([]string{tx, tx, tx}, ",") + `]}`); code != 400 {
+		t.Fatalf("expected heavy payload to be rejected, found %d", code)
+	}
+	if code := post(`{"withdrawTxObjectArray":[]}`); code != 400 {
+		t.Fatalf("expected empty payload to be rejected, found %d", code)
+	}
+	if code := post(`{"withdrawTxObjectArray":[` + strings.Join([]string{tx, tx}, ",") + `]}`); code != 200 {
+		t.Fatalf("expected payload to be accepted, found %d", code)
+	}
+}
+
+func TestUniquePayloads(t *testing.T) {
+	burn, confirm := common.HexToHash("0x01"), common.HexToHash("0x02")
+
+	pairs := (&PlasmaExitBulkPayload{TransactionHashes: []CheckExitable{
+		{BurnTxHash: burn, ConfirmTxHash: confirm},
+		{BurnTxHash: burn, ConfirmTxHash: confirm},
+		{BurnTxHash: confirm, ConfirmTxHash: burn},
+	}}).Unique()
+	if len(pairs) != 2 {
+		t.Fatalf("expected 2 unique pairs, found %d", len(pairs))
+	}
+
+	isPOS := true
+	txs := (&WithdrawTransactions{Transactions: []*WithdrawTransaction{
+		{BurnTxHash: burn, IsPOS: &isPOS},
+		nil,
+		{BurnTxHash: burn},
+		{BurnTxHash: confirm},
+	}}).Unique()
+	if len(txs) != 2 || txs[0].IsPOS == nil || txs[1].BurnTxHash != confirm {
+		t.Fatalf("expected first of each burn tx to be kept, found %+v", txs)
+	}
+}
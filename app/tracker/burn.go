@@ -1,6 +1,8 @@
 package tracker
 
 import (
+	"app/tracing"
+	"context"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"gorm.io/gorm"
@@ -11,8 +13,11 @@ import (
 //
 // This needs to be performed first, before asset can be withdrawn from child
 // chain to root chain
-func getBurnStatus(client *ethclient.Client, db *gorm.DB, txHash common.Hash) *TransactionState {
-	if status := getChildChaintxStatusFromDB(db, txHash); status != nil {
+func getBurnStatus(ctx context.Context, client *ethclient.Client, db *gorm.DB, txHash common.Hash) *TransactionState {
+	ctx, span := tracing.Start(ctx, "getBurnStatus", tracing.KindInternal, tracing.Attr("tx.hash", txHash.Hex()))
+	defer span.End()
+
+	if status := getChildChaintxStatusFromDB(ctx, db, txHash); status != nil {
 		if status.Code == -3 {
 			return &TransactionState{
 				Code:    status.Code,
@@ -21,7 +26,10 @@ func getBurnStatus(client *ethclient.Client, db *gorm.DB, txHash common.Hash) *T
 		}
 	}
 
-	receipt := getTransactionReceipt(client, txHash)
+	receipt, err := getTransactionReceipt(ctx, client, txHash)
+	if err != nil {
+		return receiptFailedStatus(ctx, client, txHash, err, -1, "Pending")
+	}
 	if receipt == nil {
 		return &TransactionState{
 			Code:    -1,
@@ -30,7 +38,7 @@ func getBurnStatus(client *ethclient.Client, db *gorm.DB, txHash common.Hash) *T
 	}
 
 	if receipt.Status == 0 {
-		putChildChainTxStatusInDB(db, txHash, -2, "Failed")
+		putChildChainTxStatusInDB(ctx, db, txHash, -2, "Failed")
 
 		return &TransactionState{
 			Code:    -2,
@@ -38,7 +46,7 @@ func getBurnStatus(client *ethclient.Client, db *gorm.DB, txHash common.Hash) *T
 		}
 	}
 
-	putChildChainTxStatusInDB(db, txHash, -3, "Burnt")
+	putChildChainTxStatusInDB(ctx, db, txHash, -3, "Burnt")
 
 	return &TransactionState{
 		Code:    -3,
@@ -0,0 +1,541 @@
+package tracker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"logger"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/maticnetwork/deposits-withdrawals-tracking/app/client"
+)
+
+// Maximum number of entries, each in-memory cache can hold
+const cacheSize = 10000
+
+// How long status of tx, found out by bulk endpoints, can be served from cache,
+// depending upon its code, so that clients polling same tx hash don't result into
+// fresh lookups every time
+//
+// Codes not listed here are final e.g. `0` Deposited, `2` Failed & `10` State
+// Sync Failed, which are kept for `finalStatusTTL`, while degraded ones are never cached,
+// so that failed dependency gets retried
+var statusTTLs = map[int]time.Duration{
+	7:   time.Second * time.Duration(5),  // Approval pending
+	4:   time.Second * time.Duration(5),  // Deposit pending
+	1:   time.Second * time.Duration(15), // Deposit en route, state sync takes minutes
+	-1:  time.Second * time.Duration(5),  // Burn pending
+	-3:  time.Second * time.Duration(30), // Burnt, checkpoint gets submitted every ~30 minutes
+	-4:  time.Second * time.Duration(15), // Checkpointed, waiting for exit
+	-5:  time.Second * time.Duration(5),  // Exited ( POS ) / Confirm withdraw pending ( Plasma )
+	-8:  time.Second * time.Duration(30), // Challenge period not yet over
+	-9:  time.Second * time.Duration(15), // Ready to exit
+	-12: time.Second * time.Duration(5),  // Exit pending
+}
+
+// How long final status of tx can be served from cache, sparing DB lookup
+const finalStatusTTL = time.Minute * time.Duration(10)
+
+// How long receipts, as fetched from RPC node, can be served from cache, where
+// not found receipt i.e. pending tx is cached for shorter duration
+const (
+	receiptTTL        = time.Second * time.Duration(60)
+	pendingReceiptTTL = time.Second * time.Duration(3)
+)
+
+// How long responses of workers can be served from cache, where once checkpointed,
+// block stays so, while latest state ID keeps changing
+const (
+	checkPointedTTL    = time.Minute * time.Duration(10)
+	notCheckPointedTTL = time.Second * time.Duration(15)
+	lastStateIDTTL     = time.Second * time.Duration(5)
+	committedStateTTL  = time.Minute * time.Duration(10)
+)
+
+// Cached value, along with when it expires
+type entry struct {
+	value   interface{}
+	expires time.Time
+}
+
+// ttlCache - In-memory cache, where each entry lives for its own TTL
+type ttlCache struct {
+	name    string
+	size    int
+	entries map[string]*entry
+	mutex   *sync.Mutex
+}
+
+// Creates cache holding at max `size` entries, where name is used for metrics,
+// empty name means lookups are not to be recorded
+func newTTLCache(name string, size int) *ttlCache {
+	return &ttlCache{
+		name:    name,
+		size:    size,
+		entries: make(map[string]*entry),
+		mutex:   &sync.Mutex{},
+	}
+}
+
+// Returns cached value, if present & not yet expired
+func (t *ttlCache) get(key string) (interface{}, bool) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	v, ok := t.entries[key]
+	if ok && time.Now().After(v.expires) {
+		delete(t.entries, key)
+		ok = false
+	}
+
+	if t.name != "" {
+		recordCacheLookup(t.name, ok)
+	}
+
+	if !ok {
+		return nil, false
+	}
+
+	return v.value, true
+}
+
+// Keeps value for given duration, where non-positive TTL means not to be kept
+//
+// When cache is full, expired entries are evicted first, if that doesn't
+// free up any space, some random entry gets evicted
+func (t *ttlCache) set(key string, value interface{}, ttl time.Duration) {
+	if ttl <= 0 {
+		return
+	}
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if _, ok := t.entries[key]; !ok && len(t.entries) >= t.size {
+		now := time.Now()
+
+		for k, v := range t.entries {
+			if now.After(v.expires) {
+				delete(t.entries, k)
+			}
+		}
+
+		for k := range t.entries {
+			if len(t.entries) < t.size {
+				break
+			}
+
+			delete(t.entries, k)
+		}
+	}
+
+	t.entries[key] = &entry{value: value, expires: time.Now().Add(ttl)}
+}
+
+// Evicts cached value, if present
+func (t *ttlCache) delete(key string) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	delete(t.entries, key)
+}
+
+// One in-flight call, whose result is shared by all callers asking for same key
+type flight struct {
+	done      chan struct{}
+	value     interface{}
+	completed bool
+}
+
+// flightGroup - Coalesces concurrent calls for same key into one, so that
+// only one of them actually hits RPC node/ DB/ worker
+type flightGroup struct {
+	name    string
+	timeout time.Duration
+	calls   map[string]*flight
+	mutex   *sync.Mutex
+}
+
+// Creates group of calls, each of which is given `timeout`, where name is used for metrics
+func newFlightGroup(name string, timeout time.Duration) *flightGroup {
+	return &flightGroup{
+		name:    name,
+		timeout: timeout,
+		calls:   make(map[string]*flight),
+		mutex:   &sync.Mutex{},
+	}
+}
+
+// Returned when coalesced call couldn't complete, while caller is still waiting
+var errFlightFailed = errors.New("coalesced call failed")
+
+// Error to be returned, when waiting for coalesced call didn't yield result
+func flightError(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return errFlightFailed
+}
+
+// Invokes `fn` in background, unless call for same key is already in flight, then waits
+// for its result, unless context gets done before that or `fn` panics, returning false
+//
+// Call is made using context carrying values of caller who asked first, but not its
+// deadline, rather it's given `timeout` of group, so that caller giving up doesn't
+// fail others, waiting for same call
+func (g *flightGroup) do(ctx context.Context, key string, fn func(context.Context) interface{}) (interface{}, bool) {
+	g.mutex.Lock()
+
+	call, ok := g.calls[key]
+	if ok {
+		g.mutex.Unlock()
+
+		metrics.GetOrRegisterCounter(metricName("bridge", "coalesced", g.name), registry).Inc(1)
+	} else {
+		call = &flight{done: make(chan struct{})}
+		g.calls[key] = call
+		g.mutex.Unlock()
+
+		inBackground(func() {
+			g.run(ctx, key, call, fn)
+		})
+	}
+
+	select {
+	case <-call.done:
+		return call.value, call.completed
+	case <-ctx.Done():
+		return nil, false
+	}
+}
+
+// Runs call, letting all waiting callers know once it's done
+func (g *flightGroup) run(ctx context.Context, key string, call *flight, fn func(context.Context) interface{}) {
+	ctx, cancel := context.WithTimeout(detachedContext{ctx}, g.timeout)
+	defer cancel()
+
+	defer func() {
+		if r := recover(); r != nil {
+			appLog.Ctx(ctx).Error("Coalesced call panicked", logger.Fields{"group": g.name, "key": key, "error": r})
+		}
+
+		g.mutex.Lock()
+		delete(g.calls, key)
+		g.mutex.Unlock()
+
+		close(call.done)
+	}()
+
+	call.value = fn(ctx)
+	call.completed = true
+}
+
+// How long coalesced calls are given, irrespective of deadlines of callers waiting
+// for them, where lookup of status involves multiple calls to RPC nodes/ DB/ workers
+const (
+	statusFlightTimeout = time.Second * time.Duration(30)
+	callFlightTimeout   = time.Second * time.Duration(10)
+)
+
+// Coalesced calls for tx statuses, as found out by bulk endpoints, where
+// statuses are cached in shared cache
+var statusFlights = newFlightGroup("status", statusFlightTimeout)
+
+// How long status lookup, made by one replica, can keep others, asking for same
+// status, waiting, before they start looking it up themselves
+const statusLockTTL = time.Second * time.Duration(10)
+
+// How often replicas, waiting for status lookup made by another one, check
+// whether status has been found out
+const statusPollInterval = time.Millisecond * time.Duration(100)
+
+// How long status is to be kept in cache, depending upon its code, where final
+// ones are kept for `finalStatusTTL`, so that DB is not hit for them every time
+func statusTTL(status *TransactionState) time.Duration {
+	if status.Degraded {
+		return 0
+	}
+
+	if ttl, ok := statusTTLs[status.Code]; ok {
+		return ttl
+	}
+
+	return finalStatusTTL
+}
+
+// Cached status of tx, if present in shared cache
+func getCachedStatus(ctx context.Context, key string) (*TransactionState, bool) {
+	data, ok, err := sharedCache.Get(ctx, "status:"+key)
+	if err != nil {
+		recordSharedCacheFailure("get", err)
+		return nil, false
+	}
+
+	recordCacheLookup("status", ok)
+
+	if !ok {
+		return nil, false
+	}
+
+	var status TransactionState
+	if err := json.Unmarshal(data, &status); err != nil {
+		return nil, false
+	}
+
+	return &status, true
+}
+
+// Keeps status of tx in shared cache, as per its TTL
+func setCachedStatus(ctx context.Context, key string, status *TransactionState) {
+	ttl := statusTTL(status)
+	if ttl <= 0 {
+		return
+	}
+
+	data, err := json.Marshal(status)
+	if err != nil {
+		return
+	}
+
+	if err := sharedCache.Set(ctx, "status:"+key, data, ttl); err != nil {
+		recordSharedCacheFailure("set", err)
+	}
+}
+
+// Serves status of tx from shared cache, if present, otherwise finds it out using `fn`,
+// while coalescing concurrent lookups for same key, made by this replica, as well as by
+// others, when shared cache is backed by Redis, keeping it cached as per its TTL
+//
+// `fn` is invoked with context, it's to find out status using, which may not be
+// same as caller's one, when lookup is shared with others
+func cachedStatus(ctx context.Context, key string, fn func(context.Context) *TransactionState) *TransactionState {
+	if !cacheBypassed(ctx) {
+		if status, ok := getCachedStatus(ctx, key); ok {
+			return status
+		}
+	}
+
+	v, ok := statusFlights.do(ctx, key, func(ctx context.Context) interface{} {
+		return lockedStatus(ctx, key, func() *TransactionState {
+			return fn(ctx)
+		})
+	})
+	if !ok {
+		if ctx.Err() != nil {
+			return timedOutStatus()
+		}
+
+		return erroredStatus(fmt.Errorf("failed to find out status"))
+	}
+
+	status, _ := v.(*TransactionState)
+	return status
+}
+
+// Finds out status of tx using `fn`, holding lock in shared cache, so that other
+// replicas wait for it to be cached, instead of looking it up themselves
+//
+// If lock can't be acquired, waits until either status gets cached or lock gets
+// released, in later case, it tries to acquire lock again
+func lockedStatus(ctx context.Context, key string, fn func() *TransactionState) *TransactionState {
+	for {
+		unlock, ok, err := sharedCache.Lock(ctx, "status:"+key, statusLockTTL)
+		if err != nil {
+			recordSharedCacheFailure("lock", err)
+			break
+		}
+
+		if ok {
+			defer unlock()
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return timedOutStatus()
+		case <-time.After(statusPollInterval):
+		}
+
+		if status, ok := getCachedStatus(ctx, key); ok {
+			return status
+		}
+	}
+
+	status := fn()
+	if status != nil {
+		setCachedStatus(ctx, key, status)
+	}
+
+	return status
+}
+
+type noCacheKey struct{}
+
+// Returns context, lookups made using which are not to be served from cache, nor from
+// statuses persisted in DB, though their results are still cached, to be used when fresh
+// response is must e.g. health checks, admin rechecks
+func withoutCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, noCacheKey{}, true)
+}
+
+// Whether lookups made using this context are to be served from cache or not
+func cacheBypassed(ctx context.Context) bool {
+	bypass, _ := ctx.Value(noCacheKey{}).(bool)
+	return bypass
+}
+
+// Caches & coalesced calls for receipts, fetched from RPC nodes
+var (
+	receiptCache   = newTTLCache("receipt", cacheSize)
+	receiptFlights = newFlightGroup("receipt", callFlightTimeout)
+)
+
+// Result of worker call, as shared with coalesced callers
+type workerResult struct {
+	value interface{}
+	err   error
+}
+
+// cachedCheckPointTracker - Serves whether block has been checkpointed or not from
+// cache, if present, otherwise asks wrapped worker, while coalescing concurrent calls
+// for same block
+type cachedCheckPointTracker struct {
+	worker  CheckPointTracker
+	cache   *ttlCache
+	flights *flightGroup
+}
+
+// IsCheckPointed - Whether given child chain block has been checkpointed or not
+func (c *cachedCheckPointTracker) IsCheckPointed(ctx context.Context, blockNumber *big.Int) (bool, error) {
+	key := blockNumber.String()
+
+	if !cacheBypassed(ctx) {
+		if v, ok := c.cache.get(key); ok {
+			return v.(bool), nil
+		}
+	}
+
+	v, ok := c.flights.do(ctx, key, func(ctx context.Context) interface{} {
+		checkPointed, err := c.worker.IsCheckPointed(ctx, blockNumber)
+		if err != nil {
+			return &workerResult{err: err}
+		}
+
+		ttl := notCheckPointedTTL
+		if checkPointed {
+			ttl = checkPointedTTL
+		}
+		c.cache.set(key, checkPointed, ttl)
+
+		return &workerResult{value: checkPointed}
+	})
+	if !ok {
+		return false, flightError(ctx)
+	}
+
+	result := v.(*workerResult)
+	if result.err != nil {
+		return false, result.err
+	}
+
+	return result.value.(bool), nil
+}
+
+// cachedStateIDManager - Serves latest `lastStateId` & committed states from cache, if
+// present, otherwise asks wrapped worker, while coalescing concurrent calls
+type cachedStateIDManager struct {
+	worker  StateIDManager
+	cache   *ttlCache
+	states  *ttlCache
+	flights *flightGroup
+}
+
+// LastStateID - Latest `lastStateId` of child chain
+func (s *cachedStateIDManager) LastStateID(ctx context.Context) (*big.Int, error) {
+	const key = "lastStateId"
+
+	if !cacheBypassed(ctx) {
+		if v, ok := s.cache.get(key); ok {
+			return new(big.Int).Set(v.(*big.Int)), nil
+		}
+	}
+
+	v, ok := s.flights.do(ctx, key, func(ctx context.Context) interface{} {
+		id, err := s.worker.LastStateID(ctx)
+		if err != nil {
+			return &workerResult{err: err}
+		}
+
+		s.cache.set(key, id, lastStateIDTTL)
+
+		return &workerResult{value: id}
+	})
+	if !ok {
+		return nil, flightError(ctx)
+	}
+
+	result := v.(*workerResult)
+	if result.err != nil {
+		return nil, result.err
+	}
+
+	return new(big.Int).Set(result.value.(*big.Int)), nil
+}
+
+// State - How state sync landed on child chain, where once it's indexed, it stays
+// so, while not yet indexed ones are never cached
+func (s *cachedStateIDManager) State(ctx context.Context, id *big.Int) (*client.CommittedState, error) {
+	key := "state:" + id.String()
+
+	if !cacheBypassed(ctx) {
+		if v, ok := s.states.get(key); ok {
+			return v.(*client.CommittedState), nil
+		}
+	}
+
+	v, ok := s.flights.do(ctx, key, func(ctx context.Context) interface{} {
+		state, err := s.worker.State(ctx, id)
+		if err != nil {
+			return &workerResult{err: err}
+		}
+
+		if state != nil {
+			s.states.set(key, state, committedStateTTL)
+		}
+
+		return &workerResult{value: state}
+	})
+	if !ok {
+		return nil, flightError(ctx)
+	}
+
+	result := v.(*workerResult)
+	if result.err != nil {
+		return nil, result.err
+	}
+
+	return result.value.(*client.CommittedState), nil
+}
+
+// Wraps workers, so that their responses get cached & concurrent calls coalesced
+func cacheWorkers(_checkPointTracker CheckPointTracker, _stateIDManager StateIDManager) (CheckPointTracker, StateIDManager) {
+	_cachedCheckPointTracker := &cachedCheckPointTracker{
+		worker:  _checkPointTracker,
+		cache:   newTTLCache("check_point_tracker", cacheSize),
+		flights: newFlightGroup("check_point_tracker", callFlightTimeout),
+	}
+
+	_cachedStateIDManager := &cachedStateIDManager{
+		worker:  _stateIDManager,
+		cache:   newTTLCache("state_id_manager", 1),
+		states:  newTTLCache("committed_state", cacheSize),
+		flights: newFlightGroup("state_id_manager", callFlightTimeout),
+	}
+
+	return _cachedCheckPointTracker, _cachedStateIDManager
+}
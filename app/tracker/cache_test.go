@@ -0,0 +1,84 @@
+package tracker
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestFlightOutlivesFirstCaller(t *testing.T) {
+	g := newFlightGroup("test", time.Second)
+
+	release := make(chan struct{})
+	fn := func(ctx context.Context) interface{} {
+		select {
+		case <-release:
+			return "done"
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	// First caller gives up, while call is in flight
+	ctx, cancel := context.WithCancel(context.Background())
+
+	first := make(chan bool)
+	go func() {
+		_, ok := g.do(ctx, "key", fn)
+		first <- ok
+	}()
+
+	// Waiting until call is in flight
+	for {
+		g.mutex.Lock()
+		_, ok := g.calls["key"]
+		g.mutex.Unlock()
+
+		if ok {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	second := make(chan interface{})
+	go func() {
+		v, _ := g.do(context.Background(), "key", fn)
+		second <- v
+	}()
+
+	cancel()
+	if <-first {
+		t.Fatal("expected first caller to stop waiting, once its context is done")
+	}
+
+	close(release)
+	if v := <-second; v != "done" {
+		t.Fatalf("expected call to complete for second caller, found %v", v)
+	}
+}
+
+func TestFlightTimeout(t *testing.T) {
+	g := newFlightGroup("test", time.Millisecond*time.Duration(10))
+
+	v, ok := g.do(context.Background(), "key", func(ctx context.Context) interface{} {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	if !ok || v != context.DeadlineExceeded {
+		t.Fatalf("expected call to hit its own timeout, found %v, %v", v, ok)
+	}
+}
+
+func TestFlightPanic(t *testing.T) {
+	g := newFlightGroup("test", time.Second)
+
+	if _, ok := g.do(context.Background(), "key", func(ctx context.Context) interface{} {
+		panic("boom")
+	}); ok {
+		t.Fatal("expected panicking call to fail")
+	}
+
+	if err := flightError(context.Background()); err != errFlightFailed {
+		t.Fatalf("expected flight failure, found %v", err)
+	}
+}
@@ -1,11 +1,9 @@
 package tracker
 
 import (
-	"bytes"
-	"encoding/json"
-	"io/ioutil"
-	"log"
-	"net/http"
+	"app/tracing"
+	"context"
+	"logger"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethclient"
@@ -13,9 +11,12 @@ import (
 )
 
 // Given burn txHash, checks whether it has been checkpointed or not,
-// by querying `check-point-manager` micro service
-func getCheckPointStatus(client *ethclient.Client, db *gorm.DB, txHash common.Hash) *TransactionState {
-	if status := getChildChaintxStatusFromDB(db, txHash); status != nil {
+// by querying `check-point-tracker` worker
+func getCheckPointStatus(ctx context.Context, client *ethclient.Client, db *gorm.DB, txHash common.Hash) *TransactionState {
+	ctx, span := tracing.Start(ctx, "getCheckPointStatus", tracing.KindInternal, tracing.Attr("tx.hash", txHash.Hex()))
+	defer span.End()
+
+	if status := getChildChaintxStatusFromDB(ctx, db, txHash); status != nil {
 		if status.Code == -4 {
 			return &TransactionState{
 				Code:    status.Code,
@@ -27,7 +28,7 @@ func getCheckPointStatus(client *ethclient.Client, db *gorm.DB, txHash common.Ha
 	// first checking burn status
 	// if response code is not equivalent to burnt
 	// we're responding with response received from `getBurnStatus`
-	_state := getBurnStatus(client, db, txHash)
+	_state := getBurnStatus(ctx, client, db, txHash)
 	if _state.Code != -3 {
 		return _state
 	}
@@ -35,62 +36,34 @@ func getCheckPointStatus(client *ethclient.Client, db *gorm.DB, txHash common.Ha
 	// fetching child chain `blockNumber` in which
 	// transaction was mined; given txHash, we're fetching
 	// respective receipt
-	receipt := getTransactionReceipt(client, txHash)
+	receipt, err := getTransactionReceipt(ctx, client, txHash)
+	if err != nil {
+		return receiptFailedStatus(ctx, client, txHash, err, -3, "Burnt")
+	}
 	if receipt == nil {
 		return _state
 	}
 
-	// sending request to `check-point-tracker` service
-	// with `blockNumber` as payload, so that we can check
-	// whether this child chain block has been checkpointed or not
+	// asking `check-point-tracker` whether this child chain
+	// block has been checkpointed or not
 	//
 	// If yes, we can also say burn tx has been checkpointed
-	resp, err := http.Post(get("CheckPointTracker"),
-		"application/json",
-		bytes.NewReader((&CheckPointed{
-			BlockNumber: receipt.BlockNumber.String(),
-		}).JSON()))
-	if err != nil {
-		log.Println("[!] ", err)
-
-		return &TransactionState{
-			Code:    -3,
-			Message: "Burnt",
-		}
-	}
-
-	defer resp.Body.Close()
-
-	var _tmp TransactionState
-
-	data, err := ioutil.ReadAll(resp.Body)
+	checkPointed, err := getCheckPointTracker().IsCheckPointed(ctx, receipt.BlockNumber)
 	if err != nil {
-		log.Println("[!] ", err)
+		workerLog.Ctx(ctx).Error("Failed to reach `check-point-tracker`", logger.Fields{"error": err, "txHash": txHash.Hex()})
+		recordWorkerFailure("check_point_tracker")
 
-		return &TransactionState{
-			Code:    -3,
-			Message: "Burnt",
-		}
-	}
-
-	err = json.Unmarshal(data, &_tmp)
-	if err != nil {
-		log.Println("[!] ", err)
-
-		return &TransactionState{
-			Code:    -3,
-			Message: "Burnt",
-		}
+		return fallbackStatus(-3, "Burnt", "check-point-tracker")
 	}
 
-	if _tmp.Code == 0 {
+	if !checkPointed {
 		return &TransactionState{
 			Code:    -3,
 			Message: "Burnt",
 		}
 	}
 
-	putChildChainTxStatusInDB(db, txHash, -4, "Checkpointed")
+	putChildChainTxStatusInDB(ctx, db, txHash, -4, "Checkpointed")
 
 	return &TransactionState{
 		Code:    -4,
@@ -0,0 +1,703 @@
+package tracker
+
+import (
+	"app/nft"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"logger"
+	"math/big"
+	"os"
+	"os/signal"
+	"os/user"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	cpt "check-point-tracker/app"
+	sim "state-id-manager/app"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/maticnetwork/deposits-withdrawals-tracking/app/client"
+	"gorm.io/gorm"
+)
+
+// Usage of `bridge-cli`
+const cliUsage = `Usage : bridge-cli [-json] [-fresh] <command> [args] [flags]
+
+Commands :
+  status <approval|deposit|pos-burn|pos-exit|plasma-burn|plasma-exit> <hash>
+  status plasma-confirm <confirm hash> -burn <hash>
+  status withdraw -burn <burn hash> [-confirm <hash>] [-exit <hash>]
+  status withdraw -pos <burn hash> [-exit <hash>]
+  status withdraw -plasma <burn hash> [-confirm <hash>] [-exit <hash>]
+  checkpoint for-block <number>
+  statesync <id>
+  watch <hash> [-endpoint <name>] [-burn <hash>] [-interval <duration>]
+  db show <hash>
+  db recheck -pending [-limit <n>]
+
+Flags :
+  -json             Print JSON, instead of table
+  -fresh            Ignore statuses persisted in DB, finding them out afresh
+  -config <file>    Path to config file, instead of .env
+  -<param> <value>  Overrides config param e.g. -RootRPC http://localhost:8545
+`
+
+// Everything command being run by `bridge-cli` needs, set up once
+type cli struct {
+	rootClient   *ethclient.Client
+	childClient  *ethclient.Client
+	db           *gorm.DB
+	recheckables map[string]*recheckable
+	json         bool
+	fresh        bool
+}
+
+// Parses flags, which are allowed to be present anywhere among args,
+// returning positional args
+func parseArgs(flags *flag.FlagSet, args []string) []string {
+	positional := make([]string, 0)
+
+	for {
+		flags.Parse(args)
+		if flags.NArg() == 0 {
+			break
+		}
+
+		positional = append(positional, flags.Arg(0))
+		args = flags.Args()[1:]
+	}
+
+	return positional
+}
+
+// Parses tx hash, given in hex form
+func parseTxHash(hash string) (common.Hash, error) {
+	if !strings.HasPrefix(strings.ToLower(hash), "0x") || len(hash) != 66 {
+		return common.Hash{}, fmt.Errorf("bad tx hash `%s`", hash)
+	}
+
+	return common.HexToHash(hash), nil
+}
+
+// How often in-process worker, started by `bridge-cli`, is checked for having
+// read what it tracks from chain, before it's asked anything
+const cliWorkerPollInterval = time.Millisecond * time.Duration(100)
+
+// Waits until given check passes or context gets done
+func waitUntil(ctx context.Context, ready func() bool) error {
+	for !ready() {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(cliWorkerPollInterval):
+		}
+	}
+
+	return nil
+}
+
+// In-process `check-point-tracker`, started by `bridge-cli`, which is asked
+// only after it has read latest checkpoint from root chain
+type startedCheckPointTracker struct {
+	*cpt.Tracker
+}
+
+func (s *startedCheckPointTracker) IsCheckPointed(ctx context.Context, blockNumber *big.Int) (bool, error) {
+	if err := waitUntil(ctx, func() bool {
+		_, end := s.Range()
+		return end.Sign() > 0
+	}); err != nil {
+		return false, fmt.Errorf("`check-point-tracker` not ready : %w", err)
+	}
+
+	return s.Tracker.IsCheckPointed(ctx, blockNumber)
+}
+
+// In-process `state-id-manager`, started by `bridge-cli`, which is asked
+// only after it has read `lastStateId` from child chain
+type startedStateIDManager struct {
+	*localStateIDManager
+}
+
+func (s *startedStateIDManager) LastStateID(ctx context.Context) (*big.Int, error) {
+	var (
+		id  *big.Int
+		err error
+	)
+
+	if err := waitUntil(ctx, func() bool {
+		id, err = s.localStateIDManager.LastStateID(ctx)
+		return err == nil
+	}); err != nil {
+		return nil, fmt.Errorf("`state-id-manager` not ready : %w", err)
+	}
+
+	return id, nil
+}
+
+// Sets up workers to be talked to, returning function to be invoked for stopping
+// those started in this process
+//
+// When `WorkerMode` is `all-in-one`, workers whose URLs are not set are started in
+// this process, same as service does, so that it's not required to be running,
+// otherwise they're talked to over HTTP
+func setUpCLIWorkers(conf *Config) (func(), error) {
+	if conf.WorkerMode != "all-in-one" {
+		connectToWorkers(conf)
+		return func() {}, nil
+	}
+
+	stops := make([]func(), 0, 2)
+	stop := func() {
+		for _, v := range stops {
+			v()
+		}
+	}
+
+	var _checkPointTracker CheckPointTracker
+	if conf.CheckPointTracker != "" {
+		_checkPointTracker = newCheckPointTracker(conf.CheckPointTracker)
+	} else {
+		tracker := cpt.NewTracker(conf.checkPointTrackerConfig())
+		tracker.Start()
+		stops = append(stops, tracker.Stop)
+
+		_checkPointTracker = &startedCheckPointTracker{tracker}
+	}
+
+	var _stateIDManager StateIDManager
+	if conf.StateIDManager != "" {
+		_stateIDManager = newStateIDManager(conf.StateIDManager)
+	} else {
+		// Index is held by one process at a time, so it can't be opened,
+		// while service is running on same host
+		manager, err := sim.NewManager(conf.stateIDManagerConfig())
+		if err != nil {
+			stop()
+			return nil, fmt.Errorf("failed to open index of `state-id-manager`, set `StateIDManager`, if service is running : %w", err)
+		}
+		manager.Start()
+		stops = append(stops, manager.Stop)
+
+		_stateIDManager = &startedStateIDManager{&localStateIDManager{manager}}
+	}
+
+	setWorkers(_checkPointTracker, _stateIDManager, newPOSExitChecker(conf.POSExitChecker))
+
+	return stop, nil
+}
+
+// Config params, without which `bridge-cli` can't do anything
+var cliRequiredKeys = []string{"RootRPC", "ChildRPC", "ExitNFT", "DB_USER", "DB_HOST", "DB_NAME"}
+
+// Separates config flags i.e. `-config` & those named after config params, which
+// override what's in config file, from args, returning both
+func splitConfigArgs(args []string) ([]string, []string) {
+	names := map[string]bool{"config": true}
+	for _, v := range params.Keys() {
+		names[v.Name] = true
+	}
+
+	configArgs := make([]string, 0)
+	rest := make([]string, 0, len(args))
+
+	for i := 0; i < len(args); i++ {
+		name := strings.TrimLeft(args[i], "-")
+		if !strings.HasPrefix(args[i], "-") || !names[strings.SplitN(name, "=", 2)[0]] {
+			rest = append(rest, args[i])
+			continue
+		}
+
+		configArgs = append(configArgs, args[i])
+
+		// Value given as next arg
+		if !strings.Contains(name, "=") && i+1 < len(args) {
+			configArgs = append(configArgs, args[i+1])
+			i++
+		}
+	}
+
+	return configArgs, rest
+}
+
+// RunCLI - Command line tool for operators & support staff, which finds out status of
+// tx(s) using same status checking functions as service, without it being running,
+// given path to config file & arguments, where config params can be overridden
+// using flags, same as service
+//
+// Only RPC nodes, `ExitNFT` & DB are required, DB is not migrated
+func RunCLI(file string, args []string) {
+	configArgs, args := splitConfigArgs(args)
+
+	if err := params.Read(file, configArgs); err != nil {
+		appLog.Fatal("Failed to read config", logger.Fields{"error": err, "file": file})
+	}
+
+	conf, err := loadConfig(false)
+	if err != nil {
+		appLog.Fatal("Bad config", logger.Fields{"error": err})
+	}
+
+	for _, v := range cliRequiredKeys {
+		if get(v) == "" {
+			appLog.Fatal("Bad config", logger.Fields{"error": fmt.Sprintf("`%s` is required", v)})
+		}
+	}
+
+	setConfig(conf)
+	setUpLogging()
+
+	flags := flag.NewFlagSet("bridge-cli", flag.ExitOnError)
+	flags.Usage = func() {
+		fmt.Fprint(os.Stderr, cliUsage)
+	}
+
+	asJSON := flags.Bool("json", false, "Print JSON, instead of table")
+	fresh := flags.Bool("fresh", false, "Ignore statuses persisted in DB")
+	burn := flags.String("burn", "", "Burn tx hash, for Plasma confirm withdraw tx, or of withdraw on bridge to be inferred")
+	pos := flags.String("pos", "", "Burn tx hash of POS withdraw")
+	plasma := flags.String("plasma", "", "Burn tx hash of Plasma withdraw")
+	confirm := flags.String("confirm", "", "Confirm withdraw tx hash of Plasma withdraw")
+	exit := flags.String("exit", "", "Exit tx hash of withdraw")
+	endpoint := flags.String("endpoint", "deposit", "Status checking endpoint, tx hash is watched using")
+	interval := flags.Duration("interval", time.Second*time.Duration(15), "How often status is to be found out")
+	pending := flags.Bool("pending", false, "Recheck persisted non-terminal statuses")
+	limit := flags.Int("limit", conf.BulkRecheckLimit, "Maximum number of statuses to be rechecked")
+
+	positional := parseArgs(flags, args)
+	if len(positional) == 0 {
+		fmt.Fprint(os.Stderr, cliUsage)
+		os.Exit(2)
+	}
+
+	rootClient, err := getClient(true)
+	if err != nil {
+		appLog.Fatal("Failed to connect to root chain", logger.Fields{"error": err})
+	}
+	childClient, err := getClient(false)
+	if err != nil {
+		appLog.Fatal("Failed to connect to child chain", logger.Fields{"error": err})
+	}
+	_nft, err := nft.NewNft(conf.ExitNFT, rootClient)
+	if err != nil {
+		appLog.Fatal("Failed to get instance of ExitNFT", logger.Fields{"error": err})
+	}
+
+	db := connectToDB()
+
+	setUpPool(conf.MaxConcurrency)
+
+	stopWorkers, err := setUpCLIWorkers(conf)
+	if err != nil {
+		appLog.Fatal("Failed to set up workers", logger.Fields{"error": err})
+	}
+
+	_cli := &cli{
+		rootClient:   rootClient,
+		childClient:  childClient,
+		db:           db,
+		recheckables: recheckables(rootClient, childClient, db, _nft),
+		json:         *asJSON,
+		fresh:        *fresh,
+	}
+
+	// Interrupting cancels whatever is being done
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt)
+
+	go func() {
+		<-signals
+		cancel()
+	}()
+
+	ctx = logger.WithRequestID(ctx, strings.Join([]string{"cli", fmt.Sprintf("%d", time.Now().Unix())}, "-"))
+
+	command, rest := positional[0], positional[1:]
+
+	switch {
+	case command == "status" && len(rest) == 1 && rest[0] == "withdraw":
+		err = _cli.withdrawStatus(ctx, *burn, *pos, *plasma, *confirm, *exit, _nft)
+	case command == "status" && len(rest) == 2:
+		err = _cli.status(ctx, rest[0], rest[1], *burn)
+	case command == "checkpoint" && len(rest) == 2 && rest[0] == "for-block":
+		err = _cli.checkpoint(ctx, rest[1])
+	case command == "statesync" && len(rest) == 1:
+		err = _cli.stateSync(ctx, rest[0])
+	case command == "watch" && len(rest) == 1:
+		err = _cli.watch(ctx, *endpoint, rest[0], *burn, *interval)
+	case command == "db" && len(rest) == 2 && rest[0] == "show":
+		err = _cli.show(ctx, rest[1])
+	case command == "db" && len(rest) == 1 && rest[0] == "recheck" && *pending:
+		err = _cli.recheck(ctx, *limit)
+	default:
+		stopWorkers()
+
+		fmt.Fprint(os.Stderr, cliUsage)
+		os.Exit(2)
+	}
+
+	stopWorkers()
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error : %s\n", err.Error())
+		os.Exit(1)
+	}
+}
+
+// Context, lookups made using which are given `RequestTimeout`, while ignoring
+// persisted statuses, when asked to
+func (c *cli) lookupContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if c.fresh {
+		ctx = withoutCache(ctx)
+	}
+
+	return context.WithTimeout(ctx, getConfig().RequestTimeout)
+}
+
+// Prints value as indented JSON
+func (c *cli) printJSON(v interface{}) error {
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(v)
+}
+
+// Prints rows as table, with given header
+func (c *cli) printTable(header []string, rows [][]string) error {
+	writer := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(writer, strings.Join(header, "\t"))
+
+	for _, v := range rows {
+		fmt.Fprintln(writer, strings.Join(v, "\t"))
+	}
+
+	return writer.Flush()
+}
+
+// Prints statuses keyed by tx hash, in given order
+func (c *cli) printStatuses(hashes []common.Hash, statuses map[common.Hash]*TransactionState) error {
+	if c.json {
+		return c.printJSON(statuses)
+	}
+
+	rows := make([][]string, 0, len(hashes))
+	for _, h := range hashes {
+		v, ok := statuses[h]
+		if !ok {
+			continue
+		}
+
+		rows = append(rows, []string{h.Hex(), fmt.Sprintf("%d", v.Code), v.Message, fmt.Sprintf("%t", v.Degraded), v.Reason})
+	}
+
+	return c.printTable([]string{"Tx Hash", "Code", "Message", "Degraded", "Reason"}, rows)
+}
+
+// Endpoint, given its name e.g. `deposit` or itself
+func endpointOf(name string) string {
+	return "/v1/" + strings.TrimPrefix(name, "/v1/")
+}
+
+// Looks up status checking function, given name of its endpoint
+func (c *cli) recheckable(name string) (*recheckable, error) {
+	r, ok := c.recheckables[endpointOf(name)]
+	if !ok {
+		return nil, fmt.Errorf("unknown endpoint `%s`", name)
+	}
+
+	return r, nil
+}
+
+// Prints status of tx, found out using status checking function behind given endpoint
+func (c *cli) status(ctx context.Context, name string, hash string, burn string) error {
+	r, err := c.recheckable(name)
+	if err != nil {
+		return err
+	}
+
+	txHash, err := parseTxHash(hash)
+	if err != nil {
+		return err
+	}
+
+	var burnTxHash common.Hash
+	if endpointOf(name) == client.PlasmaConfirmEndpoint {
+		if burnTxHash, err = parseTxHash(burn); err != nil {
+			return fmt.Errorf("burn tx hash is required : %s", err.Error())
+		}
+	}
+
+	ctx, cancel := c.lookupContext(ctx)
+	defer cancel()
+
+	status := r.status(ctx, txHash, burnTxHash)
+	if status == nil {
+		return fmt.Errorf("failed to find out status")
+	}
+
+	return c.printStatuses([]common.Hash{txHash}, map[common.Hash]*TransactionState{txHash: status})
+}
+
+// Prints status of withdraw, found out using most advanced tx hash of flow, as `/v2/withdraw` does
+//
+// When burn tx hash is given using `-burn`, bridge is inferred from it
+func (c *cli) withdrawStatus(ctx context.Context, burn string, pos string, plasma string, confirm string, exit string, _nft *nft.Nft) error {
+	given := 0
+	for _, v := range []string{burn, pos, plasma} {
+		if v != "" {
+			given++
+		}
+	}
+	if given != 1 {
+		return fmt.Errorf("one of `-burn`, `-pos` or `-plasma` burn tx hash is required")
+	}
+
+	tx := &WithdrawTransaction{}
+
+	var err error
+
+	switch {
+	case pos != "":
+		isPOS := true
+		tx.IsPOS = &isPOS
+		tx.BurnTxHash, err = parseTxHash(pos)
+	case plasma != "":
+		isPOS := false
+		tx.IsPOS = &isPOS
+		tx.BurnTxHash, err = parseTxHash(plasma)
+	default:
+		tx.BurnTxHash, err = parseTxHash(burn)
+	}
+	if err != nil {
+		return err
+	}
+
+	if confirm != "" {
+		if tx.ConfirmWithdrawTxHash, err = parseTxHash(confirm); err != nil {
+			return err
+		}
+	}
+	if exit != "" {
+		if tx.ExitTxHash, err = parseTxHash(exit); err != nil {
+			return err
+		}
+	}
+
+	ctx, cancel := c.lookupContext(ctx)
+	defer cancel()
+
+	isPOS, status := resolveBridge(ctx, c.childClient, c.db, tx)
+	if status == nil {
+		tx = discoverTxs(ctx, c.rootClient, c.childClient, c.db, tx, isPOS)
+		status = getWithdrawTxStatusWithParties(ctx, c.rootClient, c.childClient, c.db, _nft, tx, isPOS)
+	}
+
+	// Discovered tx hashes are told about, so that they can be supplied next time
+	if confirm == "" && !isEmptyTxHash(tx.ConfirmWithdrawTxHash) {
+		fmt.Fprintf(os.Stderr, "Discovered confirm tx : %s\n", tx.ConfirmWithdrawTxHash.Hex())
+	}
+	if exit == "" && !isEmptyTxHash(tx.ExitTxHash) {
+		fmt.Fprintf(os.Stderr, "Discovered exit tx : %s\n", tx.ExitTxHash.Hex())
+	}
+	if status == nil {
+		return fmt.Errorf("failed to find out status")
+	}
+
+	// Accounts involved in exit are told about, when they're not same as burner
+	if v := status.Exit; v != nil && !c.json {
+		if v.ThirdParty {
+			fmt.Fprintf(os.Stderr, "Exit submitted by third party : %s\n", v.Submitter.Hex())
+		}
+		if v.RecipientMismatch {
+			fmt.Fprintf(os.Stderr, "Funds received by other account than burner : %s\n", v.Recipient.Hex())
+		}
+	}
+
+	return c.printStatuses([]common.Hash{tx.BurnTxHash}, map[common.Hash]*TransactionState{tx.BurnTxHash: status})
+}
+
+// Prints whether given child chain block has been checkpointed or not
+func (c *cli) checkpoint(ctx context.Context, number string) error {
+	blockNumber, ok := big.NewInt(0).SetString(number, 10)
+	if !ok {
+		return fmt.Errorf("bad block number `%s`", number)
+	}
+
+	ctx, cancel := c.lookupContext(ctx)
+	defer cancel()
+
+	checkPointed, err := getCheckPointTracker().IsCheckPointed(ctx, blockNumber)
+	if err != nil {
+		return err
+	}
+
+	if c.json {
+		return c.printJSON(map[string]interface{}{"blockNumber": blockNumber.String(), "checkPointed": checkPointed})
+	}
+
+	return c.printTable([]string{"Block", "Checkpointed"}, [][]string{{blockNumber.String(), fmt.Sprintf("%t", checkPointed)}})
+}
+
+// Prints whether state sync with given ID has reached child chain or not, along
+// with its details, when indexed by `state-id-manager`
+func (c *cli) stateSync(ctx context.Context, id string) error {
+	stateID, ok := big.NewInt(0).SetString(id, 10)
+	if !ok {
+		return fmt.Errorf("bad state ID `%s`", id)
+	}
+
+	ctx, cancel := c.lookupContext(ctx)
+	defer cancel()
+
+	lastStateID, err := getStateIDManager().LastStateID(ctx)
+	if err != nil {
+		return err
+	}
+
+	state, err := getStateIDManager().State(ctx, stateID)
+	if err != nil {
+		return err
+	}
+
+	synced := lastStateID.Cmp(stateID) >= 0
+
+	if c.json {
+		return c.printJSON(map[string]interface{}{"id": stateID.String(), "lastStateId": lastStateID.String(), "synced": synced, "state": state})
+	}
+
+	row := []string{stateID.String(), lastStateID.String(), fmt.Sprintf("%t", synced), "", "", "", ""}
+	if state != nil {
+		row[3] = state.RootTxHash
+		row[4] = state.TxHash
+		row[5] = fmt.Sprintf("%t", state.Success)
+		row[6] = time.Unix(state.SyncTime, 0).UTC().Format(time.RFC3339)
+	}
+
+	return c.printTable([]string{"State ID", "Last State ID", "Synced", "Root Tx Hash", "Child Tx Hash", "Success", "Synced At"}, [][]string{row})
+}
+
+// Keeps finding out status of tx every `interval`, printing it whenever it changes,
+// until it becomes final or interrupted
+func (c *cli) watch(ctx context.Context, name string, hash string, burn string, interval time.Duration) error {
+	r, err := c.recheckable(name)
+	if err != nil {
+		return err
+	}
+
+	txHash, err := parseTxHash(hash)
+	if err != nil {
+		return err
+	}
+
+	var burnTxHash common.Hash
+	if endpointOf(name) == client.PlasmaConfirmEndpoint {
+		if burnTxHash, err = parseTxHash(burn); err != nil {
+			return fmt.Errorf("burn tx hash is required : %s", err.Error())
+		}
+	}
+
+	var last *TransactionState
+
+	for {
+		_ctx, cancel := c.lookupContext(ctx)
+		status := r.status(_ctx, txHash, burnTxHash)
+		cancel()
+
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		if status != nil && (last == nil || last.Code != status.Code || last.Degraded != status.Degraded) {
+			if c.json {
+				c.printJSON(map[string]interface{}{"time": time.Now().UTC(), "txHash": txHash, "status": status})
+			} else {
+				fmt.Printf("%s  %s  %d  %s\n", time.Now().UTC().Format(time.RFC3339), txHash.Hex(), status.Code, status.Message)
+			}
+
+			last = status
+		}
+
+		// Status, which won't change anymore
+		if status != nil && !status.Degraded {
+			if _, ok := statusTTLs[status.Code]; !ok {
+				return nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(interval):
+		}
+	}
+}
+
+// Prints persisted rows & history of status of tx
+func (c *cli) show(ctx context.Context, hash string) error {
+	txHash, err := parseTxHash(hash)
+	if err != nil {
+		return err
+	}
+
+	resp, err := adminTx(ctx, c.db, txHash)
+	if err != nil {
+		return err
+	}
+
+	if c.json {
+		return c.printJSON(resp)
+	}
+
+	rows := make([][]string, 0)
+	for _, v := range []*client.TxRow{resp.RootChain, resp.ChildChain} {
+		if v != nil {
+			rows = append(rows, []string{v.Chain, "current", fmt.Sprintf("%d", v.Code), v.Message, ""})
+		}
+	}
+	for _, v := range resp.History {
+		rows = append(rows, []string{v.Chain, v.CreatedAt.Format(time.RFC3339), fmt.Sprintf("%d", v.Code), v.Message, v.RequestID})
+	}
+
+	return c.printTable([]string{"Chain", "At", "Code", "Message", "Request ID"}, rows)
+}
+
+// Rechecks persisted non-terminal statuses, same as `POST /admin/recheck`, but
+// waiting for all of them, printing how each one has changed
+func (c *cli) recheck(ctx context.Context, limit int) error {
+	rows, err := findNonTerminalTxs(ctx, c.db, limit)
+	if err != nil {
+		return err
+	}
+
+	actor := "cli"
+	if _user, err := user.Current(); err == nil {
+		actor = "cli:" + _user.Username
+	}
+
+	writeAudit(ctx, c.db, actor, "bulk-recheck", "non-terminal", map[string]interface{}{"limit": limit, "count": len(rows)}, "")
+
+	statuses := recheckNonTerminalTxs(ctx, c.db, c.recheckables, rows)
+
+	if c.json {
+		return c.printJSON(statuses)
+	}
+
+	table := make([][]string, 0, len(rows))
+	for _, v := range rows {
+		status, ok := statuses[v.txHash]
+		if !ok {
+			continue
+		}
+
+		table = append(table, []string{v.chain, v.txHash.Hex(), fmt.Sprintf("%d", v.code), fmt.Sprintf("%d", status.Code), status.Message, fmt.Sprintf("%t", status.Degraded)})
+	}
+
+	return c.printTable([]string{"Chain", "Tx Hash", "Before", "After", "Message", "Degraded"}, table)
+}
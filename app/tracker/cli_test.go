@@ -0,0 +1,69 @@
+package tracker
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/maticnetwork/deposits-withdrawals-tracking/app/client"
+)
+
+func TestSplitConfigArgs(t *testing.T) {
+	configArgs, rest := splitConfigArgs([]string{"-json", "status", "-config", "prod.env", "deposit", "-RootRPC=http://localhost:8545", "0x01", "-DB_HOST", "db", "-burn", "0x02"})
+
+	if expected := []string{"-config", "prod.env", "-RootRPC=http://localhost:8545", "-DB_HOST", "db"}; !reflect.DeepEqual(configArgs, expected) {
+		t.Fatalf("expected config args %v, found %v", expected, configArgs)
+	}
+
+	if expected := []string{"-json", "status", "deposit", "0x01", "-burn", "0x02"}; !reflect.DeepEqual(rest, expected) {
+		t.Fatalf("expected args %v, found %v", expected, rest)
+	}
+}
+
+func TestCLIWorkersOverHTTP(t *testing.T) {
+	env := newTestEnv(t, map[string]interface{}{"WorkerMode": "all-in-one"})
+	defer env.close()
+
+	conf := getConfig()
+
+	// Workers with URLs set are not started in-process, even when running `all-in-one`
+	stop, err := setUpCLIWorkers(conf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stop()
+
+	if _, ok := getCheckPointTracker().(*client.CheckPointTracker); !ok {
+		t.Fatalf("expected `check-point-tracker` to be reached over HTTP, found %T", getCheckPointTracker())
+	}
+
+	env.workers.Sync(&client.CommittedState{ID: "7"})
+
+	id, err := getStateIDManager().LastStateID(context.Background())
+	if err != nil || id.Int64() != 7 {
+		t.Fatalf("expected `lastStateId` 7 from `state-id-manager`, found %v, %v", id, err)
+	}
+}
+
+func TestWaitUntil(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), cliWorkerPollInterval*3)
+	defer cancel()
+
+	checks := 0
+	if err := waitUntil(ctx, func() bool {
+		checks++
+		return checks == 2
+	}); err != nil || checks != 2 {
+		t.Fatalf("expected to wait until check passes, found %v after %d checks", err, checks)
+	}
+
+	start := time.Now()
+	if err := waitUntil(ctx, func() bool { return false }); err != context.DeadlineExceeded {
+		t.Fatalf("expected to give up once context is done, found %v", err)
+	}
+
+	if time.Since(start) > time.Second {
+		t.Fatal("expected to give up at deadline")
+	}
+}
@@ -5,8 +5,8 @@ import "github.com/ethereum/go-ethereum/ethclient"
 // Connects to RPC endpoint on root/ child chain
 func getClient(isRoot bool) (*ethclient.Client, error) {
 	if isRoot {
-		return ethclient.Dial(get("RootRPC"))
+		return ethclient.Dial(getConfig().RootRPC)
 	}
 
-	return ethclient.Dial(get("ChildRPC"))
+	return ethclient.Dial(getConfig().ChildRPC)
 }
@@ -1,84 +1,201 @@
 package tracker
 
 import (
-	"log"
+	"app/tracing"
+	"context"
+	"logger"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"gorm.io/gorm"
 )
 
+// How long persisting status is given, once it's been found out, irrespective
+// of deadline of request, it was found out for
+const persistTimeout = time.Second * time.Duration(10)
+
+// Context carrying values i.e. request ID & span of parent, but neither
+// its deadline nor its cancellation
+type detachedContext struct {
+	context.Context
+}
+
+func (detachedContext) Deadline() (time.Time, bool) {
+	return time.Time{}, false
+}
+
+func (detachedContext) Done() <-chan struct{} {
+	return nil
+}
+
+func (detachedContext) Err() error {
+	return nil
+}
+
+// Context, status is to be persisted using, so that status found out just before or
+// after request deadline is hit, is persisted, instead of being found out again
+func persistContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(detachedContext{ctx}, persistTimeout)
+}
+
 // Retrieves tx status, performed on root chain, given tx hash ( for deposit/ withdraw op )
-func getRootChaintxStatusFromDB(db *gorm.DB, txHash common.Hash) *RootChain {
+func getRootChaintxStatusFromDB(ctx context.Context, db *gorm.DB, txHash common.Hash) *RootChain {
+	// Status is to be found out afresh, as if it's never been persisted
+	if cacheBypassed(ctx) {
+		return nil
+	}
+
+	_tmp := findRootChainTx(ctx, db, txHash)
+	recordDBLookup(_tmp != nil)
+
+	return _tmp
+}
+
+// Looks up root chain tx entry, without considering it as status lookup
+func findRootChainTx(ctx context.Context, db *gorm.DB, txHash common.Hash) *RootChain {
+	ctx, span := tracing.Start(ctx, "db.findRootChainTx", tracing.KindClient, tracing.Attr("tx.hash", txHash.Hex()))
+	defer span.End()
+
 	var rootChainTx RootChain
 
-	if err := db.Model(&RootChain{}).Where("txhash = ?", txHash.Hex()).First(&rootChainTx).Error; err != nil {
+	if err := db.WithContext(ctx).Model(&RootChain{}).Where("txhash = ?", txHash.Hex()).First(&rootChainTx).Error; err != nil {
+		span.SetAttributes(tracing.Attr("db.found", false))
 		return nil
 	}
 
+	span.SetAttributes(tracing.Attr("db.found", true))
+
 	return &rootChainTx
 }
 
 // Updates tx status, performed on root chain, given tx hash ( for deposit/ withdraw op )
 //
 // If not present in db, creates entry
-func putRootChainTxStatusInDB(db *gorm.DB, txHash common.Hash, code int, msg string) {
-	if getRootChaintxStatusFromDB(db, txHash) == nil {
+func putRootChainTxStatusInDB(ctx context.Context, db *gorm.DB, txHash common.Hash, code int, msg string) {
+	ctx, cancel := persistContext(ctx)
+	defer cancel()
+
+	ctx, span := tracing.Start(ctx, "db.putRootChainTxStatus", tracing.KindClient, tracing.Attr("tx.hash", txHash.Hex()), tracing.Attr("tx.status", code))
+	defer span.End()
+
+	_tmp := findRootChainTx(ctx, db, txHash)
+	if _tmp == nil {
 
-		if err := db.Create(&RootChain{
+		if err := db.WithContext(ctx).Create(&RootChain{
 			TransactionHash: txHash.Hex(),
 			Code:            code,
 			Message:         msg,
 		}).Error; err != nil {
-			log.Println("[!] ", err)
+			span.RecordError(err)
+			dbLog.Ctx(ctx).Error("Failed to create tx status", logger.Fields{"error": err, "txHash": txHash.Hex(), "code": code})
+			return
 		}
 
+		recordStatusChange(ctx, db, "root", txHash, code, msg)
 		return
 	}
 
-	if err := db.Model(&RootChain{}).Where("txhash = ?", txHash.Hex()).Select("code", "msg").Updates(&RootChain{
-		Code:    code,
-		Message: msg,
+	// Time of last change is kept, while same status is found out again
+	columns := []string{"code", "msg"}
+	if _tmp.Code != code {
+		columns = append(columns, "updated_at")
+	}
+
+	if err := db.WithContext(ctx).Model(&RootChain{}).Where("txhash = ?", txHash.Hex()).Select(columns).Updates(&RootChain{
+		Code:      code,
+		Message:   msg,
+		UpdatedAt: time.Now().UTC(),
 	}).Error; err != nil {
 
-		log.Println("[!] ", err)
+		span.RecordError(err)
+		dbLog.Ctx(ctx).Error("Failed to update tx status", logger.Fields{"error": err, "txHash": txHash.Hex(), "code": code})
+		return
+
+	}
 
+	// Only changes are recorded, not each time same status is found out
+	if _tmp.Code != code {
+		recordStatusChange(ctx, db, "root", txHash, code, msg)
 	}
 }
 
 // Retrieves tx status, performed on child chain, given tx hash ( for deposit/ withdraw op )
-func getChildChaintxStatusFromDB(db *gorm.DB, txHash common.Hash) *ChildChain {
+func getChildChaintxStatusFromDB(ctx context.Context, db *gorm.DB, txHash common.Hash) *ChildChain {
+	// Status is to be found out afresh, as if it's never been persisted
+	if cacheBypassed(ctx) {
+		return nil
+	}
+
+	_tmp := findChildChainTx(ctx, db, txHash)
+	recordDBLookup(_tmp != nil)
+
+	return _tmp
+}
+
+// Looks up child chain tx entry, without considering it as status lookup
+func findChildChainTx(ctx context.Context, db *gorm.DB, txHash common.Hash) *ChildChain {
+	ctx, span := tracing.Start(ctx, "db.findChildChainTx", tracing.KindClient, tracing.Attr("tx.hash", txHash.Hex()))
+	defer span.End()
+
 	var childChainTx ChildChain
 
-	if err := db.Model(&ChildChain{}).Where("txhash = ?", txHash.Hex()).First(&childChainTx).Error; err != nil {
+	if err := db.WithContext(ctx).Model(&ChildChain{}).Where("txhash = ?", txHash.Hex()).First(&childChainTx).Error; err != nil {
+		span.SetAttributes(tracing.Attr("db.found", false))
 		return nil
 	}
 
+	span.SetAttributes(tracing.Attr("db.found", true))
+
 	return &childChainTx
 }
 
 // Updates tx status, performed on child chain, given tx hash ( for deposit/ withdraw op )
 //
 // If not present in db, creates entry
-func putChildChainTxStatusInDB(db *gorm.DB, txHash common.Hash, code int, msg string) {
-	if getChildChaintxStatusFromDB(db, txHash) == nil {
+func putChildChainTxStatusInDB(ctx context.Context, db *gorm.DB, txHash common.Hash, code int, msg string) {
+	ctx, cancel := persistContext(ctx)
+	defer cancel()
+
+	ctx, span := tracing.Start(ctx, "db.putChildChainTxStatus", tracing.KindClient, tracing.Attr("tx.hash", txHash.Hex()), tracing.Attr("tx.status", code))
+	defer span.End()
+
+	_tmp := findChildChainTx(ctx, db, txHash)
+	if _tmp == nil {
 
-		if err := db.Create(&ChildChain{
+		if err := db.WithContext(ctx).Create(&ChildChain{
 			TransactionHash: txHash.Hex(),
 			Code:            code,
 			Message:         msg,
 		}).Error; err != nil {
-			log.Println("[!] ", err)
+			span.RecordError(err)
+			dbLog.Ctx(ctx).Error("Failed to create tx status", logger.Fields{"error": err, "txHash": txHash.Hex(), "code": code})
+			return
 		}
 
+		recordStatusChange(ctx, db, "child", txHash, code, msg)
 		return
 	}
 
-	if err := db.Model(&ChildChain{}).Where("txhash = ?", txHash.Hex()).Select("code", "msg").Updates(&ChildChain{
-		Code:    code,
-		Message: msg,
+	// Time of last change is kept, while same status is found out again
+	columns := []string{"code", "msg"}
+	if _tmp.Code != code {
+		columns = append(columns, "updated_at")
+	}
+
+	if err := db.WithContext(ctx).Model(&ChildChain{}).Where("txhash = ?", txHash.Hex()).Select(columns).Updates(&ChildChain{
+		Code:      code,
+		Message:   msg,
+		UpdatedAt: time.Now().UTC(),
 	}).Error; err != nil {
 
-		log.Println("[!] ", err)
+		span.RecordError(err)
+		dbLog.Ctx(ctx).Error("Failed to update tx status", logger.Fields{"error": err, "txHash": txHash.Hex(), "code": code})
+		return
+
+	}
 
+	// Only changes are recorded, not each time same status is found out
+	if _tmp.Code != code {
+		recordStatusChange(ctx, db, "child", txHash, code, msg)
 	}
 }
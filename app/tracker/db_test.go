@@ -0,0 +1,24 @@
+package tracker
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestStatusPersistedPastDeadline(t *testing.T) {
+	env := newTestEnv(t, nil)
+	defer env.close()
+
+	// Request deadline is already hit, when status gets found out
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	putRootChainTxStatusInDB(ctx, env.db, common.HexToHash("0x01"), 5, "Approved")
+	putChildChainTxStatusInDB(ctx, env.db, common.HexToHash("0x02"), -3, "Burnt")
+
+	if !env._db.Ran(`INSERT INTO "root_chain"`) || !env._db.Ran(`INSERT INTO "child_chain"`) {
+		t.Fatalf("expected statuses to be persisted, found %v", env._db.Statements())
+	}
+}
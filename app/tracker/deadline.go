@@ -0,0 +1,35 @@
+package tracker
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Status code, given to tx(s) whose status couldn't be found out before
+// request deadline was hit
+const timedOutCode = 8
+
+// Middleware putting deadline on request context, so that all RPC calls, DB queries
+// & worker calls made while serving request are cancelled once it's hit
+func requestDeadline() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), getConfig().RequestTimeout)
+		defer cancel()
+
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+	}
+}
+
+// Status to be returned for tx, whose status couldn't be found out before deadline
+func timedOutStatus() *TransactionState {
+	return &TransactionState{
+		Code:     timedOutCode,
+		Message:  "Timed Out",
+		Degraded: true,
+		Source:   "deadline",
+		Reason:   "Status couldn't be found out before request deadline, try again",
+	}
+}
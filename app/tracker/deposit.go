@@ -1,32 +1,56 @@
 package tracker
 
 import (
+	"app/tracing"
+	"context"
+	"logger"
+
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"gorm.io/gorm"
 )
 
-// From one given transaction's log entries, we're going to find out
-// log entry with first topic `0x103fed9db65eac19c4d870f49ab7520fe03b99f1838e5996caf47e9e43308392`
-// i.e. 'StateSynced(uint256,address,bytes)', which will denote its a state sync event emission
-// and we can find out stateID from emitted log
-func pickOutTransactionLog(logs []*types.Log, topic string) *types.Log {
+// Emitted by `StateSender`, when deposit is made, for being synced to child chain
+var stateSyncedTopic = crypto.Keccak256Hash([]byte("StateSynced(uint256,address,bytes)"))
+
+// From one given transaction's log entries, we're going to find out first
+// log entry with given topic e.g. `StateSynced(uint256,address,bytes)`, which will
+// denote its a state sync event emission and we can find out stateID from emitted log
+//
+// Anonymous logs i.e. without any topic are skipped
+func pickOutTransactionLog(logs []*types.Log, topic common.Hash) *types.Log {
+	if picked := pickOutTransactionLogs(logs, topic); len(picked) != 0 {
+		return picked[0]
+	}
+
+	return nil
+}
+
+// All log entries with one of given topics, in order they were emitted, skipping
+// anonymous ones
+func pickOutTransactionLogs(logs []*types.Log, topics ...common.Hash) []*types.Log {
+	picked := make([]*types.Log, 0)
+
 	for _, v := range logs {
-		if v.Topics[0].Hex() == topic {
-			return v
+		if len(v.Topics) != 0 && isOneOf(v.Topics[0], topics) {
+			picked = append(picked, v)
 		}
 	}
 
-	return nil
+	return picked
 }
 
 // Given transaction hash, it can return what's current state of deposit transaction
 // First approval needs to be performed, so call above function first with `approve` transaction hash
 // then call this one with `depositFor`/ `depositEtherFor` transaction hash
-func getDepositStatus(client *ethclient.Client, db *gorm.DB, txHash common.Hash) *TransactionState {
-	if status := getRootChaintxStatusFromDB(db, txHash); status != nil {
-		if status.Code == 0 || status.Code == 2 || status.Code == 3 {
+func getDepositStatus(ctx context.Context, client *ethclient.Client, db *gorm.DB, txHash common.Hash) *TransactionState {
+	ctx, span := tracing.Start(ctx, "getDepositStatus", tracing.KindInternal, tracing.Attr("tx.hash", txHash.Hex()))
+	defer span.End()
+
+	if status := getRootChaintxStatusFromDB(ctx, db, txHash); status != nil {
+		if status.Code == 0 || status.Code == 2 || status.Code == 3 || status.Code == 10 {
 			return &TransactionState{
 				Code:    status.Code,
 				Message: status.Message,
@@ -34,7 +58,10 @@ func getDepositStatus(client *ethclient.Client, db *gorm.DB, txHash common.Hash)
 		}
 	}
 
-	receipt := getTransactionReceipt(client, txHash)
+	receipt, err := getTransactionReceipt(ctx, client, txHash)
+	if err != nil {
+		return receiptFailedStatus(ctx, client, txHash, err, 4, "Pending")
+	}
 	if receipt == nil {
 		return &TransactionState{
 			Code:    4,
@@ -43,9 +70,9 @@ func getDepositStatus(client *ethclient.Client, db *gorm.DB, txHash common.Hash)
 	}
 
 	// find out that transaction log which has topic `StateSynced(uint256,address,bytes)`, if any
-	_log := pickOutTransactionLog(receipt.Logs, "0x103fed9db65eac19c4d870f49ab7520fe03b99f1838e5996caf47e9e43308392")
+	_log := pickOutTransactionLog(receipt.Logs, stateSyncedTopic)
 	if _log == nil {
-		putRootChainTxStatusInDB(db, txHash, 3, "Bad Deposit Hash")
+		putRootChainTxStatusInDB(ctx, db, txHash, 3, "Bad Deposit Hash")
 
 		return &TransactionState{
 			Code:    3,
@@ -55,7 +82,7 @@ func getDepositStatus(client *ethclient.Client, db *gorm.DB, txHash common.Hash)
 
 	// deposit transaction has failed
 	if receipt.Status == 0 {
-		putRootChainTxStatusInDB(db, txHash, 2, "Failed")
+		putRootChainTxStatusInDB(ctx, db, txHash, 2, "Failed")
 
 		return &TransactionState{
 			Code:    2,
@@ -63,30 +90,71 @@ func getDepositStatus(client *ethclient.Client, db *gorm.DB, txHash common.Hash)
 		}
 	}
 
-	lastStateID := getLastStateID()
+	lastStateID, err := getStateIDManager().LastStateID(ctx)
 	// If we're unable to communicate with `state-id-manager` in this moment
 	// we're going to assume, fund is on its way to child chain, will reach destination
 	//
 	// But this assumption may not be correct, fund may have already reached child chain
-	if lastStateID == nil {
+	if err != nil {
+		workerLog.Ctx(ctx).Error("Failed to reach `state-id-manager`", logger.Fields{"error": err, "txHash": txHash.Hex()})
+		recordWorkerFailure("state_id_manager")
+
+		return fallbackStatus(1, "En Route", "state-id-manager")
+	}
+
+	recordLastStateID(lastStateID.Int64())
+
+	stateID := _log.Topics[1].Big()
+
+	// In this case truly its `en route`, which is persisted too, so that
+	// deposits held in it for long, can be found out
+	if lastStateID.Cmp(stateID) < 0 {
+		putRootChainTxStatusInDB(ctx, db, txHash, 1, "En Route")
+
 		return &TransactionState{
 			Code:    1,
 			Message: "En Route",
 		}
 	}
 
-	if lastStateID.Cmp(_log.Topics[1].Big()) >= 0 {
-		putRootChainTxStatusInDB(db, txHash, 0, "Deposited")
+	// State sync has been committed, so it's looked up in index of
+	// `state-id-manager`, for finding out how it landed on child chain
+	state, err := getStateIDManager().State(ctx, stateID)
+	if err != nil {
+		workerLog.Ctx(ctx).Error("Failed to look up state sync", logger.Fields{"error": err, "txHash": txHash.Hex(), "stateId": stateID.String()})
+		recordWorkerFailure("state_id_manager")
+
+		return fallbackStatus(0, "Deposited", "state-id-manager")
+	}
+
+	// Committed, but yet to be indexed, so it's not persisted, letting
+	// next lookup find out how it landed
+	if state == nil {
+		return &TransactionState{
+			Code:     0,
+			Message:  "Deposited",
+			Degraded: true,
+			Source:   "state-id-manager",
+			Reason:   "State sync committed, but not yet indexed by state-id-manager",
+		}
+	}
+
+	// Deposit tx itself succeeded, so it gets its own code, different from `2`
+	if !state.Success {
+		putRootChainTxStatusInDB(ctx, db, txHash, 10, "State Sync Failed")
 
 		return &TransactionState{
-			Code:    0,
-			Message: "Deposited",
+			Code:      10,
+			Message:   "State Sync Failed",
+			StateSync: state,
 		}
 	}
 
-	// In this case truly its `en route`
+	putRootChainTxStatusInDB(ctx, db, txHash, 0, "Deposited")
+
 	return &TransactionState{
-		Code:    1,
-		Message: "En Route",
+		Code:      0,
+		Message:   "Deposited",
+		StateSync: state,
 	}
 }
@@ -0,0 +1,66 @@
+package tracker
+
+import (
+	"app/internal/testutil"
+	"context"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/maticnetwork/deposits-withdrawals-tracking/app/client"
+)
+
+func TestStateSyncFailed(t *testing.T) {
+	env := newTestEnv(t, nil)
+	defer env.close()
+
+	ctx := context.Background()
+
+	// Deposit tx emitting `StateSynced` with state ID 5
+	stateSynced := &types.Log{Topics: []common.Hash{stateSyncedTopic, common.BigToHash(big.NewInt(5))}}
+	synced := env.root.Mine(&testutil.Tx{Logs: []*types.Log{stateSynced}})
+
+	env.workers.Sync(&client.CommittedState{ID: "5", Success: false})
+
+	if status := getDepositStatus(ctx, env.root.Client(), env.db, synced); status.Code != 10 || status.Message != "State Sync Failed" {
+		t.Fatalf("expected state sync failed on child chain to be `10`, found %d %s", status.Code, status.Message)
+	}
+
+	if !env._db.Ran(`INSERT INTO "root_chain"`) {
+		t.Fatal("expected state sync failed to be persisted")
+	}
+
+}
+
+func TestDepositReceiptFailed(t *testing.T) {
+	env := newTestEnv(t, nil)
+	defer env.close()
+
+	// RPC node, which can't be reached
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+
+	unreachable, err := ethclient.Dial(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer unreachable.Close()
+
+	txHash := common.HexToHash("0x0d")
+
+	status := getDepositStatus(context.Background(), unreachable, env.db, txHash)
+	if status.Code != 4 || !status.Degraded {
+		t.Fatalf("expected receipt which couldn't be fetched to be degraded `4`, found %+v", status)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if status := getDepositStatus(ctx, unreachable, env.db, txHash); status.Code != timedOutCode || !status.Degraded {
+		t.Fatalf("expected receipt which couldn't be fetched before deadline to be timed out, found %+v", status)
+	}
+}
@@ -0,0 +1,488 @@
+package tracker
+
+import (
+	"app/tracing"
+	"bytes"
+	"context"
+	"errors"
+	"logger"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rlp"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Root chain logs are searched for confirm/ exit tx(s) in ranges of these many blocks
+const exitBlockRange = 10000
+
+// At max these many ranges of root chain blocks are searched in one lookup, where
+// next lookup resumes from where it's left
+const exitSearchRanges = 20
+
+// Topics of events emitted on root chain, when withdraw is exited
+var (
+	// Emitted by `WithdrawManager`, when Plasma exit is started, by confirm tx
+	plasmaExitStartedTopic = crypto.Keccak256Hash([]byte("ExitStarted(address,uint256,address,uint256,bool)"))
+	// Emitted by `WithdrawManager`, when Plasma exit is processed, by exit tx
+	plasmaExitedTopic = crypto.Keccak256Hash([]byte("Withdraw(uint256,address,address,uint256)"))
+	// Emitted by POS predicates, when `RootChainManager.exit(...)` is called, where
+	// token ID(s)/ amount(s) are in data
+	exitedERC20Topics = []common.Hash{
+		crypto.Keccak256Hash([]byte("ExitedERC20(address,address,uint256)")),
+		crypto.Keccak256Hash([]byte("ExitedMintableERC20(address,address,uint256)")),
+	}
+	exitedERC721Topics = []common.Hash{
+		crypto.Keccak256Hash([]byte("ExitedERC721(address,address,uint256)")),
+		crypto.Keccak256Hash([]byte("ExitedMintableERC721(address,address,uint256)")),
+	}
+	exitedERC721BatchTopics = []common.Hash{
+		crypto.Keccak256Hash([]byte("ExitedERC721Batch(address,address,uint256[])")),
+		crypto.Keccak256Hash([]byte("ExitedMintableERC721Batch(address,address,uint256[])")),
+	}
+	exitedERC1155Topics = []common.Hash{
+		crypto.Keccak256Hash([]byte("ExitedERC1155(address,address,uint256,uint256)")),
+		crypto.Keccak256Hash([]byte("ExitedMintableERC1155(address,address,uint256,uint256)")),
+	}
+	exitedERC1155BatchTopics = []common.Hash{
+		crypto.Keccak256Hash([]byte("ExitedBatchERC1155(address,address,uint256[],uint256[])")),
+		crypto.Keccak256Hash([]byte("ExitedBatchMintableERC1155(address,address,uint256[],uint256[])")),
+	}
+	exitedEtherTopic = crypto.Keccak256Hash([]byte("ExitedEther(address,uint256)"))
+	// All of above
+	posExitedTopics = joinTopics(exitedERC20Topics, exitedERC721Topics, exitedERC721BatchTopics, exitedERC1155Topics, exitedERC1155BatchTopics, []common.Hash{exitedEtherTopic})
+)
+
+// Joins lists of topics, so that logs matching any of them can be queried
+func joinTopics(topics ...[]common.Hash) []common.Hash {
+	joined := make([]common.Hash, 0)
+	for _, v := range topics {
+		joined = append(joined, v...)
+	}
+
+	return joined
+}
+
+// Selectors of functions, called with proof of burn, for exiting
+var (
+	posExitSelector         = crypto.Keccak256([]byte("exit(bytes)"))[:4]
+	plasmaStartExitSelector = crypto.Keccak256([]byte("startExitWithBurntTokens(bytes)"))[:4]
+)
+
+// Arguments of functions called for exiting, which take only proof of burn
+var exitArguments = func() abi.Arguments {
+	_type, _ := abi.NewType("bytes", "", nil)
+	return abi.Arguments{{Type: _type}}
+}()
+
+// ExitDiscovery - Confirm/ exit tx(s) discovered on root chain, for burn tx, along with
+// root chain block, search for them resumes from
+//
+// `ExitID` is of Plasma exit, started by confirm tx, which is looked for in exit tx
+type ExitDiscovery struct {
+	BurnTxHash    string    `gorm:"column:txhash;type:char(66);primaryKey"`
+	ConfirmTxHash string    `gorm:"column:confirmtxhash;type:char(66);not null;default:''"`
+	ExitID        string    `gorm:"column:exitid;type:char(66);not null;default:''"`
+	ExitTxHash    string    `gorm:"column:exittxhash;type:char(66);not null;default:''"`
+	Block         uint64    `gorm:"column:block;type:bigint;not null"`
+	UpdatedAt     time.Time `gorm:"column:updated_at"`
+}
+
+// TableName - Overriding default table name
+func (ExitDiscovery) TableName() string {
+	return "exit_discovery"
+}
+
+// Discovery of confirm/ exit tx(s) for burn tx, as far as it's gone, where
+// false is returned, when it's not been started yet
+func getExitDiscovery(ctx context.Context, db *gorm.DB, burnTxHash common.Hash) (*ExitDiscovery, bool, error) {
+	var row ExitDiscovery
+
+	if err := db.WithContext(ctx).Where("txhash = ?", burnTxHash.Hex()).First(&row).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return &ExitDiscovery{BurnTxHash: burnTxHash.Hex()}, false, nil
+		}
+
+		return nil, false, err
+	}
+
+	return &row, true, nil
+}
+
+// Remembers how far discovery of confirm/ exit tx(s) has gone
+func putExitDiscovery(ctx context.Context, db *gorm.DB, row *ExitDiscovery) error {
+	row.UpdatedAt = time.Now().UTC()
+
+	return db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "txhash"}},
+		DoUpdates: clause.AssignmentColumns([]string{"confirmtxhash", "exitid", "exittxhash", "block", "updated_at"}),
+	}).Create(row).Error
+}
+
+// Log emitted by child token while being burnt, which is `Withdraw` for Plasma
+// & transfer to zero address for POS
+func burnLog(receipt *types.Receipt, isPOS bool) *types.Log {
+	for _, _log := range receipt.Logs {
+		if len(_log.Topics) == 0 {
+			continue
+		}
+
+		switch _log.Topics[0] {
+		case plasmaWithdrawTopic:
+			if !isPOS && len(_log.Topics) == 3 {
+				return _log
+			}
+
+		case transferTopic, transferSingleTopic, transferBatchTopic:
+			if isPOS && isBurnTransfer(_log) {
+				return _log
+			}
+
+		}
+	}
+
+	return nil
+}
+
+// Address of account, which burnt tokens, as found in burn log
+func burner(_log *types.Log) common.Hash {
+	switch _log.Topics[0] {
+	case transferSingleTopic, transferBatchTopic, plasmaWithdrawTopic:
+		return _log.Topics[2]
+	}
+
+	return _log.Topics[1]
+}
+
+// Path of burn tx in receipts trie of child chain block, as it's put in proof of
+// burn i.e. hex-prefix encoded, RLP encoded tx index
+func receiptPath(receipt *types.Receipt) []byte {
+	index, _ := rlp.EncodeToBytes(receipt.TransactionIndex)
+	return append([]byte{0}, index...)
+}
+
+// Child chain block number & path of burn tx in its receipts trie, as found in
+// proof of burn, given arguments of function called for exiting
+func decodeExitPayload(args []byte) (uint64, []byte, bool) {
+	values, err := exitArguments.UnpackValues(args)
+	if err != nil || len(values) != 1 {
+		return 0, nil, false
+	}
+
+	payload, ok := values[0].([]byte)
+	if !ok {
+		return 0, nil, false
+	}
+
+	var items []rlp.RawValue
+	if err := rlp.DecodeBytes(payload, &items); err != nil || len(items) < 10 {
+		return 0, nil, false
+	}
+
+	var blockNumber uint64
+	if err := rlp.DecodeBytes(items[2], &blockNumber); err != nil {
+		return 0, nil, false
+	}
+
+	var branchMask []byte
+	if err := rlp.DecodeBytes(items[8], &branchMask); err != nil {
+		return 0, nil, false
+	}
+
+	return blockNumber, branchMask, true
+}
+
+// Checks whether root chain tx calls function with given selector, on `to` if
+// it's set, with proof of given burn tx
+func isExitOf(ctx context.Context, rootClient *ethclient.Client, txHash common.Hash, to common.Address, selector []byte, receipt *types.Receipt) (bool, error) {
+	start := time.Now()
+
+	tx, _, err := rootClient.TransactionByHash(ctx, txHash)
+	recordRPCCall(chainOf(rootClient), "eth_getTransactionByHash", start, err)
+	if err != nil {
+		return false, err
+	}
+
+	if to != (common.Address{}) && (tx.To() == nil || *tx.To() != to) {
+		return false, nil
+	}
+
+	data := tx.Data()
+	if len(data) < 4 || !bytes.Equal(data[:4], selector) {
+		return false, nil
+	}
+
+	blockNumber, branchMask, ok := decodeExitPayload(data[4:])
+	if !ok {
+		return false, nil
+	}
+
+	return blockNumber == receipt.BlockNumber.Uint64() && bytes.Equal(branchMask, receiptPath(receipt)), nil
+}
+
+// First block, mined at or after given time, upto head, on chain
+func blockAt(ctx context.Context, chain chainReader, timestamp uint64, head uint64) (uint64, error) {
+	low, high := uint64(0), head
+
+	for low < high {
+		mid := low + (high-low)/2
+
+		start := time.Now()
+
+		header, err := chain.HeaderByNumber(ctx, new(big.Int).SetUint64(mid))
+		recordRPCCall(readerName(chain), "eth_getBlockByNumber", start, err)
+		if err != nil {
+			return 0, err
+		}
+
+		if header.Time < timestamp {
+			low = mid + 1
+		} else {
+			high = mid
+		}
+	}
+
+	return low, nil
+}
+
+// Searches root chain logs matching query, from `from` upto `head`, in ranges, until
+// `match` accepts one of them or `exitSearchRanges` ranges are searched, returning
+// accepted log along with block, search is to be resumed from
+func searchLogs(ctx context.Context, rootClient *ethclient.Client, query ethereum.FilterQuery, from uint64, head uint64, match func(*types.Log) (bool, error)) (*types.Log, uint64, error) {
+	for i := 0; i < exitSearchRanges && from <= head; i++ {
+		to := from + exitBlockRange - 1
+		if to > head {
+			to = head
+		}
+
+		query.FromBlock = new(big.Int).SetUint64(from)
+		query.ToBlock = new(big.Int).SetUint64(to)
+
+		start := time.Now()
+
+		logs, err := rootClient.FilterLogs(ctx, query)
+		recordRPCCall(chainOf(rootClient), "eth_getLogs", start, err)
+		if err != nil {
+			return nil, from, err
+		}
+
+		for j := range logs {
+			_log := &logs[j]
+			if _log.Removed {
+				continue
+			}
+
+			ok, err := match(_log)
+			if err != nil {
+				return nil, from, err
+			}
+			if ok {
+				return _log, _log.BlockNumber, nil
+			}
+		}
+
+		from = to + 1
+	}
+
+	return nil, from, nil
+}
+
+// Discovers confirm/ exit tx(s) of withdraw on root chain, given burn tx hash & confirm
+// tx hash of Plasma withdraw, if known
+//
+// POS exit is found from logs emitted by predicates, for account which burnt tokens,
+// where `RootChainManager.exit(...)` tx carrying proof of burn is looked for. For Plasma,
+// `ExitStarted` is looked for, emitted by `startExitWithBurntTokens(...)` tx, carrying
+// proof of burn, followed by `Withdraw` of exit ID, emitted by `processExits(...)` tx
+//
+// Search starts from root chain block mined at time of burn, where progress is kept
+// in DB, so that next lookup resumes from there. Returns nil, when required contract
+// address is not set in config
+func discoverExit(ctx context.Context, rootClient *ethclient.Client, childClient *ethclient.Client, db *gorm.DB, burnTxHash common.Hash, confirmTxHash common.Hash, isPOS bool) (*ExitDiscovery, error) {
+	ctx, span := tracing.Start(ctx, "discoverExit", tracing.KindInternal, tracing.Attr("tx.hash", burnTxHash.Hex()))
+	defer span.End()
+
+	conf := getConfig()
+
+	manager := conf.WithdrawManager
+	if isPOS {
+		manager = conf.RootChainManager
+	}
+	if manager == (common.Address{}) {
+		return nil, nil
+	}
+
+	row, ok, err := getExitDiscovery(ctx, db, burnTxHash)
+	if err != nil {
+		return nil, err
+	}
+	if row.ExitTxHash != "" {
+		return row, nil
+	}
+
+	receipt, err := getTransactionReceipt(ctx, childClient, burnTxHash)
+	if err != nil {
+		return nil, err
+	}
+	if receipt == nil || receipt.Status == 0 {
+		return row, nil
+	}
+
+	_log := burnLog(receipt, isPOS)
+	if _log == nil {
+		return row, nil
+	}
+
+	start := time.Now()
+
+	head, err := rootClient.HeaderByNumber(ctx, nil)
+	recordRPCCall(chainOf(rootClient), "eth_getBlockByNumber", start, err)
+	if err != nil {
+		return nil, err
+	}
+
+	if !ok {
+		start := time.Now()
+
+		header, err := childClient.HeaderByNumber(ctx, receipt.BlockNumber)
+		recordRPCCall(chainOf(childClient), "eth_getBlockByNumber", start, err)
+		if err != nil {
+			return nil, err
+		}
+
+		from, err := blockAt(ctx, rootClient, header.Time, head.Number.Uint64())
+		if err != nil {
+			return nil, err
+		}
+
+		row.Block = from
+	}
+
+	// Confirm tx hash, if supplied, spares searching for it
+	if !isPOS && row.ConfirmTxHash == "" && !isEmptyTxHash(confirmTxHash) {
+		_receipt, err := getTransactionReceipt(ctx, rootClient, confirmTxHash)
+		if err != nil {
+			return nil, err
+		}
+
+		if _receipt != nil {
+			if _started := pickOutTransactionLog(_receipt.Logs, plasmaExitStartedTopic); _started != nil && len(_started.Topics) > 2 {
+				row.ConfirmTxHash, row.ExitID, row.Block = confirmTxHash.Hex(), _started.Topics[2].Hex(), _receipt.BlockNumber.Uint64()
+			}
+		}
+	}
+
+	switch {
+	case isPOS:
+		found, next, err := searchLogs(ctx, rootClient, ethereum.FilterQuery{
+			Topics: [][]common.Hash{posExitedTopics, {burner(_log)}},
+		}, row.Block, head.Number.Uint64(), func(l *types.Log) (bool, error) {
+			return isExitOf(ctx, rootClient, l.TxHash, manager, posExitSelector, receipt)
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		row.Block = next
+		if found != nil {
+			row.ExitTxHash = found.TxHash.Hex()
+		}
+
+	case row.ConfirmTxHash == "":
+		found, next, err := searchLogs(ctx, rootClient, ethereum.FilterQuery{
+			Addresses: []common.Address{manager},
+			Topics:    [][]common.Hash{{plasmaExitStartedTopic}, {_log.Topics[2]}, nil, {_log.Topics[1]}},
+		}, row.Block, head.Number.Uint64(), func(l *types.Log) (bool, error) {
+			return isExitOf(ctx, rootClient, l.TxHash, common.Address{}, plasmaStartExitSelector, receipt)
+		})
+
+		if err != nil {
+			return nil, err
+		}
+
+		// Exit can only be processed after it's started, so it's searched
+		// for from there
+		row.Block = next
+		if found != nil {
+			row.ConfirmTxHash, row.ExitID = found.TxHash.Hex(), found.Topics[2].Hex()
+		}
+
+	}
+
+	if !isPOS && row.ConfirmTxHash != "" {
+		found, next, err := searchLogs(ctx, rootClient, ethereum.FilterQuery{
+			Addresses: []common.Address{manager},
+			Topics:    [][]common.Hash{{plasmaExitedTopic}, {common.HexToHash(row.ExitID)}},
+		}, row.Block, head.Number.Uint64(), func(l *types.Log) (bool, error) {
+			return true, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		row.Block = next
+		if found != nil {
+			row.ExitTxHash = found.TxHash.Hex()
+		}
+	}
+
+	if err := putExitDiscovery(ctx, db, row); err != nil {
+		return nil, err
+	}
+
+	return row, nil
+}
+
+// Fills in confirm/ exit tx hashes of withdraw, which are not supplied, with those
+// discovered on root chain, returning copy of it, on bridge it's resolved to be of
+//
+// Discovery is attempted only once burn tx is checkpointed, while for POS withdraw,
+// only once `pos-exit-checker` says it has exited
+func discoverTxs(ctx context.Context, rootClient *ethclient.Client, childClient *ethclient.Client, db *gorm.DB, tx *WithdrawTransaction, isPOS bool) *WithdrawTransaction {
+	_tx := *tx
+	_tx.IsPOS = &isPOS
+
+	if !isEmptyTxHash(tx.ExitTxHash) {
+		return &_tx
+	}
+
+	if isPOS {
+		if getPOSBurnStatus(ctx, childClient, db, tx.BurnTxHash).Code != -5 {
+			return &_tx
+		}
+	} else if isEmptyTxHash(tx.ConfirmWithdrawTxHash) {
+		if getCheckPointStatus(ctx, childClient, db, tx.BurnTxHash).Code != -4 {
+			return &_tx
+		}
+	}
+
+	row, err := discoverExit(ctx, rootClient, childClient, db, tx.BurnTxHash, tx.ConfirmWithdrawTxHash, isPOS)
+	if err != nil {
+		statusLog.Ctx(ctx).Error("Failed to discover confirm/ exit tx", logger.Fields{"error": err, "txHash": tx.BurnTxHash.Hex()})
+		return &_tx
+	}
+	if row == nil {
+		return &_tx
+	}
+
+	// Exit discovered for some other confirm tx, than supplied one, is not of this withdraw
+	if !isPOS && !isEmptyTxHash(tx.ConfirmWithdrawTxHash) && row.ConfirmTxHash != tx.ConfirmWithdrawTxHash.Hex() {
+		return &_tx
+	}
+
+	if !isPOS && row.ConfirmTxHash != "" {
+		_tx.ConfirmWithdrawTxHash = common.HexToHash(row.ConfirmTxHash)
+	}
+	if row.ExitTxHash != "" {
+		_tx.ExitTxHash = common.HexToHash(row.ExitTxHash)
+	}
+
+	return &_tx
+}
@@ -0,0 +1,211 @@
+package tracker
+
+import (
+	"app/internal/testutil"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+var (
+	rootChainManager = common.HexToAddress("0x0000000000000000000000000000000000000d01")
+	withdrawManager  = common.HexToAddress("0x0000000000000000000000000000000000000d02")
+	predicate        = common.HexToAddress("0x0000000000000000000000000000000000000d03")
+)
+
+// Calldata of function with given selector, taking proof of burn, mined in given
+// child chain block, at given path in its receipts trie
+func exitCalldata(t *testing.T, selector []byte, blockNumber uint64, path []byte) []byte {
+	items := make([]interface{}, 10)
+	for i := range items {
+		items[i] = []byte{}
+	}
+	items[2], items[8] = blockNumber, path
+
+	payload, err := rlp.EncodeToBytes(items)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	args, err := exitArguments.Pack(payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return append(append([]byte{}, selector...), args...)
+}
+
+func TestPlasmaBurnLog(t *testing.T) {
+	receipt := receiptOf(plasmaBurn()...)
+
+	_log := burnLog(receipt, false)
+	if _log == nil || _log.Topics[0] != plasmaWithdrawTopic || burner(_log) != account.Hash() {
+		t.Fatalf("expected `Withdraw` of %s to be burn log", account.Hex())
+	}
+
+	if burnLog(receipt, true) != nil {
+		t.Fatal("expected Plasma burn log not to be picked for POS")
+	}
+}
+
+func TestBlockAt(t *testing.T) {
+	chain := testutil.NewChain()
+	chain.Advance(100)
+
+	genesis := chain.Head().Time - 2*100
+
+	for _, v := range []struct {
+		timestamp uint64
+		block     uint64
+	}{
+		{0, 0},
+		{genesis, 0},
+		{genesis + 2*40, 40},
+		// Between blocks, it's next one
+		{genesis + 2*40 + 1, 41},
+		{genesis + 2*100, 100},
+		// Not yet mined, it's head
+		{genesis + 2*200, 100},
+	} {
+		block, err := blockAt(context.Background(), chain.Client(), v.timestamp, 100)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if block != v.block {
+			t.Fatalf("expected block mined at/ after %d to be %d, found %d", v.timestamp, v.block, block)
+		}
+	}
+}
+
+func TestSearchLogs(t *testing.T) {
+	chain := testutil.NewChain()
+
+	emit := func(blocks int) {
+		chain.Advance(blocks)
+		chain.Mine(&testutil.Tx{Logs: []*types.Log{{Address: predicate, Topics: []common.Hash{exitedEtherTopic, account.Hash()}}}})
+	}
+
+	// One log in each of first & third range, while second one has none
+	emit(10)
+	emit(2 * exitBlockRange)
+	chain.Advance(10)
+
+	first, second := uint64(11), uint64(2*exitBlockRange+12)
+	head := chain.Head().Number.Uint64()
+
+	ctx := context.Background()
+	query := ethereum.FilterQuery{Topics: [][]common.Hash{{exitedEtherTopic}}}
+
+	matched := make([]uint64, 0)
+	found, next, err := searchLogs(ctx, chain.Client(), query, 0, head, func(l *types.Log) (bool, error) {
+		matched = append(matched, l.BlockNumber)
+		return l.BlockNumber == second, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if found == nil || found.BlockNumber != second || next != second || len(matched) != 2 || matched[0] != first {
+		t.Fatalf("expected log in third range to be found after one in first, found %v, resuming from %d", matched, next)
+	}
+
+	// Search resumes from block next to head, when nothing is found
+	if found, next, err := searchLogs(ctx, chain.Client(), query, second+1, head, func(l *types.Log) (bool, error) {
+		return true, nil
+	}); err != nil || found != nil || next != head+1 {
+		t.Fatalf("expected nothing to be found past last log, resuming from %d, found resuming from %d", head+1, next)
+	}
+
+	// Failed match is retried from start of range, log is in
+	if _, next, err := searchLogs(ctx, chain.Client(), query, exitBlockRange, head, func(l *types.Log) (bool, error) {
+		return false, errors.New("connection refused")
+	}); err == nil || next != 2*exitBlockRange {
+		t.Fatalf("expected failed match to be resumed from %d, found %d", 2*exitBlockRange, next)
+	}
+
+	// Only `exitSearchRanges` ranges are searched in one go
+	chain.Advance(exitSearchRanges * exitBlockRange)
+	head = chain.Head().Number.Uint64()
+
+	if found, next, err := searchLogs(ctx, chain.Client(), query, second+1, head, func(l *types.Log) (bool, error) {
+		return true, nil
+	}); err != nil || found != nil || next != second+1+exitSearchRanges*exitBlockRange {
+		t.Fatalf("expected search to stop after %d ranges, found resuming from %d", exitSearchRanges, next)
+	}
+}
+
+func TestDiscoverPOSExit(t *testing.T) {
+	env := newTestEnv(t, map[string]interface{}{"RootChainManager": rootChainManager.Hex()})
+	defer env.close()
+
+	burnTxHash := env.child.Mine(&testutil.Tx{Logs: []*types.Log{erc20Burn()}})
+	burn := env.child.Head().Number.Uint64()
+
+	exited := &types.Log{Address: predicate, Topics: []common.Hash{exitedERC20Topics[0], account.Hash(), childToken.Hash()}, Data: make([]byte, 32)}
+
+	env.root.Advance(int(burn))
+
+	// Exit of some other burn, exit not called on `RootChainManager` & then exit of burn
+	env.root.Mine(&testutil.Tx{To: rootChainManager, Data: exitCalldata(t, posExitSelector, burn+1, []byte{0, 0x80}), Logs: []*types.Log{exited}})
+	env.root.Mine(&testutil.Tx{To: predicate, Data: exitCalldata(t, posExitSelector, burn, []byte{0, 0x80}), Logs: []*types.Log{exited}})
+	exitTxHash := env.root.Mine(&testutil.Tx{To: rootChainManager, Data: exitCalldata(t, posExitSelector, burn, []byte{0, 0x80}), Logs: []*types.Log{exited}})
+
+	row, err := discoverExit(context.Background(), env.root.Client(), env.child.Client(), env.db, burnTxHash, common.Hash{}, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if row == nil || row.ExitTxHash != exitTxHash.Hex() || row.ConfirmTxHash != "" {
+		t.Fatalf("expected exit tx %s to be discovered, found %+v", exitTxHash.Hex(), row)
+	}
+
+	if !env._db.Ran(`INSERT INTO "exit_discovery"`) {
+		t.Fatal("expected discovery to be persisted")
+	}
+}
+
+func TestDiscoverPlasmaExit(t *testing.T) {
+	env := newTestEnv(t, map[string]interface{}{"WithdrawManager": withdrawManager.Hex()})
+	defer env.close()
+
+	burnTxHash := env.child.Mine(&testutil.Tx{Logs: plasmaBurn()})
+	burn := env.child.Head().Number.Uint64()
+
+	exitID := common.HexToHash("0x0e1d")
+	started := func(exitID common.Hash) *types.Log {
+		return &types.Log{Address: withdrawManager, Topics: []common.Hash{plasmaExitStartedTopic, account.Hash(), exitID, childToken.Hash()}, Data: make([]byte, 64)}
+	}
+	withdrawn := func(exitID common.Hash) *types.Log {
+		return &types.Log{Address: withdrawManager, Topics: []common.Hash{plasmaExitedTopic, exitID, account.Hash(), childToken.Hash()}, Data: make([]byte, 32)}
+	}
+
+	env.root.Advance(int(burn))
+
+	// Exit started for some other burn, followed by one for burn, both of which are processed
+	env.root.Mine(&testutil.Tx{Data: exitCalldata(t, plasmaStartExitSelector, burn+1, []byte{0, 0x80}), Logs: []*types.Log{started(common.HexToHash("0x0e1c"))}})
+	confirmTxHash := env.root.Mine(&testutil.Tx{Data: exitCalldata(t, plasmaStartExitSelector, burn, []byte{0, 0x80}), Logs: []*types.Log{started(exitID)}})
+	env.root.Mine(&testutil.Tx{Logs: []*types.Log{withdrawn(common.HexToHash("0x0e1c"))}})
+	exitTxHash := env.root.Mine(&testutil.Tx{Logs: []*types.Log{withdrawn(exitID)}})
+
+	for _, supplied := range []common.Hash{{}, confirmTxHash} {
+		row, err := discoverExit(context.Background(), env.root.Client(), env.child.Client(), env.db, burnTxHash, supplied, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if row == nil || row.ConfirmTxHash != confirmTxHash.Hex() || row.ExitID != exitID.Hex() || row.ExitTxHash != exitTxHash.Hex() {
+			t.Fatalf("expected confirm tx %s & exit tx %s to be discovered, found %+v", confirmTxHash.Hex(), exitTxHash.Hex(), row)
+		}
+	}
+
+	// POS exit isn't discovered, without `RootChainManager`
+	if row, err := discoverExit(context.Background(), env.root.Client(), env.child.Client(), env.db, burnTxHash, common.Hash{}, true); err != nil || row != nil {
+		t.Fatalf("expected POS exit not to be discovered without `RootChainManager`, found %+v", row)
+	}
+}
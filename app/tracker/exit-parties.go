@@ -0,0 +1,155 @@
+package tracker
+
+import (
+	"app/nft"
+	"app/tracing"
+	"context"
+	"logger"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/maticnetwork/deposits-withdrawals-tracking/app/client"
+	"gorm.io/gorm"
+)
+
+// Sender of tx, as reported by node, which is why chain ID is not required
+func txSender(ctx context.Context, chain *ethclient.Client, receipt *types.Receipt) (common.Address, error) {
+	start := time.Now()
+
+	tx, _, err := chain.TransactionByHash(ctx, receipt.TxHash)
+	recordRPCCall(chainOf(chain), "eth_getTransactionByHash", start, err)
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	return chain.TransactionSender(ctx, tx, receipt.BlockHash, receipt.TransactionIndex)
+}
+
+// Exit ID of Plasma exit, as found in `ExitStarted`, emitted by confirm tx, which is
+// looked up from exit discovery first
+func plasmaExitID(ctx context.Context, rootClient *ethclient.Client, db *gorm.DB, burnTxHash common.Hash, confirmTxHash common.Hash) (common.Hash, bool) {
+	if row, ok, err := getExitDiscovery(ctx, db, burnTxHash); err == nil && ok && row.ExitID != "" && row.ConfirmTxHash == confirmTxHash.Hex() {
+		return common.HexToHash(row.ExitID), true
+	}
+
+	if isEmptyTxHash(confirmTxHash) {
+		return common.Hash{}, false
+	}
+
+	receipt, err := getTransactionReceipt(ctx, rootClient, confirmTxHash)
+	if err != nil || receipt == nil || receipt.Status == 0 {
+		return common.Hash{}, false
+	}
+
+	_log := pickOutTransactionLog(receipt.Logs, plasmaExitStartedTopic)
+	if _log == nil || len(_log.Topics) < 3 {
+		return common.Hash{}, false
+	}
+
+	return _log.Topics[2], true
+}
+
+// Account which received funds on root chain, as found in logs of exit tx, where
+// it's exitor of `Exited*`, emitted by POS predicates & user of `Withdraw` of same
+// exit ID, emitted by Plasma `WithdrawManager`
+func exitRecipient(ctx context.Context, rootClient *ethclient.Client, db *gorm.DB, tx *WithdrawTransaction, isPOS bool, receipt *types.Receipt) *common.Address {
+	exitID, ok := common.Hash{}, isPOS
+	if !isPOS {
+		exitID, ok = plasmaExitID(ctx, rootClient, db, tx.BurnTxHash, tx.ConfirmWithdrawTxHash)
+	}
+	if !ok {
+		return nil
+	}
+
+	for _, _log := range receipt.Logs {
+		if len(_log.Topics) < 2 {
+			continue
+		}
+
+		switch {
+		case isPOS && isOneOf(_log.Topics[0], posExitedTopics):
+			recipient := common.BytesToAddress(_log.Topics[1].Bytes())
+			return &recipient
+
+		case !isPOS && _log.Topics[0] == plasmaExitedTopic && len(_log.Topics) == 4 && _log.Topics[1] == exitID:
+			recipient := common.BytesToAddress(_log.Topics[2].Bytes())
+			return &recipient
+
+		}
+	}
+
+	return nil
+}
+
+// Finds out accounts involved in exit of withdraw i.e. who burnt tokens on child chain,
+// who submitted exit tx on root chain & who received funds, flagging when they're not
+// same as burner
+//
+// Returns nil, when exit tx is not known or it's not successful
+func getExitParties(ctx context.Context, rootClient *ethclient.Client, childClient *ethclient.Client, db *gorm.DB, tx *WithdrawTransaction, isPOS bool) *client.ExitParties {
+	if isEmptyTxHash(tx.ExitTxHash) {
+		return nil
+	}
+
+	ctx, span := tracing.Start(ctx, "getExitParties", tracing.KindInternal, tracing.Attr("tx.hash", tx.BurnTxHash.Hex()), tracing.Attr("tx.exit_hash", tx.ExitTxHash.Hex()))
+	defer span.End()
+
+	burnReceipt, err := getTransactionReceipt(ctx, childClient, tx.BurnTxHash)
+	if err != nil {
+		statusLog.Ctx(ctx).Error("Failed to fetch burn tx receipt", logger.Fields{"error": err, "txHash": tx.BurnTxHash.Hex()})
+		return nil
+	}
+	if burnReceipt == nil || burnReceipt.Status == 0 {
+		return nil
+	}
+
+	_log := burnLog(burnReceipt, isPOS)
+	if _log == nil {
+		return nil
+	}
+
+	exitReceipt, err := getTransactionReceipt(ctx, rootClient, tx.ExitTxHash)
+	if err != nil {
+		statusLog.Ctx(ctx).Error("Failed to fetch exit tx receipt", logger.Fields{"error": err, "txHash": tx.BurnTxHash.Hex(), "exitTxHash": tx.ExitTxHash.Hex()})
+		return nil
+	}
+	if exitReceipt == nil || exitReceipt.Status == 0 {
+		return nil
+	}
+
+	submitter, err := txSender(ctx, rootClient, exitReceipt)
+	if err != nil {
+		statusLog.Ctx(ctx).Error("Failed to find out exit tx submitter", logger.Fields{"error": err, "txHash": tx.BurnTxHash.Hex(), "exitTxHash": tx.ExitTxHash.Hex()})
+		return nil
+	}
+
+	parties := &client.ExitParties{
+		Burner:    common.BytesToAddress(burner(_log).Bytes()),
+		Submitter: submitter,
+		Recipient: exitRecipient(ctx, rootClient, db, tx, isPOS, exitReceipt),
+	}
+
+	parties.ThirdParty = parties.Submitter != parties.Burner
+	parties.RecipientMismatch = parties.Recipient != nil && *parties.Recipient != parties.Burner
+
+	if parties.ThirdParty || parties.RecipientMismatch {
+		statusLog.Ctx(ctx).Info("Withdraw exited by third party", logger.Fields{"txHash": tx.BurnTxHash.Hex(), "exitTxHash": tx.ExitTxHash.Hex(), "burner": parties.Burner.Hex(), "submitter": parties.Submitter.Hex(), "recipientMismatch": parties.RecipientMismatch})
+	}
+
+	return parties
+}
+
+// Finds out status of withdraw, as `getWithdrawTxStatus` does, putting accounts involved
+// in exit in it, once it's exited
+func getWithdrawTxStatusWithParties(ctx context.Context, rootClient *ethclient.Client, childClient *ethclient.Client, db *gorm.DB, _nft *nft.Nft, tx *WithdrawTransaction, isPOS bool) *TransactionState {
+	_state := getWithdrawTxStatus(ctx, rootClient, childClient, db, _nft, tx, isPOS)
+	if _state == nil || _state.Code != -10 || _state.Degraded {
+		return _state
+	}
+
+	_state.Exit = getExitParties(ctx, rootClient, childClient, db, tx, isPOS)
+
+	return _state
+}
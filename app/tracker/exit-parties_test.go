@@ -0,0 +1,137 @@
+package tracker
+
+import (
+	"app/internal/testutil"
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/maticnetwork/deposits-withdrawals-tracking/app/client"
+)
+
+// Hex form of address, if any
+func hexOf(address *common.Address) string {
+	if address == nil {
+		return "<nil>"
+	}
+
+	return address.Hex()
+}
+
+// Exit parties expected, where nil recipient means it's not found out
+func checkExitParties(t *testing.T, name string, parties *client.ExitParties, burner common.Address, submitter common.Address, recipient *common.Address) {
+	if parties == nil {
+		t.Fatalf("%s : expected exit parties to be found out", name)
+	}
+
+	if parties.Burner != burner || parties.Submitter != submitter {
+		t.Fatalf("%s : expected burner %s & submitter %s, found %+v", name, burner.Hex(), submitter.Hex(), parties)
+	}
+
+	if (recipient == nil) != (parties.Recipient == nil) || (recipient != nil && *recipient != *parties.Recipient) {
+		t.Fatalf("%s : expected recipient %s, found %s", name, hexOf(recipient), hexOf(parties.Recipient))
+	}
+
+	thirdParty := submitter != burner
+	recipientMismatch := recipient != nil && *recipient != burner
+
+	if parties.ThirdParty != thirdParty || parties.RecipientMismatch != recipientMismatch {
+		t.Fatalf("%s : expected third party %t & recipient mismatch %t, found %+v", name, thirdParty, recipientMismatch, parties)
+	}
+}
+
+func TestPOSExitParties(t *testing.T) {
+	env := newTestEnv(t, nil)
+	defer env.close()
+
+	// Exit tx is always sent by account of root chain
+	submitter := env.root.From()
+
+	exited := func(exitor common.Address) *types.Log {
+		return &types.Log{Address: predicate, Topics: []common.Hash{exitedERC20Topics[0], exitor.Hash(), childToken.Hash()}, Data: make([]byte, 32)}
+	}
+
+	for name, v := range map[string]struct {
+		burner    common.Address
+		recipient common.Address
+	}{
+		"exited by burner":      {submitter, submitter},
+		"exited by third party": {account, account},
+		"exited to other":       {account, operator},
+	} {
+		burn := erc20Burn()
+		burn.Topics[1] = v.burner.Hash()
+
+		tx := &WithdrawTransaction{
+			BurnTxHash: env.child.Mine(&testutil.Tx{Logs: []*types.Log{burn}}),
+			ExitTxHash: env.root.Mine(&testutil.Tx{To: rootChainManager, Logs: []*types.Log{exited(v.recipient)}}),
+		}
+
+		recipient := v.recipient
+		checkExitParties(t, name, getExitParties(context.Background(), env.root.Client(), env.child.Client(), env.db, tx, true), v.burner, submitter, &recipient)
+	}
+
+	// Not exited yet, failed exit & Plasma burn, which isn't exited on POS
+	burnTxHash := env.child.Mine(&testutil.Tx{Logs: []*types.Log{erc20Burn()}})
+	failedTxHash := env.root.Mine(&testutil.Tx{Failed: true})
+	exitTxHash := env.root.Mine(&testutil.Tx{Logs: []*types.Log{exited(account)}})
+	plasmaTxHash := env.child.Mine(&testutil.Tx{Logs: plasmaBurn()})
+
+	for name, tx := range map[string]*WithdrawTransaction{
+		"not exited":  {BurnTxHash: burnTxHash},
+		"exit failed": {BurnTxHash: burnTxHash, ExitTxHash: failedTxHash},
+		"plasma burn": {BurnTxHash: plasmaTxHash, ExitTxHash: exitTxHash},
+	} {
+		if parties := getExitParties(context.Background(), env.root.Client(), env.child.Client(), env.db, tx, true); parties != nil {
+			t.Fatalf("%s : expected no exit parties, found %+v", name, parties)
+		}
+	}
+}
+
+func TestPlasmaExitParties(t *testing.T) {
+	env := newTestEnv(t, nil)
+	defer env.close()
+
+	submitter := env.root.From()
+
+	exitID := common.HexToHash("0x0e1d")
+	started := func(exitor common.Address) *types.Log {
+		return &types.Log{Address: withdrawManager, Topics: []common.Hash{plasmaExitStartedTopic, exitor.Hash(), exitID, childToken.Hash()}, Data: make([]byte, 64)}
+	}
+	withdrawn := func(exitID common.Hash, user common.Address) *types.Log {
+		return &types.Log{Address: withdrawManager, Topics: []common.Hash{plasmaExitedTopic, exitID, user.Hash(), childToken.Hash()}, Data: make([]byte, 32)}
+	}
+
+	for name, v := range map[string]struct {
+		burner    common.Address
+		recipient common.Address
+	}{
+		"exited by burner":      {submitter, submitter},
+		"exited by third party": {account, account},
+		// Exit NFT is transferred by burner, after starting exit, so funds are
+		// withdrawn to its owner
+		"exit nft transferred": {account, operator},
+	} {
+		logs := plasmaBurn()
+		logs[0].Topics[2] = v.burner.Hash()
+
+		tx := &WithdrawTransaction{
+			BurnTxHash:            env.child.Mine(&testutil.Tx{Logs: logs}),
+			ConfirmWithdrawTxHash: env.root.Mine(&testutil.Tx{To: withdrawManager, Logs: []*types.Log{started(v.burner)}}),
+			// Withdraw of some other exit is processed in same tx, before this one
+			ExitTxHash: env.root.Mine(&testutil.Tx{To: withdrawManager, Logs: []*types.Log{withdrawn(common.HexToHash("0x0e1c"), operator), withdrawn(exitID, v.recipient)}}),
+		}
+
+		recipient := v.recipient
+		checkExitParties(t, name, getExitParties(context.Background(), env.root.Client(), env.child.Client(), env.db, tx, false), v.burner, submitter, &recipient)
+	}
+
+	// Recipient isn't found out, when exit ID isn't known, as confirm tx isn't
+	tx := &WithdrawTransaction{
+		BurnTxHash: env.child.Mine(&testutil.Tx{Logs: plasmaBurn()}),
+		ExitTxHash: env.root.Mine(&testutil.Tx{To: withdrawManager, Logs: []*types.Log{withdrawn(exitID, operator)}}),
+	}
+
+	checkExitParties(t, "confirm tx not known", getExitParties(context.Background(), env.root.Client(), env.child.Client(), env.db, tx, false), account, submitter, nil)
+}
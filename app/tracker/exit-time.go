@@ -1,66 +1,27 @@
 package tracker
 
 import (
-	"bytes"
-	"encoding/json"
+	"app/tracing"
+	"context"
 	"fmt"
-	"io/ioutil"
-	"log"
-	"net/http"
+	"logger"
 
 	"github.com/ethereum/go-ethereum/common"
 )
 
 // Given child chain's burnTxHash & respective confirmTxHash, performed on root chain
 // it can check whether withdraw tx has covered challenge period or not
-func checkWhetherExitable(burnTxHash common.Hash, confirmTxHash common.Hash) *TransactionState {
-	resp, err := http.Post(fmt.Sprintf("%s/%s", get("POSExitChecker"), "exit-time"),
-		"application/json",
-		bytes.NewReader((&CheckExitable{
-			BurnTxHash:    burnTxHash,
-			ConfirmTxHash: confirmTxHash,
-		}).JSON()))
-
-	if err != nil {
-		log.Println("[!] ", err)
-
-		return &TransactionState{
-			Code:    -8,
-			Message: "Exitable in 0",
-		}
-	}
-
-	defer resp.Body.Close()
+func checkWhetherExitable(ctx context.Context, burnTxHash common.Hash, confirmTxHash common.Hash) *TransactionState {
+	ctx, span := tracing.Start(ctx, "checkWhetherExitable", tracing.KindInternal, tracing.Attr("tx.hash", burnTxHash.Hex()), tracing.Attr("tx.confirm_hash", confirmTxHash.Hex()))
+	defer span.End()
 
 	// HTTP status code must be 200 for valid response, otherwise we don't proceed
-	if resp.StatusCode != 200 {
-
-		return &TransactionState{
-			Code:    -8,
-			Message: "Exitable in 0",
-		}
-
-	}
-
-	data, err := ioutil.ReadAll(resp.Body)
+	_tmp, err := getPOSExitChecker().ExitTime(ctx, burnTxHash, confirmTxHash)
 	if err != nil {
-		log.Println("[!] ", err)
-
-		return &TransactionState{
-			Code:    -8,
-			Message: "Exitable in 0",
-		}
-	}
-
-	var _tmp TransactionState
-
-	if err = json.Unmarshal(data, &_tmp); err != nil {
-		log.Println("[!] ", err)
+		workerLog.Ctx(ctx).Error("Failed to reach `pos-exit-checker`", logger.Fields{"error": err, "txHash": burnTxHash.Hex(), "confirmTxHash": confirmTxHash.Hex()})
+		recordWorkerFailure("pos_exit_checker")
 
-		return &TransactionState{
-			Code:    -8,
-			Message: "Exitable in 0",
-		}
+		return fallbackStatus(-8, "Exitable in 0", "pos-exit-checker")
 	}
 
 	if _tmp.Code == 0 {
@@ -0,0 +1,140 @@
+package tracker
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/gin-gonic/gin"
+	"github.com/maticnetwork/deposits-withdrawals-tracking/app/client"
+	"gorm.io/gorm"
+)
+
+// Status to be returned when dependency, named `source`, couldn't be reached, so
+// that clients can tell it apart from real status
+func fallbackStatus(code int, msg string, source string) *TransactionState {
+	return &TransactionState{
+		Code:     code,
+		Message:  msg,
+		Degraded: true,
+		Source:   source,
+		Reason:   fmt.Sprintf("Failed to reach %s, falling back to last known status", source),
+	}
+}
+
+// How long result of probing each dependency is served from cache, so that
+// frequent hits to unauthenticated `/health` don't reach dependencies
+const healthProbeTTL = time.Second * time.Duration(5)
+
+// Result of probing dependency, served from cache if probed recently, otherwise
+// probed once, for all concurrent callers, given at max 5 seconds, bypassing
+// cached responses of dependency
+func probe(probes *ttlCache, flights *flightGroup, name string, check func(context.Context) error) *client.DependencyHealth {
+	if v, ok := probes.get(name); ok {
+		return v.(*client.DependencyHealth)
+	}
+
+	v, ok := flights.do(withoutCache(context.Background()), name, func(ctx context.Context) interface{} {
+		_tmp := &client.DependencyHealth{Healthy: true}
+		if err := check(ctx); err != nil {
+			_tmp.Healthy = false
+			_tmp.Message = err.Error()
+		}
+
+		probes.set(name, _tmp, healthProbeTTL)
+		return _tmp
+	})
+	if !ok {
+		return &client.DependencyHealth{Healthy: false, Message: "Failed to probe"}
+	}
+
+	return v.(*client.DependencyHealth)
+}
+
+// Checks health of each dependency of this service concurrently, where result
+// of probing each of them is reused for `healthProbeTTL`
+func checkHealth(rootClient *ethclient.Client, childClient *ethclient.Client, db *gorm.DB, probes *ttlCache, flights *flightGroup) *client.HealthResponse {
+	checks := map[string]func(context.Context) error{
+		"root-rpc": func(ctx context.Context) error {
+			_, err := rootClient.BlockNumber(ctx)
+			return err
+		},
+		"child-rpc": func(ctx context.Context) error {
+			_, err := childClient.BlockNumber(ctx)
+			return err
+		},
+		"db": func(ctx context.Context) error {
+			_db, err := db.DB()
+			if err != nil {
+				return err
+			}
+
+			return _db.PingContext(ctx)
+		},
+		"check-point-tracker": func(ctx context.Context) error {
+			_, err := getCheckPointTracker().IsCheckPointed(ctx, big.NewInt(0))
+			return err
+		},
+		"state-id-manager": func(ctx context.Context) error {
+			_, err := getStateIDManager().LastStateID(ctx)
+			return err
+		},
+		"pos-exit-checker": func(ctx context.Context) error {
+			return getPOSExitChecker().Health(ctx)
+		},
+	}
+
+	if sharedCacheIsRedis() {
+		checks["redis"] = sharedCache.Ping
+	}
+
+	resp := &client.HealthResponse{
+		Healthy:      true,
+		Dependencies: make(map[string]*client.DependencyHealth),
+	}
+
+	mutex := sync.Mutex{}
+	var wg sync.WaitGroup
+
+	for name, check := range checks {
+
+		wg.Add(1)
+		go func(name string, check func(context.Context) error) {
+			defer wg.Done()
+
+			_tmp := probe(probes, flights, name, check)
+
+			mutex.Lock()
+			resp.Dependencies[name] = _tmp
+			if !_tmp.Healthy {
+				resp.Healthy = false
+			}
+			mutex.Unlock()
+		}(name, check)
+
+	}
+	wg.Wait()
+
+	return resp
+}
+
+// Responds with health of all dependencies, with status code 503
+// when any of them is unhealthy
+func healthHandler(rootClient *ethclient.Client, childClient *ethclient.Client, db *gorm.DB) gin.HandlerFunc {
+	probes := newTTLCache("health", 16)
+	flights := newFlightGroup("health", time.Second*time.Duration(5))
+
+	return func(c *gin.Context) {
+		resp := checkHealth(rootClient, childClient, db, probes, flights)
+
+		if !resp.Healthy {
+			c.JSON(503, resp)
+			return
+		}
+
+		c.JSON(200, resp)
+	}
+}
@@ -0,0 +1,30 @@
+package tracker
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHealthProbesCached(t *testing.T) {
+	env := newTestEnv(t, nil)
+	defer env.close()
+
+	health := func() int {
+		w := httptest.NewRecorder()
+		env.router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/health", nil))
+
+		return w.Code
+	}
+
+	if code := health(); code != 200 {
+		t.Fatalf("expected healthy, found %d", code)
+	}
+
+	// Going down is noticed only once cached probe results expire
+	env.workers.POSExitChecker.Close()
+
+	if code := health(); code != 200 {
+		t.Fatalf("expected cached probe results, found %d", code)
+	}
+}
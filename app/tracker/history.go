@@ -0,0 +1,109 @@
+package tracker
+
+import (
+	"context"
+	"encoding/json"
+	"logger"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gin-gonic/gin"
+	"github.com/maticnetwork/deposits-withdrawals-tracking/app/client"
+	"gorm.io/gorm"
+)
+
+// TxStatusHistory - Each change in persisted status of tx, along with request
+// which caused it, so that wrong statuses can be traced back
+type TxStatusHistory struct {
+	ID              uint      `gorm:"column:id;primaryKey"`
+	Chain           string    `gorm:"column:chain;type:varchar(8);not null"`
+	TransactionHash string    `gorm:"column:txhash;type:char(66);index;not null"`
+	Code            int       `gorm:"column:code;type:smallint;not null"`
+	Message         string    `gorm:"column:msg;type:varchar;not null"`
+	RequestID       string    `gorm:"column:request_id;type:varchar;not null;default:''"`
+	CreatedAt       time.Time `gorm:"column:created_at"`
+}
+
+// TableName - Overriding default table name
+func (TxStatusHistory) TableName() string {
+	return "tx_status_history"
+}
+
+// AdminAudit - Each action performed using admin API
+type AdminAudit struct {
+	ID        uint      `gorm:"column:id;primaryKey"`
+	Actor     string    `gorm:"column:actor;type:varchar;not null"`
+	Action    string    `gorm:"column:action;type:varchar;not null"`
+	Target    string    `gorm:"column:target;type:varchar;not null"`
+	Details   string    `gorm:"column:details;type:text;not null;default:''"`
+	RequestID string    `gorm:"column:request_id;type:varchar;not null;default:''"`
+	IP        string    `gorm:"column:ip;type:varchar;not null;default:''"`
+	CreatedAt time.Time `gorm:"column:created_at"`
+}
+
+// TableName - Overriding default table name
+func (AdminAudit) TableName() string {
+	return "admin_audit"
+}
+
+// Records change in persisted status of tx, on given chain i.e. `root`/ `child`
+func recordStatusChange(ctx context.Context, db *gorm.DB, chain string, txHash common.Hash, code int, msg string) {
+	if err := db.WithContext(ctx).Create(&TxStatusHistory{
+		Chain:           chain,
+		TransactionHash: txHash.Hex(),
+		Code:            code,
+		Message:         msg,
+		RequestID:       logger.RequestID(ctx),
+		CreatedAt:       time.Now().UTC(),
+	}).Error; err != nil {
+		dbLog.Ctx(ctx).Error("Failed to record tx status change", logger.Fields{"error": err, "txHash": txHash.Hex(), "code": code})
+	}
+}
+
+// History of persisted status of tx, oldest first
+func findStatusHistory(ctx context.Context, db *gorm.DB, txHash common.Hash) ([]TxStatusHistory, error) {
+	var history []TxStatusHistory
+
+	if err := db.WithContext(ctx).Where("txhash = ?", txHash.Hex()).Order("id").Find(&history).Error; err != nil {
+		return nil, err
+	}
+
+	return history, nil
+}
+
+// Records admin action, performed by given actor, on given target along with
+// details, which are JSON encoded
+func writeAudit(ctx context.Context, db *gorm.DB, actor string, action string, target string, details interface{}, ip string) {
+	data, err := json.Marshal(details)
+	if err != nil {
+		data = []byte{}
+	}
+
+	entry := &AdminAudit{
+		Actor:     actor,
+		Action:    action,
+		Target:    target,
+		Details:   string(data),
+		RequestID: logger.RequestID(ctx),
+		IP:        ip,
+		CreatedAt: time.Now().UTC(),
+	}
+
+	appLog.Ctx(ctx).Info("Admin action", logger.Fields{"actor": actor, "action": action, "target": target, "details": entry.Details})
+
+	// Audit entry must be written even if request got cancelled meanwhile
+	if err := db.Create(entry).Error; err != nil {
+		dbLog.Ctx(ctx).Error("Failed to record admin action", logger.Fields{"error": err, "action": action, "target": target})
+	}
+}
+
+// Records admin action, performed while serving this request, on given target
+// along with details
+func recordAudit(c *gin.Context, db *gorm.DB, action string, target string, details interface{}) {
+	actor := c.GetHeader(client.AdminActorHeader)
+	if actor == "" {
+		actor = "admin"
+	}
+
+	writeAudit(c.Request.Context(), db, actor, action, target, details, c.ClientIP())
+}
@@ -0,0 +1,203 @@
+package tracker
+
+import (
+	"flag"
+	"fmt"
+	"logger"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Usage of `keys` admin command
+const keysUsage = `Usage : bridge-api keys <command> [flags]
+
+Commands :
+  create   -name <name> [-rate-limit <n>] [-daily-quota <n>] [-origins <origin,...>]
+  list
+  update   -id <id> [-rate-limit <n>] [-daily-quota <n>] [-origins <origin,...>]
+  disable  -id <id>
+  enable   -id <id>
+  usage    -id <id> [-days <n>]
+`
+
+// RunKeys - Admin command for managing API keys, issued to partner dApps, given
+// path to config file & arguments following `keys`
+func RunKeys(file string, args []string) {
+	// Only database is required
+	if err := params.Read(file, nil); err != nil {
+		appLog.Fatal("Failed to read config", logger.Fields{"error": err, "file": file})
+	}
+
+	c, err := loadConfig(false)
+	if err != nil {
+		appLog.Fatal("Bad config", logger.Fields{"error": err})
+	}
+	setConfig(c)
+
+	setUpLogging()
+
+	if len(args) == 0 {
+		fmt.Fprint(os.Stderr, keysUsage)
+		os.Exit(2)
+	}
+
+	flags := flag.NewFlagSet(args[0], flag.ExitOnError)
+	flags.Usage = func() {
+		fmt.Fprint(os.Stderr, keysUsage)
+	}
+
+	id := flags.Uint("id", 0, "ID of API key")
+	name := flags.String("name", "", "Name of partner, key is issued to")
+	rateLimit := flags.Int64("rate-limit", -1, "Requests per minute, 0 for default `RateLimit`")
+	dailyQuota := flags.Int64("daily-quota", -1, "Requests per day, 0 for no quota")
+	origins := flags.String("origins", "-", "Comma separated origins, empty for any origin")
+	days := flags.Int("days", 7, "Number of days, usage to be shown for")
+
+	flags.Parse(args[1:])
+
+	db := connectToDB()
+	migrateDB(db)
+
+	switch args[0] {
+	case "create":
+		err = createAPIKey(db, *name, *rateLimit, *dailyQuota, *origins)
+	case "list":
+		err = listAPIKeys(db)
+	case "update":
+		err = updateAPIKey(db, *id, map[string]interface{}{}, *rateLimit, *dailyQuota, *origins)
+	case "disable":
+		err = updateAPIKey(db, *id, map[string]interface{}{"disabled": true}, -1, -1, "-")
+	case "enable":
+		err = updateAPIKey(db, *id, map[string]interface{}{"disabled": false}, -1, -1, "-")
+	case "usage":
+		err = showAPIKeyUsage(db, *id, *days)
+	default:
+		fmt.Fprint(os.Stderr, keysUsage)
+		os.Exit(2)
+	}
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error : %s\n", err.Error())
+		os.Exit(1)
+	}
+}
+
+// Normalizes comma separated origins
+func joinOrigins(origins string) string {
+	return strings.Join((&APIKey{AllowedOrigins: origins}).Origins(), ",")
+}
+
+// Creates API key, printing it, which is only time it's shown
+func createAPIKey(db *gorm.DB, name string, rateLimit int64, dailyQuota int64, origins string) error {
+	if name == "" {
+		return fmt.Errorf("name of partner is required")
+	}
+
+	key, err := generateAPIKey()
+	if err != nil {
+		return err
+	}
+
+	apiKey := &APIKey{
+		Name:      name,
+		Prefix:    key[:11],
+		Hash:      hashAPIKey(key),
+		CreatedAt: time.Now().UTC(),
+	}
+
+	if rateLimit > 0 {
+		apiKey.RateLimit = rateLimit
+	}
+	if dailyQuota > 0 {
+		apiKey.DailyQuota = dailyQuota
+	}
+	if origins != "-" {
+		apiKey.AllowedOrigins = joinOrigins(origins)
+	}
+
+	if err := db.Create(apiKey).Error; err != nil {
+		return err
+	}
+
+	fmt.Printf("Created API key %d for %s, store it safely, it won't be shown again\n\n%s\n", apiKey.ID, name, key)
+	return nil
+}
+
+// Prints all API keys, as table
+func listAPIKeys(db *gorm.DB) error {
+	var keys []APIKey
+
+	if err := db.Order("id").Find(&keys).Error; err != nil {
+		return err
+	}
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(writer, "ID\tName\tPrefix\tRate Limit\tDaily Quota\tOrigins\tDisabled\tCreated At")
+
+	for _, v := range keys {
+		fmt.Fprintf(writer, "%d\t%s\t%s\t%d\t%d\t%s\t%t\t%s\n", v.ID, v.Name, v.Prefix, v.RateLimit, v.DailyQuota, v.AllowedOrigins, v.Disabled, v.CreatedAt.Format(time.RFC3339))
+	}
+
+	return writer.Flush()
+}
+
+// Updates API key, where negative limits & `-` as origins mean not to be updated
+//
+// Updated key takes effect within `apiKeyTTL`, in all replicas
+func updateAPIKey(db *gorm.DB, id uint, updates map[string]interface{}, rateLimit int64, dailyQuota int64, origins string) error {
+	if id == 0 {
+		return fmt.Errorf("id of API key is required")
+	}
+
+	if rateLimit >= 0 {
+		updates["rate_limit"] = rateLimit
+	}
+	if dailyQuota >= 0 {
+		updates["daily_quota"] = dailyQuota
+	}
+	if origins != "-" {
+		updates["allowed_origins"] = joinOrigins(origins)
+	}
+
+	if len(updates) == 0 {
+		return fmt.Errorf("nothing to update")
+	}
+
+	result := db.Model(&APIKey{}).Where("id = ?", id).Updates(updates)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("API key %d not found", id)
+	}
+
+	fmt.Printf("Updated API key %d\n", id)
+	return nil
+}
+
+// Prints usage of API key, for last given many days
+func showAPIKeyUsage(db *gorm.DB, id uint, days int) error {
+	if id == 0 {
+		return fmt.Errorf("id of API key is required")
+	}
+
+	var usages []APIUsage
+
+	since := time.Now().UTC().AddDate(0, 0, -days).Format("2006-01-02")
+	if err := db.Where("key_id = ? and day > ?", id, since).Order("day desc").Find(&usages).Error; err != nil {
+		return err
+	}
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(writer, "Day\tRequests\tThrottled")
+
+	for _, v := range usages {
+		fmt.Fprintf(writer, "%s\t%d\t%d\n", v.Day, v.Requests, v.Throttled)
+	}
+
+	return writer.Flush()
+}
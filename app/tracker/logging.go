@@ -0,0 +1,23 @@
+package tracker
+
+import "logger"
+
+// Loggers of components of this service, minimum level of each of them
+// can be configured using `LogLevels` e.g. `LogLevels=db=warn,worker=debug`
+var (
+	appLog    = logger.New("bridge-api")
+	httpLog   = logger.New("http")
+	statusLog = logger.New("status")
+	dbLog     = logger.New("db")
+	workerLog = logger.New("worker")
+)
+
+// Sets up log levels, as specified in config file, where `LogLevel` is
+// default level for all components, unless overridden in `LogLevels`
+func setUpLogging() {
+	conf := getConfig()
+
+	if err := logger.Configure(conf.LogLevel, conf.LogLevels); err != nil {
+		appLog.Fatal("Failed to configure logging", logger.Fields{"error": err})
+	}
+}
@@ -0,0 +1,171 @@
+package tracker
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/ethereum/go-ethereum/metrics/prometheus"
+	"github.com/gin-gonic/gin"
+)
+
+// Metrics need to be enabled before any of them gets registered, otherwise
+// no-op versions are handed out
+//
+// They're kept in own registry, so that metrics of geth packages, we
+// depend on, don't show up
+func init() {
+	metrics.Enabled = true
+}
+
+// All metrics collected by this service
+var registry = metrics.NewRegistry()
+
+// Chain names of connected RPC clients, to be used as part of metric names
+var (
+	chains      = make(map[*ethclient.Client]string)
+	chainsMutex = &sync.RWMutex{}
+)
+
+// Remembers which chain this client is connected to, so that
+// RPC calls made using it can be attributed to that chain
+func nameChain(client *ethclient.Client, name string) {
+	if client == nil {
+		return
+	}
+
+	chainsMutex.Lock()
+	defer chainsMutex.Unlock()
+
+	chains[client] = name
+}
+
+// Name of chain given client is connected to
+func chainOf(client *ethclient.Client) string {
+	chainsMutex.RLock()
+	defer chainsMutex.RUnlock()
+
+	if name, ok := chains[client]; ok {
+		return name
+	}
+
+	return "unknown"
+}
+
+// Name of chain given reader is connected to, which is known only for clients
+// named using `nameChain`
+func readerName(reader chainReader) string {
+	if client, ok := reader.(*ethclient.Client); ok {
+		return chainOf(client)
+	}
+
+	return "unknown"
+}
+
+// Prometheus metric names can't have anything other than `[a-zA-Z0-9_:]`,
+// where `/` gets converted to `_` while exporting
+func metricName(parts ...string) string {
+	for i, v := range parts {
+		parts[i] = strings.Trim(v, "/")
+	}
+
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '/':
+			return r
+		}
+		return '_'
+	}, strings.Join(parts, "/"))
+}
+
+// Records time spent in RPC call to given chain, along with whether it failed
+//
+// Tx not being found is not considered as failure, because that's what we get
+// for pending tx(s)
+func recordRPCCall(chain string, method string, start time.Time, err error) {
+	metrics.GetOrRegisterTimer(metricName("bridge", "rpc", chain, method), registry).UpdateSince(start)
+
+	if err != nil && err != ethereum.NotFound {
+		metrics.GetOrRegisterCounter(metricName("bridge", "rpc", chain, method, "errors"), registry).Inc(1)
+	}
+}
+
+// Records failure in talking to worker, which results into status
+// checking function falling back to some previously known status
+func recordWorkerFailure(worker string) {
+	metrics.GetOrRegisterCounter(metricName("bridge", "worker", worker, "failures"), registry).Inc(1)
+}
+
+// Records whether tx status was found in DB or not, when status
+// checking function looked it up
+//
+// Hit ratio is also kept updated, so that it can be looked at directly
+func recordDBLookup(found bool) {
+	hits := metrics.GetOrRegisterCounter("bridge/db/hits", registry)
+	misses := metrics.GetOrRegisterCounter("bridge/db/misses", registry)
+
+	if found {
+		hits.Inc(1)
+	} else {
+		misses.Inc(1)
+	}
+
+	metrics.GetOrRegisterGaugeFloat64("bridge/db/hit_ratio", registry).Update(float64(hits.Count()) / float64(hits.Count()+misses.Count()))
+}
+
+// Records whether lookup in named cache was served from it or not
+func recordCacheLookup(cache string, hit bool) {
+	if hit {
+		metrics.GetOrRegisterCounter(metricName("bridge", "cache", cache, "hits"), registry).Inc(1)
+		return
+	}
+
+	metrics.GetOrRegisterCounter(metricName("bridge", "cache", cache, "misses"), registry).Inc(1)
+}
+
+// Records latest `lastStateId`, as received from `state-id-manager`
+func recordLastStateID(id int64) {
+	metrics.GetOrRegisterGauge("bridge/worker/state_id_manager/last_state_id", registry).Update(id)
+}
+
+// Middleware counting requests per endpoint & status code returned, along
+// with time spent in serving them
+func requestMetrics() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		c.Next()
+
+		// Unmatched routes are not worth having separate metrics for
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+
+		metrics.GetOrRegisterTimer(metricName("bridge", "http", route), registry).UpdateSince(start)
+		metrics.GetOrRegisterCounter(metricName("bridge", "http", route, fmt.Sprintf("%d", c.Writer.Status())), registry).Inc(1)
+	}
+}
+
+// Registries of workers running in-process, whose metrics are
+// to be served along with ours
+var workerRegistries []metrics.Registry
+
+// Serves all collected metrics, in Prometheus exposition format
+func metricsHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		merged := metrics.NewRegistry()
+
+		for _, r := range append([]metrics.Registry{registry}, workerRegistries...) {
+			r.Each(func(name string, m interface{}) {
+				merged.Register(name, m)
+			})
+		}
+
+		prometheus.Handler(merged).ServeHTTP(c.Writer, c.Request)
+	}
+}
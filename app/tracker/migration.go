@@ -1,14 +1,21 @@
 package tracker
 
 import (
-	"log"
+	"logger"
 
 	"gorm.io/gorm"
 )
 
 // Running automatic database migration, on application start up
 func migrateDB(db *gorm.DB) {
-	if err := db.AutoMigrate(&RootChain{}, &ChildChain{}); err != nil {
-		log.Fatalln("[!] ", err)
+	if err := db.AutoMigrate(&RootChain{}, &ChildChain{}, &APIKey{}, &APIUsage{}, &TxStatusHistory{}, &AdminAudit{}, &TokenMapping{}, &IndexCursor{}, &ExitDiscovery{}); err != nil {
+		dbLog.Fatal("Failed to migrate database", logger.Fields{"error": err})
+	}
+
+	// Deposits, whose state sync failed on child chain, were persisted with code `2`
+	// earlier, same as failed deposit tx, & then with `8`, same as timed out ones,
+	// while they've their own code now
+	if err := db.Model(&RootChain{}).Where("code IN (?) AND msg = ?", []int{2, 8}, "State Sync Failed").UpdateColumn("code", 10).Error; err != nil {
+		dbLog.Fatal("Failed to migrate database", logger.Fields{"error": err})
 	}
 }
@@ -0,0 +1,458 @@
+package tracker
+
+import (
+	"math/big"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gin-gonic/gin"
+	"github.com/maticnetwork/deposits-withdrawals-tracking/app/client"
+)
+
+// routeDoc - Describes one endpoint exposed by this service, to be
+// used for generating OpenAPI document
+//
+// `Request` & `Response` are zero values of types, being accepted & returned
+// by handler, schemas are derived from them using reflection
+type routeDoc struct {
+	Method      string
+	Path        string
+	Summary     string
+	Deprecated  bool
+	Request     interface{}
+	Response    interface{}
+	Description string
+}
+
+// All endpoints registered in `NewRouter`, any route added there
+// must be described here too, otherwise a warning will be logged during startup
+var routeDocs = []routeDoc{
+	{
+		Method:   http.MethodPost,
+		Path:     "/v1/approval",
+		Summary:  "Status of `Token.approve()` tx(s) on root chain",
+		Request:  client.BulkPayload{},
+		Response: client.ApprovalResponse{},
+	},
+	{
+		Method:   http.MethodPost,
+		Path:     "/v1/deposit",
+		Summary:  "Status of `depositFor`/ `depositEtherFor` tx(s) on root chain",
+		Request:  client.BulkPayload{},
+		Response: client.DepositResponse{},
+	},
+	{
+		Method:   http.MethodPost,
+		Path:     "/v1/pos-burn",
+		Summary:  "Status of POS bridge burn tx(s) on child chain, upto exit",
+		Request:  client.BulkPayload{},
+		Response: client.Statuses{},
+	},
+	{
+		Method:     http.MethodPost,
+		Path:       "/v1/pos-withdraw",
+		Summary:    "Same as `/v1/pos-burn`",
+		Deprecated: true,
+		Request:    client.BulkPayload{},
+		Response:   client.Statuses{},
+	},
+	{
+		Method:   http.MethodPost,
+		Path:     "/v1/pos-exit",
+		Summary:  "Status of `RootChain*.exit(...)` tx(s) on root chain",
+		Request:  client.BulkPayload{},
+		Response: client.Statuses{},
+	},
+	{
+		Method:     http.MethodPost,
+		Path:       "/v1/exit",
+		Summary:    "Same as `/v1/pos-exit`",
+		Deprecated: true,
+		Request:    client.BulkPayload{},
+		Response:   client.Statuses{},
+	},
+	{
+		Method:   http.MethodPost,
+		Path:     "/v1/plasma-burn",
+		Summary:  "Status of Plasma bridge burn tx(s) on child chain, upto checkpoint",
+		Request:  client.BulkPayload{},
+		Response: client.Statuses{},
+	},
+	{
+		Method:      http.MethodPost,
+		Path:        "/v1/plasma-confirm",
+		Summary:     "Status of Plasma withdraw(s), given burn & confirm withdraw tx hash pairs",
+		Description: "Response is keyed by confirm withdraw tx hash",
+		Request:     client.PlasmaExitBulkPayload{},
+		Response:    client.Statuses{},
+	},
+	{
+		Method:   http.MethodPost,
+		Path:     "/v1/plasma-exit",
+		Summary:  "Status of `WithdrawManager.processExits(...)` tx(s) on root chain",
+		Request:  client.BulkPayload{},
+		Response: client.Statuses{},
+	},
+	{
+		Method:      http.MethodPost,
+		Path:        "/v2/withdraw",
+		Summary:     "Status of Plasma/ POS withdraw(s), upto exit",
+		Description: "Response is keyed by burn tx hash",
+		Request:     client.WithdrawTransactions{},
+		Response:    client.WithdrawResponse{},
+	},
+	{
+		Method:      http.MethodGet,
+		Path:        "/v2/tokens",
+		Summary:     "Tokens mapped between root & child chain",
+		Description: "Optionally filtered by `bridge` i.e. `pos`/ `plasma` & `type` i.e. `ERC20`/ `ERC721`/ `ERC1155`/ `Ether` query params",
+		Response:    client.TokensResponse{},
+	},
+	{
+		Method:      http.MethodGet,
+		Path:        "/v2/tokens/:address",
+		Summary:     "Mappings of token, given its address on either of chains",
+		Description: "One mapping per bridge, token is mapped on, responds with 404 when it's not mapped",
+		Response:    client.TokenResponse{},
+	},
+	{
+		Method:  http.MethodGet,
+		Path:    "/openapi.json",
+		Summary: "This document",
+	},
+	{
+		Method:  http.MethodGet,
+		Path:    "/metrics",
+		Summary: "Metrics in Prometheus exposition format",
+	},
+	{
+		Method:      http.MethodGet,
+		Path:        "/health",
+		Summary:     "Health of RPC nodes, DB & workers",
+		Description: "Responds with status code 503, when any of them is unhealthy",
+		Response:    client.HealthResponse{},
+	},
+	{
+		Method:   http.MethodGet,
+		Path:     "/admin/tx/:hash",
+		Summary:  "Persisted statuses & status history of tx",
+		Response: client.AdminTxResponse{},
+	},
+	{
+		Method:      http.MethodPost,
+		Path:        "/admin/tx/:hash/recheck",
+		Summary:     "Finds out status of tx once again, bypassing cache & persisted status",
+		Description: "Persisted status, which disagrees with freshly found out one, is deleted",
+		Request:     client.RecheckRequest{},
+		Response:    client.RecheckResponse{},
+	},
+	{
+		Method:      http.MethodDelete,
+		Path:        "/admin/tx/:hash",
+		Summary:     "Deletes persisted status of tx",
+		Description: "From `chain` query param i.e. `root`/ `child` table if given, otherwise from both",
+		Response:    client.DeleteResponse{},
+	},
+	{
+		Method:      http.MethodPost,
+		Path:        "/admin/recheck",
+		Summary:     "Rechecks all non-terminal persisted statuses, in background",
+		Description: "At max `limit` query param many, defaulting to `BulkRecheckLimit`. Responds with status code 202",
+		Response:    client.BulkRecheckResponse{},
+	},
+	{
+		Method:      http.MethodGet,
+		Path:        "/admin/audit",
+		Summary:     "Recent admin actions, latest first",
+		Description: "At max `limit` query param many, defaulting to 50",
+		Response:    []client.AuditEntry{},
+	},
+	{
+		Method:      http.MethodGet,
+		Path:        "/v2/admin/stuck",
+		Summary:     "Transfers held in status for longer than its `StuckAfter` threshold, oldest first",
+		Description: "Looks at statuses `1`, `-3` & `-13`, or only `code` query param, if given, returning at max `limit` query param many, defaulting to 100",
+		Response:    &client.StuckResponse{},
+	},
+}
+
+// Whether route belongs to admin API i.e. `/admin/*` or `/v2/admin/*`
+func isAdminPath(path string) bool {
+	return strings.HasPrefix(path, "/admin/") || strings.HasPrefix(path, "/v2/admin/")
+}
+
+// Routes registered under these prefixes belong to workers, which
+// are documented in their own README(s)
+var undocumentedPrefixes = []string{"/workers/"}
+
+var (
+	hashType   = reflect.TypeOf(common.Hash{})
+	addrType   = reflect.TypeOf(common.Address{})
+	bigIntType = reflect.TypeOf(big.Int{})
+)
+
+// Generates JSON schema of given type, while placing named structs
+// under `components`, so that they can be referred to
+func schemaOf(t reflect.Type, components map[string]interface{}) map[string]interface{} {
+	switch t {
+
+	case hashType:
+		return gin.H{"type": "string", "pattern": "^0x[0-9a-fA-F]{64}$"}
+
+	case addrType:
+		return gin.H{"type": "string", "pattern": "^0x[0-9a-fA-F]{40}$"}
+
+	case bigIntType:
+		return gin.H{"type": "string", "pattern": "^[0-9]+$"}
+
+	}
+
+	switch t.Kind() {
+
+	case reflect.Ptr:
+		return schemaOf(t.Elem(), components)
+
+	case reflect.Bool:
+		return gin.H{"type": "boolean"}
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return gin.H{"type": "integer"}
+
+	case reflect.Float32, reflect.Float64:
+		return gin.H{"type": "number"}
+
+	case reflect.String:
+		return gin.H{"type": "string"}
+
+	case reflect.Slice, reflect.Array:
+		return gin.H{"type": "array", "items": schemaOf(t.Elem(), components)}
+
+	case reflect.Map:
+		// keys are always strings in JSON, so only values can be described
+		return gin.H{"type": "object", "additionalProperties": schemaOf(t.Elem(), components)}
+
+	case reflect.Struct:
+
+		ref := gin.H{"$ref": "#/components/schemas/" + t.Name()}
+		if _, ok := components[t.Name()]; ok {
+			return ref
+		}
+
+		// placeholder, so that self referencing types don't recurse forever
+		components[t.Name()] = nil
+
+		properties := gin.H{}
+		required := make([]string, 0)
+
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if field.PkgPath != "" {
+				continue
+			}
+
+			name := strings.Split(field.Tag.Get("json"), ",")[0]
+			if name == "-" {
+				continue
+			}
+			if name == "" {
+				name = field.Name
+			}
+
+			properties[name] = schemaOf(field.Type, components)
+
+			if strings.Contains(field.Tag.Get("binding"), "required") {
+				required = append(required, name)
+			}
+		}
+
+		schema := gin.H{"type": "object", "properties": properties}
+		if len(required) != 0 {
+			schema["required"] = required
+		}
+
+		components[t.Name()] = schema
+		return ref
+
+	}
+
+	return gin.H{}
+}
+
+// Generates OpenAPI 3 document from `routeDocs`
+func openAPIDocument() gin.H {
+	components := make(map[string]interface{})
+	paths := gin.H{}
+
+	errorSchema := schemaOf(reflect.TypeOf(client.ErrorResponse{}), components)
+
+	for _, v := range routeDocs {
+
+		operation := gin.H{
+			"summary":    v.Summary,
+			"deprecated": v.Deprecated,
+		}
+
+		if v.Description != "" {
+			operation["description"] = v.Description
+		}
+
+		responses := gin.H{}
+
+		if v.Response != nil {
+			responses["200"] = gin.H{
+				"description": "Successful response",
+				"content": gin.H{
+					"application/json": gin.H{
+						"schema": schemaOf(reflect.TypeOf(v.Response), components),
+					},
+				},
+			}
+		} else {
+			responses["200"] = gin.H{"description": "OK"}
+		}
+
+		if v.Request != nil {
+			operation["requestBody"] = gin.H{
+				"required": true,
+				"content": gin.H{
+					"application/json": gin.H{
+						"schema": schemaOf(reflect.TypeOf(v.Request), components),
+					},
+				},
+			}
+
+			responses["400"] = gin.H{
+				"description": "Bad/ Empty/ Heavy Payload",
+				"content": gin.H{
+					"application/json": gin.H{
+						"schema": errorSchema,
+					},
+				},
+			}
+		}
+
+		// Status checking endpoints are authenticated using API key, when present
+		// or required, while being rate limited, when `RateLimit` is set
+		if !isAdminPath(v.Path) && (strings.HasPrefix(v.Path, "/v1/") || strings.HasPrefix(v.Path, "/v2/")) {
+			operation["security"] = []gin.H{{"apiKey": []string{}}, {}}
+
+			for code, description := range map[string]string{
+				"401": "Missing/ Invalid API Key",
+				"403": "Origin Not Allowed, for API key",
+				"429": "Too Many Requests/ Daily Quota Exceeded, retry after `Retry-After` seconds, if present",
+			} {
+				responses[code] = gin.H{
+					"description": description,
+					"content": gin.H{
+						"application/json": gin.H{
+							"schema": errorSchema,
+						},
+					},
+				}
+			}
+		}
+
+		// Admin endpoints are authenticated using admin token
+		if isAdminPath(v.Path) {
+			operation["security"] = []gin.H{{"adminToken": []string{}}}
+
+			for code, description := range map[string]string{
+				"401": "Invalid Admin Token",
+				"403": "Admin API Disabled",
+			} {
+				responses[code] = gin.H{
+					"description": description,
+					"content": gin.H{
+						"application/json": gin.H{
+							"schema": errorSchema,
+						},
+					},
+				}
+			}
+		}
+
+		// Path params i.e. `:name` in gin, are written as `{name}` in OpenAPI
+		path := v.Path
+		params := make([]gin.H, 0)
+
+		for _, segment := range strings.Split(v.Path, "/") {
+			if strings.HasPrefix(segment, ":") {
+				path = strings.Replace(path, segment, "{"+segment[1:]+"}", 1)
+				params = append(params, gin.H{"name": segment[1:], "in": "path", "required": true, "schema": gin.H{"type": "string"}})
+			}
+		}
+
+		if len(params) != 0 {
+			operation["parameters"] = params
+		}
+
+		operation["responses"] = responses
+
+		item, ok := paths[path].(gin.H)
+		if !ok {
+			item = gin.H{}
+			paths[path] = item
+		}
+		item[strings.ToLower(v.Method)] = operation
+
+	}
+
+	return gin.H{
+		"openapi": "3.0.3",
+		"info": gin.H{
+			"title":   "Bridge API",
+			"version": "1.0.0",
+		},
+		"paths": paths,
+		"components": gin.H{
+			"schemas": components,
+			"securitySchemes": gin.H{
+				"apiKey":     gin.H{"type": "apiKey", "in": "header", "name": APIKeyHeader},
+				"adminToken": gin.H{"type": "http", "scheme": "bearer"},
+			},
+		},
+	}
+}
+
+// Serves OpenAPI document, which is generated only once
+func openAPIHandler() gin.HandlerFunc {
+	document := openAPIDocument()
+
+	return func(c *gin.Context) {
+		c.JSON(200, document)
+	}
+}
+
+// Checks whether every route registered with router has been described
+// in `routeDocs` or not, returning those which are not, in `METHOD path` form
+func checkRouteDocs(router *gin.Engine) []string {
+	documented := make(map[string]bool)
+	for _, v := range routeDocs {
+		documented[v.Method+" "+v.Path] = true
+	}
+
+	undocumented := make([]string, 0)
+
+	for _, v := range router.Routes() {
+
+		skip := false
+		for _, p := range undocumentedPrefixes {
+			if strings.HasPrefix(v.Path, p) {
+				skip = true
+				break
+			}
+		}
+
+		if skip || documented[v.Method+" "+v.Path] {
+			continue
+		}
+
+		undocumented = append(undocumented, v.Method+" "+v.Path)
+
+	}
+
+	return undocumented
+}
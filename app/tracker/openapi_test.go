@@ -0,0 +1,12 @@
+package tracker
+
+import "testing"
+
+func TestRouteDocs(t *testing.T) {
+	env := newTestEnv(t, nil)
+	defer env.close()
+
+	if undocumented := checkRouteDocs(env.router); len(undocumented) != 0 {
+		t.Fatalf("routes not described in OpenAPI document : %v", undocumented)
+	}
+}
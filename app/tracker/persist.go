@@ -2,8 +2,8 @@ package tracker
 
 import (
 	"fmt"
-	"log"
-	"strconv"
+	"logger"
+	"time"
 
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
@@ -17,10 +17,15 @@ type Tabler interface {
 
 // RootChain - Tx performed on root chain during deposit/ withdraw, to be
 // persisted in this table, along with respective status
+//
+// `UpdatedAt` is when status was last changed, not when it was last found
+// out, so that how long tx has been held in it, can be told
 type RootChain struct {
-	TransactionHash string `gorm:"column:txhash;type:char(66);primaryKey"`
-	Code            int    `gorm:"column:code;type:smallint;not null"`
-	Message         string `gorm:"column:msg;type:varchar;not null"`
+	TransactionHash string    `gorm:"column:txhash;type:char(66);primaryKey"`
+	Code            int       `gorm:"column:code;type:smallint;not null"`
+	Message         string    `gorm:"column:msg;type:varchar;not null"`
+	CreatedAt       time.Time `gorm:"column:created_at;not null;default:now()"`
+	UpdatedAt       time.Time `gorm:"column:updated_at;not null;default:now();index"`
 }
 
 // TableName - Overriding default table name
@@ -30,10 +35,14 @@ func (RootChain) TableName() string {
 
 // ChildChain - Tx performed on child chain during deposit/ withdraw, to be
 // persisted in this table, along with respective status
+//
+// `UpdatedAt` carries same meaning as in `RootChain`
 type ChildChain struct {
-	TransactionHash string `gorm:"column:txhash;type:char(66);primaryKey"`
-	Code            int    `gorm:"column:code;type:smallint;not null"`
-	Message         string `gorm:"column:msg;type:varchar;not null"`
+	TransactionHash string    `gorm:"column:txhash;type:char(66);primaryKey"`
+	Code            int       `gorm:"column:code;type:smallint;not null"`
+	Message         string    `gorm:"column:msg;type:varchar;not null"`
+	CreatedAt       time.Time `gorm:"column:created_at;not null;default:now()"`
+	UpdatedAt       time.Time `gorm:"column:updated_at;not null;default:now();index"`
 }
 
 // TableName - Overriding default table name
@@ -43,16 +52,26 @@ func (ChildChain) TableName() string {
 
 // Connecting to postgres database
 func connectToDB() *gorm.DB {
-	dbPort, err := strconv.Atoi(get("DB_PORT"))
-	if err != nil {
-		log.Fatalln("[!] ", err)
-	}
+	conf := getConfig()
 
-	db, err := gorm.Open(postgres.Open(fmt.Sprintf("postgresql://%s:%s@%s:%d/%s", get("DB_USER"), get("DB_PASSWORD"), get("DB_HOST"), dbPort, get("DB_NAME"))),
+	db, err := gorm.Open(postgres.Open(fmt.Sprintf("postgresql://%s:%s@%s:%d/%s", conf.DBUser, conf.DBPassword, conf.DBHost, conf.DBPort, conf.DBName)),
 		&gorm.Config{})
 	if err != nil {
-		log.Fatalln("[!] ", err)
+		dbLog.Fatal("Failed to connect to database", logger.Fields{"error": err})
 	}
 
 	return db
 }
+
+// Closing connections to postgres database, during shutdown
+func closeDB(db *gorm.DB) {
+	_db, err := db.DB()
+	if err != nil {
+		dbLog.Error("Failed to get database connection pool", logger.Fields{"error": err})
+		return
+	}
+
+	if err := _db.Close(); err != nil {
+		dbLog.Error("Failed to close database connections", logger.Fields{"error": err})
+	}
+}
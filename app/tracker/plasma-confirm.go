@@ -2,8 +2,12 @@ package tracker
 
 import (
 	"app/nft"
-	"log"
+	"app/tracing"
+	"context"
+	"logger"
+	"time"
 
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"gorm.io/gorm"
@@ -11,8 +15,11 @@ import (
 
 // Given both child chain's burn tx hash & respective confirm tx hash on root chain,
 // it can check what's status of this plasma exit tx
-func getPlasmaConfirmStatus(client *ethclient.Client, db *gorm.DB, burnTxHash common.Hash, confirmTxHash common.Hash, _nft *nft.Nft) *TransactionState {
-	if status := getRootChaintxStatusFromDB(db, confirmTxHash); status != nil {
+func getPlasmaConfirmStatus(ctx context.Context, client *ethclient.Client, db *gorm.DB, burnTxHash common.Hash, confirmTxHash common.Hash, _nft *nft.Nft) *TransactionState {
+	ctx, span := tracing.Start(ctx, "getPlasmaConfirmStatus", tracing.KindInternal, tracing.Attr("tx.hash", burnTxHash.Hex()), tracing.Attr("tx.confirm_hash", confirmTxHash.Hex()))
+	defer span.End()
+
+	if status := getRootChaintxStatusFromDB(ctx, db, confirmTxHash); status != nil {
 		if status.Code == -6 || status.Code == -7 || status.Code == -10 {
 			return &TransactionState{
 				Code:    status.Code,
@@ -21,7 +28,10 @@ func getPlasmaConfirmStatus(client *ethclient.Client, db *gorm.DB, burnTxHash co
 		}
 	}
 
-	receipt := getTransactionReceipt(client, confirmTxHash)
+	receipt, err := getTransactionReceipt(ctx, client, confirmTxHash)
+	if err != nil {
+		return receiptFailedStatus(ctx, client, confirmTxHash, err, -5, "Pending")
+	}
 	if receipt == nil {
 		return &TransactionState{
 			Code:    -5,
@@ -32,12 +42,13 @@ func getPlasmaConfirmStatus(client *ethclient.Client, db *gorm.DB, burnTxHash co
 	// Picking out `ExitStarted(address,uint256,address,uint256,bool)` from log entry
 	//
 	// If present, that will ensure, we're given with correct root chain tx hash, which is generated
-	// as result of executing `ERC20Predicate.startExitWithBurntTokens(...)`
+	// as result of executing `ERC20Predicate.startExitWithBurntTokens(...)` or
+	// `ERC721Predicate.startExitWithBurntTokens(...)`, emitting same event
 	//
 	// Otherwise, we're going to stop checking further
-	_log := pickOutTransactionLog(receipt.Logs, "0xaa5303fdad123ab5ecaefaf69137bf8632257839546d43a3b3dd148cc2879d6f")
+	_log := pickOutTransactionLog(receipt.Logs, plasmaExitStartedTopic)
 	if _log == nil {
-		putRootChainTxStatusInDB(db, confirmTxHash, -6, "Bad Plasma Exit Hash")
+		putRootChainTxStatusInDB(ctx, db, confirmTxHash, -6, "Bad Plasma Exit Hash")
 
 		return &TransactionState{
 			Code:    -6,
@@ -47,7 +58,7 @@ func getPlasmaConfirmStatus(client *ethclient.Client, db *gorm.DB, burnTxHash co
 
 	// Tx execution failed
 	if receipt.Status == 0 {
-		putRootChainTxStatusInDB(db, confirmTxHash, -7, "Failed")
+		putRootChainTxStatusInDB(ctx, db, confirmTxHash, -7, "Failed")
 
 		return &TransactionState{
 			Code:    -7,
@@ -57,10 +68,16 @@ func getPlasmaConfirmStatus(client *ethclient.Client, db *gorm.DB, burnTxHash co
 
 	// If Plasma exit has happened, then this NFT
 	// must not exist
-	exists, err := _nft.Exists(nil, _log.Topics[2].Big())
+	_ctx, _span := tracing.Start(ctx, "contract.ExitNFT.exists", tracing.KindClient, tracing.Attr("token.id", _log.Topics[2].Big().String()))
+	start := time.Now()
+
+	exists, err := _nft.Exists(&bind.CallOpts{Context: _ctx}, _log.Topics[2].Big())
+	recordRPCCall("root", "eth_call", start, err)
+	_span.RecordError(err)
+	_span.End()
 	if err != nil {
 
-		log.Printf("[!] Failed to check if Plasma withdraw NFT exists : %s\n", err.Error())
+		statusLog.Ctx(ctx).Error("Failed to check if Plasma withdraw NFT exists", logger.Fields{"error": err, "txHash": burnTxHash.Hex(), "confirmTxHash": confirmTxHash.Hex()})
 
 		// This scenario was not expected
 		// so it's returning some `static` result
@@ -70,17 +87,14 @@ func getPlasmaConfirmStatus(client *ethclient.Client, db *gorm.DB, burnTxHash co
 		// for exit in some
 		//
 		// But when exactly, is not known by service
-		return &TransactionState{
-			Code:    -8,
-			Message: "Exitable in 0",
-		}
+		return fallbackStatus(-8, "Exitable in 0", "root-rpc")
 
 	}
 
 	// Yes Plasma exit has happened
 	if !exists {
 
-		putRootChainTxStatusInDB(db, confirmTxHash, -10, "Exited")
+		putRootChainTxStatusInDB(ctx, db, confirmTxHash, -10, "Exited")
 
 		return &TransactionState{
 			Code:    -10,
@@ -91,6 +105,6 @@ func getPlasmaConfirmStatus(client *ethclient.Client, db *gorm.DB, burnTxHash co
 
 	// Attempts to determine how much time left before
 	// plasma exit can be invoked
-	return checkWhetherExitable(burnTxHash, confirmTxHash)
+	return checkWhetherExitable(ctx, burnTxHash, confirmTxHash)
 
 }
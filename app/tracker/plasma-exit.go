@@ -2,6 +2,8 @@ package tracker
 
 import (
 	"app/nft"
+	"app/tracing"
+	"context"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethclient"
@@ -12,10 +14,13 @@ import (
 // found in confirmed state, we're not talking to blockchain, rather cached status is returned
 //
 // If tx on root chain is still in pending state, then we'll check with blockchain whether status has updated or not
-func getPlasmaExitStatus(client *ethclient.Client, db *gorm.DB, txHash common.Hash) *TransactionState {
+func getPlasmaExitStatus(ctx context.Context, client *ethclient.Client, db *gorm.DB, txHash common.Hash) *TransactionState {
+	ctx, span := tracing.Start(ctx, "getPlasmaExitStatus", tracing.KindInternal, tracing.Attr("tx.hash", txHash.Hex()))
+	defer span.End()
+
 	// If record is present in database & confirmed, then we're simply going to read status from database
 	// and return to client
-	if status := getRootChaintxStatusFromDB(db, txHash); status != nil {
+	if status := getRootChaintxStatusFromDB(ctx, db, txHash); status != nil {
 		if status.Code == -10 || status.Code == -11 {
 			return &TransactionState{
 				Code:    status.Code,
@@ -24,7 +29,10 @@ func getPlasmaExitStatus(client *ethclient.Client, db *gorm.DB, txHash common.Ha
 		}
 	}
 
-	receipt := getTransactionReceipt(client, txHash)
+	receipt, err := getTransactionReceipt(ctx, client, txHash)
+	if err != nil {
+		return receiptFailedStatus(ctx, client, txHash, err, -12, "Pending")
+	}
 	if receipt == nil {
 		return &TransactionState{
 			Code:    -12,
@@ -33,7 +41,7 @@ func getPlasmaExitStatus(client *ethclient.Client, db *gorm.DB, txHash common.Ha
 	}
 
 	if receipt.Status == 0 {
-		putRootChainTxStatusInDB(db, txHash, -11, "Failed")
+		putRootChainTxStatusInDB(ctx, db, txHash, -11, "Failed")
 
 		return &TransactionState{
 			Code:    -11,
@@ -41,7 +49,7 @@ func getPlasmaExitStatus(client *ethclient.Client, db *gorm.DB, txHash common.Ha
 		}
 	}
 
-	putRootChainTxStatusInDB(db, txHash, -10, "Exited")
+	putRootChainTxStatusInDB(ctx, db, txHash, -10, "Exited")
 
 	return &TransactionState{
 		Code:    -10,
@@ -63,10 +71,13 @@ func getPlasmaExitStatus(client *ethclient.Client, db *gorm.DB, txHash common.Ha
 //
 // @note This function is nothing but updated & improved version of `getPlasmaExitStatus`
 // so that we also take NFT existance under consideration
-func getReliablePlasmaExitStatus(client *ethclient.Client, db *gorm.DB, burnTxHash common.Hash, confirmTxHash common.Hash, _nft *nft.Nft, exitTxHash common.Hash) *TransactionState {
+func getReliablePlasmaExitStatus(ctx context.Context, client *ethclient.Client, db *gorm.DB, burnTxHash common.Hash, confirmTxHash common.Hash, _nft *nft.Nft, exitTxHash common.Hash) *TransactionState {
+	ctx, span := tracing.Start(ctx, "getReliablePlasmaExitStatus", tracing.KindInternal, tracing.Attr("tx.hash", burnTxHash.Hex()), tracing.Attr("tx.confirm_hash", confirmTxHash.Hex()), tracing.Attr("tx.exit_hash", exitTxHash.Hex()))
+	defer span.End()
+
 	// If record is present in database & confirmed, then we're simply going to read status from database
 	// and return to client
-	if status := getRootChaintxStatusFromDB(db, exitTxHash); status != nil {
+	if status := getRootChaintxStatusFromDB(ctx, db, exitTxHash); status != nil {
 		if status.Code == -10 || status.Code == -11 {
 			return &TransactionState{
 				Code:    status.Code,
@@ -75,7 +86,10 @@ func getReliablePlasmaExitStatus(client *ethclient.Client, db *gorm.DB, burnTxHa
 		}
 	}
 
-	receipt := getTransactionReceipt(client, exitTxHash)
+	receipt, err := getTransactionReceipt(ctx, client, exitTxHash)
+	if err != nil {
+		return receiptFailedStatus(ctx, client, exitTxHash, err, -12, "Pending")
+	}
 	if receipt == nil {
 		return &TransactionState{
 			Code:    -12,
@@ -84,7 +98,7 @@ func getReliablePlasmaExitStatus(client *ethclient.Client, db *gorm.DB, burnTxHa
 	}
 
 	if receipt.Status == 0 {
-		putRootChainTxStatusInDB(db, exitTxHash, -11, "Failed")
+		putRootChainTxStatusInDB(ctx, db, exitTxHash, -11, "Failed")
 
 		return &TransactionState{
 			Code:    -11,
@@ -101,7 +115,7 @@ func getReliablePlasmaExitStatus(client *ethclient.Client, db *gorm.DB, burnTxHa
 	//
 	// @note This situation happens when a lot of pending tx(s) present in Plasma
 	// exit queue, gas provided with tx, gets exhausted
-	confirmTxStat := getPlasmaConfirmStatus(client, db, burnTxHash, confirmTxHash, _nft)
+	confirmTxStat := getPlasmaConfirmStatus(ctx, client, db, burnTxHash, confirmTxHash, _nft)
 
 	var retStatus *TransactionState
 
@@ -118,6 +132,9 @@ func getReliablePlasmaExitStatus(client *ethclient.Client, db *gorm.DB, burnTxHa
 		//
 		// Necessary steps should be asking user again call
 		// Plasma `processExit`
+		//
+		// Persisted, so that exits held in it for long, can be found out
+		putRootChainTxStatusInDB(ctx, db, exitTxHash, -13, "Plasma exit called, but not exited")
 
 		retStatus = &TransactionState{
 			Code:    -13,
@@ -127,7 +144,7 @@ func getReliablePlasmaExitStatus(client *ethclient.Client, db *gorm.DB, burnTxHa
 	case -10:
 		// This is what we expect to see ideally
 
-		putRootChainTxStatusInDB(db, exitTxHash, -10, "Exited")
+		putRootChainTxStatusInDB(ctx, db, exitTxHash, -10, "Exited")
 
 		retStatus = confirmTxStat
 
@@ -1,6 +1,8 @@
 package tracker
 
 import (
+	"app/tracing"
+	"context"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"gorm.io/gorm"
@@ -10,10 +12,13 @@ import (
 // found in confirmed state, we're not talking to blockchain, rather cached status is returned
 //
 // If tx on root chain is still in pending state, then we'll check with blockchain whether status has updated or not
-func getPOSExitStatus(client *ethclient.Client, db *gorm.DB, txHash common.Hash) *TransactionState {
+func getPOSExitStatus(ctx context.Context, client *ethclient.Client, db *gorm.DB, txHash common.Hash) *TransactionState {
+	ctx, span := tracing.Start(ctx, "getPOSExitStatus", tracing.KindInternal, tracing.Attr("tx.hash", txHash.Hex()))
+	defer span.End()
+
 	// If record is present in database & confirmed, then we're simply going to read status from database
 	// and return to client
-	if status := getRootChaintxStatusFromDB(db, txHash); status != nil {
+	if status := getRootChaintxStatusFromDB(ctx, db, txHash); status != nil {
 		if status.Code == -10 || status.Code == -11 {
 			return &TransactionState{
 				Code:    status.Code,
@@ -22,7 +27,10 @@ func getPOSExitStatus(client *ethclient.Client, db *gorm.DB, txHash common.Hash)
 		}
 	}
 
-	receipt := getTransactionReceipt(client, txHash)
+	receipt, err := getTransactionReceipt(ctx, client, txHash)
+	if err != nil {
+		return receiptFailedStatus(ctx, client, txHash, err, -12, "Pending")
+	}
 	if receipt == nil {
 		return &TransactionState{
 			Code:    -12,
@@ -31,7 +39,7 @@ func getPOSExitStatus(client *ethclient.Client, db *gorm.DB, txHash common.Hash)
 	}
 
 	if receipt.Status == 0 {
-		putRootChainTxStatusInDB(db, txHash, -11, "Failed")
+		putRootChainTxStatusInDB(ctx, db, txHash, -11, "Failed")
 
 		return &TransactionState{
 			Code:    -11,
@@ -39,7 +47,7 @@ func getPOSExitStatus(client *ethclient.Client, db *gorm.DB, txHash common.Hash)
 		}
 	}
 
-	putRootChainTxStatusInDB(db, txHash, -10, "Exited")
+	putRootChainTxStatusInDB(ctx, db, txHash, -10, "Exited")
 
 	return &TransactionState{
 		Code:    -10,
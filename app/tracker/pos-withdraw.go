@@ -1,11 +1,9 @@
 package tracker
 
 import (
-	"bytes"
-	"encoding/json"
-	"io/ioutil"
-	"log"
-	"net/http"
+	"app/tracing"
+	"context"
+	"logger"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethclient"
@@ -15,8 +13,11 @@ import (
 // Given burn tx hash on child chain, it'll check whether
 // this transaction has exited using POS bridge or not
 // by talking to another micro service, `pos-exit-checker`
-func getPOSBurnStatus(client *ethclient.Client, db *gorm.DB, txHash common.Hash) *TransactionState {
-	if status := getChildChaintxStatusFromDB(db, txHash); status != nil {
+func getPOSBurnStatus(ctx context.Context, client *ethclient.Client, db *gorm.DB, txHash common.Hash) *TransactionState {
+	ctx, span := tracing.Start(ctx, "getPOSBurnStatus", tracing.KindInternal, tracing.Attr("tx.hash", txHash.Hex()))
+	defer span.End()
+
+	if status := getChildChaintxStatusFromDB(ctx, db, txHash); status != nil {
 		if status.Code == -5 || status.Code == -2 {
 			return &TransactionState{
 				Code:    status.Code,
@@ -26,57 +27,27 @@ func getPOSBurnStatus(client *ethclient.Client, db *gorm.DB, txHash common.Hash)
 	}
 
 	// first checking whether tx is checkpointed or not
-	_state := getCheckPointStatus(client, db, txHash)
+	_state := getCheckPointStatus(ctx, client, db, txHash)
 	if _state.Code != -4 {
 		return _state
 	}
 
-	resp, err := http.Post(get("POSExitChecker"),
-		"application/json",
-		bytes.NewReader((&POSExited{
-			TransactionHash: txHash.Hex(),
-		}).JSON()))
-	if err != nil {
-		log.Println("[!] ", err)
-
-		return &TransactionState{
-			Code:    -4,
-			Message: "Checkpointed",
-		}
-	}
-
-	defer resp.Body.Close()
-
-	var _tmp TransactionState
-
-	data, err := ioutil.ReadAll(resp.Body)
+	exited, err := getPOSExitChecker().IsExited(ctx, txHash)
 	if err != nil {
-		log.Println("[!] ", err)
+		workerLog.Ctx(ctx).Error("Failed to reach `pos-exit-checker`", logger.Fields{"error": err, "txHash": txHash.Hex()})
+		recordWorkerFailure("pos_exit_checker")
 
-		return &TransactionState{
-			Code:    -4,
-			Message: "Checkpointed",
-		}
-	}
-
-	err = json.Unmarshal(data, &_tmp)
-	if err != nil {
-		log.Println("[!] ", err)
-
-		return &TransactionState{
-			Code:    -4,
-			Message: "Checkpointed",
-		}
+		return fallbackStatus(-4, "Checkpointed", "pos-exit-checker")
 	}
 
-	if _tmp.Code == 0 {
+	if !exited {
 		return &TransactionState{
 			Code:    -4,
 			Message: "Checkpointed",
 		}
 	}
 
-	putChildChainTxStatusInDB(db, txHash, -5, "Exited")
+	putChildChainTxStatusInDB(ctx, db, txHash, -5, "Exited")
 
 	return &TransactionState{
 		Code:    -5,
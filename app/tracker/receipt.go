@@ -1,8 +1,13 @@
 package tracker
 
 import (
+	"app/tracing"
 	"context"
+	"logger"
+	"strings"
+	"time"
 
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
@@ -10,13 +15,74 @@ import (
 
 // Fetches transaction receipt of specific transaction hash
 // will only return something non-nil, given that transaction is not pending
-func getTransactionReceipt(client *ethclient.Client, txHash common.Hash) *types.Receipt {
-	receipt, err := client.TransactionReceipt(context.Background(), txHash)
+//
+// Error is returned, when receipt couldn't be fetched e.g. RPC node is unreachable
+// or request deadline is hit, so that it's not mistaken for pending tx
+//
+// Receipts are served from cache, if present, while concurrent fetches of
+// same receipt are coalesced
+func getTransactionReceipt(ctx context.Context, client *ethclient.Client, txHash common.Hash) (*types.Receipt, error) {
+	key := strings.Join([]string{chainOf(client), txHash.Hex()}, "/")
+
+	if !cacheBypassed(ctx) {
+		if v, ok := receiptCache.get(key); ok {
+			receipt, _ := v.(*types.Receipt)
+			return receipt, nil
+		}
+	}
+
+	v, ok := receiptFlights.do(ctx, key, func(ctx context.Context) interface{} {
+		return fetchTransactionReceipt(ctx, client, txHash, key)
+	})
+	if !ok {
+		return nil, flightError(ctx)
+	}
+
+	result := v.(*workerResult)
+	if result.err != nil {
+		return nil, result.err
+	}
+
+	receipt, _ := result.value.(*types.Receipt)
+	return receipt, nil
+}
+
+// Fetches transaction receipt from RPC node, keeping it cached against given key,
+// where not found receipt is cached for shorter duration, while failed fetch is
+// never cached
+func fetchTransactionReceipt(ctx context.Context, client *ethclient.Client, txHash common.Hash, key string) *workerResult {
+	ctx, span := tracing.Start(ctx, "rpc.eth_getTransactionReceipt", tracing.KindClient, tracing.Attr("chain", chainOf(client)), tracing.Attr("tx.hash", txHash.Hex()))
+	defer span.End()
+
+	start := time.Now()
+
+	receipt, err := client.TransactionReceipt(ctx, txHash)
+	recordRPCCall(chainOf(client), "eth_getTransactionReceipt", start, err)
+	if err == ethereum.NotFound {
+		receiptCache.set(key, (*types.Receipt)(nil), pendingReceiptTTL)
+		return &workerResult{value: (*types.Receipt)(nil)}
+	}
 	if err != nil {
-		return nil
+		span.RecordError(err)
+		return &workerResult{err: err}
 	}
 
+	receiptCache.set(key, receipt, receiptTTL)
+
 	// Status is 0, in case of failed transaction execution
 	// to be taken care of in next stage of processing
-	return receipt
+	return &workerResult{value: receipt}
+}
+
+// Status to be returned, when receipt of tx couldn't be fetched, where it's timed
+// out one, if request deadline is hit, otherwise status tx is known to be in, at
+// least, marked as degraded
+func receiptFailedStatus(ctx context.Context, client *ethclient.Client, txHash common.Hash, err error, code int, msg string) *TransactionState {
+	statusLog.Ctx(ctx).Error("Failed to fetch tx receipt", logger.Fields{"error": err, "chain": chainOf(client), "txHash": txHash.Hex()})
+
+	if ctx.Err() != nil {
+		return timedOutStatus()
+	}
+
+	return fallbackStatus(code, msg, chainOf(client)+"-rpc")
 }
@@ -0,0 +1,892 @@
+package tracker
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"logger"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/maticnetwork/deposits-withdrawals-tracking/app/client"
+	"gorm.io/gorm"
+)
+
+// Logs are queried in ranges of these many blocks, while reconciling
+const reconcileBlockRange = 5000
+
+// Logs of child tokens are queried for these many of them at a time
+const reconcileTokenBatch = 200
+
+// Window, which couldn't be reconciled, is retried after this long
+const reconcileRetryInterval = time.Minute * time.Duration(5)
+
+// Transfers are matched against counterparts found upto this long before/ after
+// window, so that deposits getting synced & burns getting exited around window
+// boundaries are not reported as unmatched
+const reconcileGrace = time.Hour
+
+// Topics of events emitted by Plasma child tokens, where root token & account are
+// indexed, while amount/ token ID is first value in data
+var (
+	plasmaDepositTopic        = crypto.Keccak256Hash([]byte("Deposit(address,address,uint256,uint256,uint256)"))
+	plasmaERC721DepositTopic  = crypto.Keccak256Hash([]byte("Deposit(address,address,uint256)"))
+	plasmaERC721WithdrawTopic = crypto.Keccak256Hash([]byte("Withdraw(address,address,uint256)"))
+)
+
+// Kinds of transfers across bridge, where deposits are matched against mints &
+// burns against exits
+const (
+	depositTransfer = "deposit"
+	mintTransfer    = "mint"
+	burnTransfer    = "burn"
+	exitTransfer    = "exit"
+)
+
+// chainReader - Subset of chain client, logs & headers are read with, while reconciling,
+// which is satisfied by both `ethclient.Client` & simulated backend, so that it can be
+// run offline
+type chainReader interface {
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+	FilterLogs(ctx context.Context, query ethereum.FilterQuery) ([]types.Log, error)
+}
+
+// bridgeContracts - Root chain contracts, transfers across bridge are emitted by,
+// where logs of any other contract are never reconciled, even when they're of same
+// signature, as anyone can deploy contract emitting them
+type bridgeContracts struct {
+	Predicates      []common.Address
+	DepositManager  common.Address
+	WithdrawManager common.Address
+}
+
+// Addresses of contracts, which are set
+func (b *bridgeContracts) addresses() []common.Address {
+	addresses := make([]common.Address, 0, len(b.Predicates)+2)
+	addresses = append(addresses, b.Predicates...)
+
+	for _, v := range []common.Address{b.DepositManager, b.WithdrawManager} {
+		if v != (common.Address{}) {
+			addresses = append(addresses, v)
+		}
+	}
+
+	return addresses
+}
+
+// Checks whether address is of one of POS predicates or not
+func (b *bridgeContracts) isPredicate(address common.Address) bool {
+	for _, v := range b.Predicates {
+		if v == address {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Topics of root chain logs, transfers across bridge are found in
+var rootTransferTopics = joinTopics(lockedERC20Topics, lockedERC721Topics, lockedERC721BatchTopics, lockedERC1155Topics, posExitedTopics, []common.Hash{lockedEtherTopic, newDepositBlockTopic, plasmaExitedTopic})
+
+// bridgeTransfer - One token ID/ amount moved across bridge, as found in log, where
+// `Token` is always root token
+type bridgeTransfer struct {
+	Kind     string         `json:"kind"`
+	Bridge   string         `json:"bridge"`
+	Token    common.Address `json:"token"`
+	Type     string         `json:"type"`
+	Account  common.Address `json:"account"`
+	TokenID  string         `json:"tokenId,omitempty"`
+	Amount   string         `json:"amount"`
+	TxHash   common.Hash    `json:"txHash"`
+	Block    uint64         `json:"block"`
+	LogIndex uint           `json:"logIndex"`
+	Time     time.Time      `json:"time"`
+}
+
+// Counterpart is looked for using this key, where ERC721 transfers are told apart
+// by token ID, ERC20 & Ether ones by amount, while ERC1155 ones by both
+func (b *bridgeTransfer) key() string {
+	return strings.Join([]string{b.Bridge, b.Token.Hex(), b.Account.Hex(), b.TokenID, b.Amount}, "/")
+}
+
+// tokenTotals - Total amount moved across bridge for token, in window, where
+// ERC721 tokens are counted
+type tokenTotals struct {
+	Bridge     string         `json:"bridge"`
+	RootToken  common.Address `json:"rootToken"`
+	ChildToken common.Address `json:"childToken"`
+	Type       string         `json:"type"`
+	Symbol     string         `json:"symbol"`
+	Deposited  string         `json:"deposited"`
+	Minted     string         `json:"minted"`
+	Burnt      string         `json:"burnt"`
+	Exited     string         `json:"exited"`
+	// Deposited but not minted & burnt but not exited, which
+	// may be negative
+	DepositGap  string `json:"depositGap"`
+	WithdrawGap string `json:"withdrawGap"`
+}
+
+// reconciliationReport - Deposits & withdraws in window, totalled per token, along with
+// transfers which couldn't be matched against their counterparts
+type reconciliationReport struct {
+	From        time.Time         `json:"from"`
+	To          time.Time         `json:"to"`
+	RootBlocks  [2]uint64         `json:"rootBlocks"`
+	ChildBlocks [2]uint64         `json:"childBlocks"`
+	Tokens      []*tokenTotals    `json:"tokens"`
+	Unmatched   []*bridgeTransfer `json:"unmatched"`
+}
+
+// Token registry, as looked up by reconciliation, keyed by bridge & root/ child token
+type tokenRegistry struct {
+	byRoot  map[string]*TokenMapping
+	byChild map[common.Address]*TokenMapping
+}
+
+// Builds registry out of token mappings
+func newTokenRegistry(mappings []TokenMapping) *tokenRegistry {
+	registry := &tokenRegistry{
+		byRoot:  make(map[string]*TokenMapping, len(mappings)),
+		byChild: make(map[common.Address]*TokenMapping, len(mappings)),
+	}
+
+	for i := range mappings {
+		v := &mappings[i]
+
+		registry.byRoot[strings.Join([]string{v.Bridge, common.HexToAddress(v.RootToken).Hex()}, "/")] = v
+		registry.byChild[common.HexToAddress(v.ChildToken)] = v
+	}
+
+	return registry
+}
+
+// Mapping of root token on bridge, nil if it's not mapped
+func (r *tokenRegistry) root(bridge string, token common.Address) *TokenMapping {
+	return r.byRoot[strings.Join([]string{bridge, token.Hex()}, "/")]
+}
+
+// Child tokens, logs of which are to be queried
+func (r *tokenRegistry) childTokens() []common.Address {
+	tokens := make([]common.Address, 0, len(r.byChild))
+	for k := range r.byChild {
+		tokens = append(tokens, k)
+	}
+
+	sort.Slice(tokens, func(i, j int) bool {
+		return tokens[i].Hex() < tokens[j].Hex()
+	})
+
+	return tokens
+}
+
+// Splits tokens moved by log into one transfer per token ID/ amount, so that each
+// of them can be matched against its counterpart
+func splitTransfer(kind string, bridge string, account common.Address, transfer *client.TokenTransfer, _log *types.Log) []*bridgeTransfer {
+	n := len(transfer.Amounts)
+	if len(transfer.TokenIDs) > n {
+		n = len(transfer.TokenIDs)
+	}
+
+	split := make([]*bridgeTransfer, 0, n)
+
+	for i := 0; i < n; i++ {
+		_transfer := &bridgeTransfer{
+			Kind:     kind,
+			Bridge:   bridge,
+			Token:    transfer.Token,
+			Type:     transfer.Type,
+			Account:  account,
+			Amount:   "1",
+			TxHash:   _log.TxHash,
+			Block:    _log.BlockNumber,
+			LogIndex: _log.Index,
+		}
+
+		if i < len(transfer.TokenIDs) {
+			_transfer.TokenID = transfer.TokenIDs[i]
+		}
+		if i < len(transfer.Amounts) {
+			_transfer.Amount = transfer.Amounts[i]
+		}
+
+		split = append(split, _transfer)
+	}
+
+	return split
+}
+
+// Transfers found in root chain log i.e. tokens locked by POS predicates/ Plasma
+// `DepositManager` & exited by POS predicates/ Plasma `WithdrawManager`, of tokens
+// present in registry, where log must be emitted by contract of respective role
+func rootTransfers(_log *types.Log, registry *tokenRegistry, contracts *bridgeContracts) ([]*bridgeTransfer, error) {
+	if len(_log.Topics) == 0 {
+		return nil, nil
+	}
+
+	switch topic := _log.Topics[0]; {
+	case topic == newDepositBlockTopic, topic == plasmaExitedTopic:
+		// `NewDepositBlock` indexes owner & token, while `Withdraw` indexes exit ID, user & token
+		kind, indexed, emitter := depositTransfer, 3, contracts.DepositManager
+		if topic == plasmaExitedTopic {
+			kind, indexed, emitter = exitTransfer, 4, contracts.WithdrawManager
+		}
+		if len(_log.Topics) != indexed || emitter == (common.Address{}) || _log.Address != emitter {
+			return nil, nil
+		}
+
+		token := common.BytesToAddress(_log.Topics[indexed-1].Bytes())
+
+		mapping := registry.root(client.PlasmaBridge, token)
+		if mapping == nil {
+			return nil, nil
+		}
+
+		if len(_log.Data) < 32 {
+			return nil, nil
+		}
+
+		values, err := decodeUints(_log.Data[:32], uint256Type)
+		if err != nil {
+			return nil, err
+		}
+
+		transfer := &client.TokenTransfer{Token: token, Type: mapping.Type, Amounts: values[0]}
+		if mapping.Type == client.ERC721Token {
+			transfer = &client.TokenTransfer{Token: token, Type: mapping.Type, TokenIDs: values[0]}
+		}
+
+		return splitTransfer(kind, client.PlasmaBridge, common.BytesToAddress(_log.Topics[indexed-2].Bytes()), transfer, _log), nil
+
+	default:
+		if !contracts.isPredicate(_log.Address) {
+			return nil, nil
+		}
+
+		transfer, account, err := predicateTransfer(_log)
+		if err != nil || transfer == nil {
+			return nil, err
+		}
+
+		if registry.root(client.POSBridge, transfer.Token) == nil {
+			return nil, nil
+		}
+
+		kind := depositTransfer
+		if isOneOf(topic, posExitedTopics) {
+			kind = exitTransfer
+		}
+
+		return splitTransfer(kind, client.POSBridge, account, transfer, _log), nil
+
+	}
+}
+
+// Transfers found in child chain log i.e. tokens minted & burnt by child tokens present
+// in registry, where POS ones transfer from/ to zero address, while Plasma ones emit
+// `Deposit`/ `Withdraw`
+func childTransfers(_log *types.Log, registry *tokenRegistry) ([]*bridgeTransfer, error) {
+	mapping, ok := registry.byChild[_log.Address]
+	if !ok || len(_log.Topics) == 0 {
+		return nil, nil
+	}
+
+	token := common.HexToAddress(mapping.RootToken)
+
+	if mapping.Bridge == client.PlasmaBridge {
+		var kind string
+
+		switch _log.Topics[0] {
+		case plasmaDepositTopic, plasmaERC721DepositTopic:
+			kind = mintTransfer
+		case plasmaWithdrawTopic, plasmaERC721WithdrawTopic:
+			kind = burnTransfer
+		default:
+			return nil, nil
+		}
+
+		if len(_log.Topics) != 3 || len(_log.Data) < 32 {
+			return nil, nil
+		}
+
+		values, err := decodeUints(_log.Data[:32], uint256Type)
+		if err != nil {
+			return nil, err
+		}
+
+		transfer := &client.TokenTransfer{Token: token, Type: mapping.Type, Amounts: values[0]}
+		if mapping.Type == client.ERC721Token {
+			transfer = &client.TokenTransfer{Token: token, Type: mapping.Type, TokenIDs: values[0]}
+		}
+
+		return splitTransfer(kind, client.PlasmaBridge, common.BytesToAddress(_log.Topics[2].Bytes()), transfer, _log), nil
+	}
+
+	transfer, from, to, err := movedTokens(_log)
+	if err != nil || transfer == nil {
+		return nil, err
+	}
+
+	// Child token's type is what it's mapped with, so that Ether is reported as such
+	transfer.Token, transfer.Type = token, mapping.Type
+
+	switch {
+	case from == (common.Address{}) && to != (common.Address{}):
+		return splitTransfer(mintTransfer, client.POSBridge, to, transfer, _log), nil
+	case to == (common.Address{}) && from != (common.Address{}):
+		return splitTransfer(burnTransfer, client.POSBridge, from, transfer, _log), nil
+	}
+
+	return nil, nil
+}
+
+// Queries logs matching query, from `from` upto `to` block, in ranges
+func filterLogs(ctx context.Context, chain chainReader, query ethereum.FilterQuery, from uint64, to uint64) ([]types.Log, error) {
+	logs := make([]types.Log, 0)
+
+	for ; from <= to; from += reconcileBlockRange {
+		end := from + reconcileBlockRange - 1
+		if end > to {
+			end = to
+		}
+
+		query.FromBlock = new(big.Int).SetUint64(from)
+		query.ToBlock = new(big.Int).SetUint64(end)
+
+		start := time.Now()
+
+		_logs, err := chain.FilterLogs(ctx, query)
+		recordRPCCall(readerName(chain), "eth_getLogs", start, err)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, v := range _logs {
+			if !v.Removed {
+				logs = append(logs, v)
+			}
+		}
+	}
+
+	return logs, nil
+}
+
+// Blocks mined in `[from, to)` on chain, as inclusive range, where false is returned
+// when none of them were
+func blockRange(ctx context.Context, chain chainReader, from time.Time, to time.Time) (uint64, uint64, bool, error) {
+	start := time.Now()
+
+	head, err := chain.HeaderByNumber(ctx, nil)
+	recordRPCCall(readerName(chain), "eth_getBlockByNumber", start, err)
+	if err != nil {
+		return 0, 0, false, err
+	}
+
+	first, err := blockAt(ctx, chain, uint64(from.Unix()), head.Number.Uint64())
+	if err != nil {
+		return 0, 0, false, err
+	}
+
+	// Head is still in window, when window hasn't ended yet
+	if head.Time < uint64(to.Unix()) {
+		return first, head.Number.Uint64(), head.Time >= uint64(from.Unix()), nil
+	}
+
+	last, err := blockAt(ctx, chain, uint64(to.Unix()), head.Number.Uint64())
+	if err != nil {
+		return 0, 0, false, err
+	}
+
+	return first, last - 1, last > first, nil
+}
+
+// Time, blocks were mined at, so that transfers found in them can be told to be
+// in window or not
+func blockTimes(ctx context.Context, chain chainReader, transfers []*bridgeTransfer) error {
+	times := make(map[uint64]time.Time)
+
+	for _, v := range transfers {
+		if t, ok := times[v.Block]; ok {
+			v.Time = t
+			continue
+		}
+
+		start := time.Now()
+
+		header, err := chain.HeaderByNumber(ctx, new(big.Int).SetUint64(v.Block))
+		recordRPCCall(readerName(chain), "eth_getBlockByNumber", start, err)
+		if err != nil {
+			return err
+		}
+
+		v.Time = time.Unix(int64(header.Time), 0).UTC()
+		times[v.Block] = v.Time
+	}
+
+	return nil
+}
+
+// Transfers across bridge, found in logs of root & child chain, in `[from, to)`
+//
+// Root chain logs are only queried from bridge contracts, while transfers are
+// picked out of them by topic here, as they emit few other events
+func collectTransfers(ctx context.Context, rootClient chainReader, childClient chainReader, registry *tokenRegistry, contracts *bridgeContracts, from time.Time, to time.Time) ([]*bridgeTransfer, [2]uint64, [2]uint64, error) {
+	var rootBlocks, childBlocks [2]uint64
+
+	addresses := contracts.addresses()
+	if len(addresses) == 0 {
+		return nil, rootBlocks, childBlocks, errors.New("no bridge contract to reconcile logs of, set `Predicates`, `DepositManager` or `WithdrawManager`")
+	}
+
+	transfers := make([]*bridgeTransfer, 0)
+
+	rootFrom, rootTo, ok, err := blockRange(ctx, rootClient, from, to)
+	if err != nil {
+		return nil, rootBlocks, childBlocks, err
+	}
+	if ok {
+		rootBlocks = [2]uint64{rootFrom, rootTo}
+
+		logs, err := filterLogs(ctx, rootClient, ethereum.FilterQuery{Addresses: addresses}, rootFrom, rootTo)
+		if err != nil {
+			return nil, rootBlocks, childBlocks, err
+		}
+
+		_logs := make([]*types.Log, len(logs))
+		for i := range logs {
+			_logs[i] = &logs[i]
+		}
+
+		_transfers := make([]*bridgeTransfer, 0)
+		for _, v := range pickOutTransactionLogs(_logs, rootTransferTopics...) {
+			found, err := rootTransfers(v, registry, contracts)
+			if err != nil {
+				return nil, rootBlocks, childBlocks, err
+			}
+
+			_transfers = append(_transfers, found...)
+		}
+
+		if err := blockTimes(ctx, rootClient, _transfers); err != nil {
+			return nil, rootBlocks, childBlocks, err
+		}
+
+		transfers = append(transfers, _transfers...)
+	}
+
+	childFrom, childTo, ok, err := blockRange(ctx, childClient, from, to)
+	if err != nil {
+		return nil, rootBlocks, childBlocks, err
+	}
+	if ok {
+		childBlocks = [2]uint64{childFrom, childTo}
+
+		tokens := registry.childTokens()
+
+		_transfers := make([]*bridgeTransfer, 0)
+		for i := 0; i < len(tokens); i += reconcileTokenBatch {
+			end := i + reconcileTokenBatch
+			if end > len(tokens) {
+				end = len(tokens)
+			}
+
+			logs, err := filterLogs(ctx, childClient, ethereum.FilterQuery{
+				Addresses: tokens[i:end],
+				Topics:    [][]common.Hash{{transferTopic, transferSingleTopic, transferBatchTopic, plasmaDepositTopic, plasmaWithdrawTopic, plasmaERC721DepositTopic, plasmaERC721WithdrawTopic}},
+			}, childFrom, childTo)
+			if err != nil {
+				return nil, rootBlocks, childBlocks, err
+			}
+
+			for j := range logs {
+				found, err := childTransfers(&logs[j], registry)
+				if err != nil {
+					return nil, rootBlocks, childBlocks, err
+				}
+
+				_transfers = append(_transfers, found...)
+			}
+		}
+
+		if err := blockTimes(ctx, childClient, _transfers); err != nil {
+			return nil, rootBlocks, childBlocks, err
+		}
+
+		transfers = append(transfers, _transfers...)
+	}
+
+	return transfers, rootBlocks, childBlocks, nil
+}
+
+// Adds amount to total kept in string form, returning updated one
+func addAmount(total string, amount string) string {
+	_total, ok := new(big.Int).SetString(total, 10)
+	if !ok {
+		_total = big.NewInt(0)
+	}
+
+	_amount, ok := new(big.Int).SetString(amount, 10)
+	if !ok {
+		return _total.String()
+	}
+
+	return _total.Add(_total, _amount).String()
+}
+
+// Difference of amounts kept in string form
+func subAmount(a string, b string) string {
+	_a, ok := new(big.Int).SetString(a, 10)
+	if !ok {
+		_a = big.NewInt(0)
+	}
+
+	_b, ok := new(big.Int).SetString(b, 10)
+	if !ok {
+		_b = big.NewInt(0)
+	}
+
+	return _a.Sub(_a, _b).String()
+}
+
+// Builds report of transfers in `[from, to)`, out of those found in wider range, so
+// that transfers in window are matched against counterparts outside it too
+func buildReport(transfers []*bridgeTransfer, registry *tokenRegistry, from time.Time, to time.Time) *reconciliationReport {
+	report := &reconciliationReport{
+		From:      from,
+		To:        to,
+		Tokens:    make([]*tokenTotals, 0),
+		Unmatched: make([]*bridgeTransfer, 0),
+	}
+
+	inWindow := func(t *bridgeTransfer) bool {
+		return !t.Time.Before(from) && t.Time.Before(to)
+	}
+
+	// Transfers yet to be matched, keyed by kind & key, in order they were found
+	pending := make(map[string][]*bridgeTransfer)
+	matched := make(map[*bridgeTransfer]bool)
+
+	counterpart := map[string]string{
+		depositTransfer: mintTransfer,
+		mintTransfer:    depositTransfer,
+		burnTransfer:    exitTransfer,
+		exitTransfer:    burnTransfer,
+	}
+
+	sort.SliceStable(transfers, func(i, j int) bool {
+		return transfers[i].Time.Before(transfers[j].Time)
+	})
+
+	totals := make(map[string]*tokenTotals)
+
+	for _, v := range transfers {
+		key := strings.Join([]string{counterpart[v.Kind], v.key()}, "|")
+		if candidates := pending[key]; len(candidates) > 0 {
+			matched[v], matched[candidates[0]] = true, true
+			pending[key] = candidates[1:]
+		} else {
+			own := strings.Join([]string{v.Kind, v.key()}, "|")
+			pending[own] = append(pending[own], v)
+		}
+
+		if !inWindow(v) {
+			continue
+		}
+
+		id := strings.Join([]string{v.Bridge, v.Token.Hex()}, "/")
+
+		total, ok := totals[id]
+		if !ok {
+			total = &tokenTotals{Bridge: v.Bridge, RootToken: v.Token, Type: v.Type, Deposited: "0", Minted: "0", Burnt: "0", Exited: "0"}
+			if mapping := registry.root(v.Bridge, v.Token); mapping != nil {
+				total.ChildToken, total.Symbol = common.HexToAddress(mapping.ChildToken), mapping.Symbol
+			}
+
+			totals[id] = total
+			report.Tokens = append(report.Tokens, total)
+		}
+
+		switch v.Kind {
+		case depositTransfer:
+			total.Deposited = addAmount(total.Deposited, v.Amount)
+		case mintTransfer:
+			total.Minted = addAmount(total.Minted, v.Amount)
+		case burnTransfer:
+			total.Burnt = addAmount(total.Burnt, v.Amount)
+		case exitTransfer:
+			total.Exited = addAmount(total.Exited, v.Amount)
+		}
+	}
+
+	for _, v := range report.Tokens {
+		v.DepositGap, v.WithdrawGap = subAmount(v.Deposited, v.Minted), subAmount(v.Burnt, v.Exited)
+	}
+
+	sort.Slice(report.Tokens, func(i, j int) bool {
+		if report.Tokens[i].Bridge != report.Tokens[j].Bridge {
+			return report.Tokens[i].Bridge < report.Tokens[j].Bridge
+		}
+
+		return report.Tokens[i].RootToken.Hex() < report.Tokens[j].RootToken.Hex()
+	})
+
+	for _, v := range transfers {
+		if inWindow(v) && !matched[v] {
+			report.Unmatched = append(report.Unmatched, v)
+		}
+	}
+
+	return report
+}
+
+// Reconciles transfers across bridge in `[from, to)`, totalling deposits & withdraws
+// per token, from logs emitted on root & child chain, where root side locks are matched
+// against child side mints & burns against exits
+//
+// Counterparts are looked for upto `reconcileGrace` before/ after window, so transfers
+// reported as unmatched are either stuck or their counterparts are further away, e.g.
+// Plasma exits, which happen after challenge period
+func reconcile(ctx context.Context, rootClient chainReader, childClient chainReader, mappings []TokenMapping, contracts *bridgeContracts, from time.Time, to time.Time) (*reconciliationReport, error) {
+	registry := newTokenRegistry(mappings)
+
+	transfers, _, _, err := collectTransfers(ctx, rootClient, childClient, registry, contracts, from.Add(-reconcileGrace), to.Add(reconcileGrace))
+	if err != nil {
+		return nil, err
+	}
+
+	report := buildReport(transfers, registry, from, to)
+
+	// Blocks of window itself are reported, not of wider range
+	if report.RootBlocks[0], report.RootBlocks[1], _, err = blockRange(ctx, rootClient, from, to); err != nil {
+		return nil, err
+	}
+	if report.ChildBlocks[0], report.ChildBlocks[1], _, err = blockRange(ctx, childClient, from, to); err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+// Name, files of report for window are written with
+func reportName(from time.Time, to time.Time) string {
+	return fmt.Sprintf("reconciliation-%s-%s", from.UTC().Format("20060102T150405Z"), to.UTC().Format("20060102T150405Z"))
+}
+
+// Writes report in given directory, as JSON & CSV, where CSV is written in two files,
+// one with totals per token & another with unmatched transfers
+func writeReport(dir string, report *reconciliationReport) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	name := filepath.Join(dir, reportName(report.From, report.To))
+
+	totals := [][]string{{"bridge", "rootToken", "childToken", "type", "symbol", "deposited", "minted", "depositGap", "burnt", "exited", "withdrawGap"}}
+	for _, v := range report.Tokens {
+		totals = append(totals, []string{v.Bridge, v.RootToken.Hex(), v.ChildToken.Hex(), v.Type, v.Symbol, v.Deposited, v.Minted, v.DepositGap, v.Burnt, v.Exited, v.WithdrawGap})
+	}
+
+	unmatched := [][]string{{"kind", "bridge", "token", "type", "account", "tokenId", "amount", "txHash", "block", "logIndex", "time"}}
+	for _, v := range report.Unmatched {
+		unmatched = append(unmatched, []string{v.Kind, v.Bridge, v.Token.Hex(), v.Type, v.Account.Hex(), v.TokenID, v.Amount, v.TxHash.Hex(), fmt.Sprintf("%d", v.Block), fmt.Sprintf("%d", v.LogIndex), v.Time.Format(time.RFC3339)})
+	}
+
+	if err := writeCSV(name+".csv", totals); err != nil {
+		return err
+	}
+	if err := writeCSV(name+"-unmatched.csv", unmatched); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	// JSON is written last, as its presence denotes report is complete
+	return writeFile(name+".json", data)
+}
+
+// Writes rows to CSV file
+func writeCSV(file string, rows [][]string) error {
+	builder := &strings.Builder{}
+
+	writer := csv.NewWriter(builder)
+	if err := writer.WriteAll(rows); err != nil {
+		return err
+	}
+
+	return writeFile(file, []byte(builder.String()))
+}
+
+// Writes file atomically, so that partially written one is never found
+func writeFile(file string, data []byte) error {
+	tmp := file + ".tmp"
+
+	if err := ioutil.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, file)
+}
+
+// Reconciles given window & writes report in given directory, using token mappings
+// present in registry
+func reconcileWindow(ctx context.Context, rootClient *ethclient.Client, childClient *ethclient.Client, db *gorm.DB, from time.Time, to time.Time, dir string) (*reconciliationReport, error) {
+	conf := getConfig()
+
+	var mappings []TokenMapping
+	if err := db.WithContext(ctx).Find(&mappings).Error; err != nil {
+		return nil, err
+	}
+	if len(mappings) == 0 {
+		appLog.Ctx(ctx).Warn("Token registry is empty, nothing to reconcile", logger.Fields{"from": from, "to": to})
+	}
+
+	report, err := reconcile(ctx, rootClient, childClient, mappings, conf.bridgeContracts(), from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := writeReport(dir, report); err != nil {
+		return nil, err
+	}
+
+	appLog.Ctx(ctx).Info("Wrote reconciliation report", logger.Fields{"from": from, "to": to, "tokens": len(report.Tokens), "unmatched": len(report.Unmatched), "dir": dir})
+
+	return report, nil
+}
+
+// Starts reconciling every `ReconcileInterval`, in a different thread of execution,
+// returning function to be invoked for stopping it, during shutdown
+//
+// Windows are aligned to interval, each being reconciled once `reconcileGrace` has
+// passed since it ended, where windows already reported on are skipped, so that
+// restarting doesn't write them again
+func startReconciliation(rootClient *ethclient.Client, childClient *ethclient.Client, db *gorm.DB) func() {
+	conf := getConfig()
+
+	interval := conf.ReconcileInterval
+	if interval == 0 {
+		return func() {}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		for {
+			to := time.Now().UTC().Add(-reconcileGrace).Truncate(interval)
+			from := to.Add(-interval)
+
+			wait := time.Until(to.Add(interval + reconcileGrace))
+
+			if _, err := os.Stat(filepath.Join(conf.ReportDir, reportName(from, to)+".json")); os.IsNotExist(err) {
+				if _, err := reconcileWindow(ctx, rootClient, childClient, db, from, to, conf.ReportDir); err != nil && ctx.Err() == nil {
+					appLog.Error("Failed to reconcile", logger.Fields{"error": err, "from": from, "to": to})
+					wait = reconcileRetryInterval
+				}
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(wait):
+			}
+		}
+	}()
+
+	return func() {
+		cancel()
+		<-done
+	}
+}
+
+// Usage of `reconcile` admin command
+const reconcileUsage = `Usage : bridge-api reconcile -from <time> -to <time> [-dir <dir>]
+
+Times are in RFC3339 form e.g. 2020-11-01T00:00:00Z, while report is written in
+` + "`ReportDir`" + `, unless -dir is given
+`
+
+// RunReconcile - Admin command for reconciling transfers across bridge in given
+// window, writing report, given path to config file & arguments following `reconcile`
+func RunReconcile(file string, args []string) {
+	if err := params.Read(file, nil); err != nil {
+		appLog.Fatal("Failed to read config", logger.Fields{"error": err, "file": file})
+	}
+
+	c, err := loadConfig(false)
+	if err != nil {
+		appLog.Fatal("Bad config", logger.Fields{"error": err})
+	}
+	setConfig(c)
+
+	setUpLogging()
+
+	flags := flag.NewFlagSet("reconcile", flag.ExitOnError)
+	flags.Usage = func() {
+		fmt.Fprint(os.Stderr, reconcileUsage)
+	}
+
+	from := flags.String("from", "", "Start of window, inclusive")
+	to := flags.String("to", "", "End of window, exclusive")
+	dir := flags.String("dir", c.ReportDir, "Directory, report is written in")
+
+	flags.Parse(args)
+
+	_from, err := time.Parse(time.RFC3339, *from)
+	if err != nil {
+		fmt.Fprint(os.Stderr, reconcileUsage)
+		os.Exit(2)
+	}
+	_to, err := time.Parse(time.RFC3339, *to)
+	if err != nil || !_to.After(_from) {
+		fmt.Fprint(os.Stderr, reconcileUsage)
+		os.Exit(2)
+	}
+
+	rootClient, err := getClient(true)
+	if err != nil {
+		appLog.Fatal("Failed to connect to root chain", logger.Fields{"error": err})
+	}
+	defer rootClient.Close()
+
+	childClient, err := getClient(false)
+	if err != nil {
+		appLog.Fatal("Failed to connect to child chain", logger.Fields{"error": err})
+	}
+	defer childClient.Close()
+
+	nameChain(rootClient, "root")
+	nameChain(childClient, "child")
+
+	db := connectToDB()
+	defer closeDB(db)
+
+	migrateDB(db)
+
+	report, err := reconcileWindow(context.Background(), rootClient, childClient, db, _from.UTC(), _to.UTC(), *dir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error : %s\n", err.Error())
+		os.Exit(1)
+	}
+
+	fmt.Printf("Reconciled %d token(s), %d unmatched transfer(s), report written to %s\n", len(report.Tokens), len(report.Unmatched), filepath.Join(*dir, reportName(report.From, report.To)+".{json,csv}"))
+}
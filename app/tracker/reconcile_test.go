@@ -0,0 +1,136 @@
+package tracker
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/backends"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/maticnetwork/deposits-withdrawals-tracking/app/client"
+)
+
+// Creation code of contract, which emits log with topics & data it's called with, where
+// calldata is number of topics i.e. 3 or 4, followed by 4 topics & then data
+var emitterCode = hexutil.MustDecode("0x603380600b6000396000f3" +
+	"60a0360380" + "60a0600037" + "600035600314602457" +
+	"608035606035604035602035846000a400" +
+	"5b606035604035602035836000a300")
+
+// Simulated chain, along with funded account, txs are sent from
+type simulatedChain struct {
+	*backends.SimulatedBackend
+	key   *ecdsa.PrivateKey
+	nonce uint64
+}
+
+func newSimulatedChain(t *testing.T) *simulatedChain {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	backend := backends.NewSimulatedBackend(core.GenesisAlloc{
+		crypto.PubkeyToAddress(key.PublicKey): {Balance: new(big.Int).Lsh(big.NewInt(1), 100)},
+	}, 8000000)
+
+	return &simulatedChain{SimulatedBackend: backend, key: key}
+}
+
+// Sends tx & mines it
+func (s *simulatedChain) send(t *testing.T, tx *types.Transaction) {
+	signed, err := types.SignTx(tx, types.HomesteadSigner{}, s.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.SendTransaction(context.Background(), signed); err != nil {
+		t.Fatal(err)
+	}
+	s.Commit()
+	s.nonce++
+}
+
+// Deploys log emitting contract, returning its address
+func (s *simulatedChain) deployEmitter(t *testing.T) common.Address {
+	address := crypto.CreateAddress(crypto.PubkeyToAddress(s.key.PublicKey), s.nonce)
+	s.send(t, types.NewContractCreation(s.nonce, big.NewInt(0), 1000000, big.NewInt(1), emitterCode))
+
+	return address
+}
+
+// Makes contract emit log with given topics & data
+func (s *simulatedChain) emit(t *testing.T, emitter common.Address, data []byte, topics ...common.Hash) {
+	calldata := common.LeftPadBytes(big.NewInt(int64(len(topics))).Bytes(), 32)
+	for i := 0; i < 4; i++ {
+		topic := common.Hash{}
+		if i < len(topics) {
+			topic = topics[i]
+		}
+
+		calldata = append(calldata, topic.Bytes()...)
+	}
+
+	s.send(t, types.NewTransaction(s.nonce, emitter, big.NewInt(0), 1000000, big.NewInt(1), append(calldata, data...)))
+}
+
+func TestCollectTransfersOfBridgeContracts(t *testing.T) {
+	root, child := newSimulatedChain(t), newSimulatedChain(t)
+	defer root.Close()
+	defer child.Close()
+
+	predicate, forged, depositManager := root.deployEmitter(t), root.deployEmitter(t), root.deployEmitter(t)
+
+	token := common.HexToAddress("0x0000000000000000000000000000000000000a01")
+	plasmaToken := common.HexToAddress("0x0000000000000000000000000000000000000b01")
+	account := common.HexToAddress("0x0000000000000000000000000000000000000c01")
+
+	amount := common.LeftPadBytes(big.NewInt(100).Bytes(), 32)
+
+	// Same event is emitted by predicate & by contract, which is not one of bridge contracts
+	root.emit(t, predicate, amount, lockedERC20Topics[0], account.Hash(), account.Hash(), token.Hash())
+	root.emit(t, forged, amount, lockedERC20Topics[0], account.Hash(), account.Hash(), token.Hash())
+	root.emit(t, forged, append(amount, amount...), newDepositBlockTopic, account.Hash(), plasmaToken.Hash())
+	root.emit(t, depositManager, append(amount, amount...), newDepositBlockTopic, account.Hash(), plasmaToken.Hash())
+	// Other event of predicate is not one of transfers
+	root.emit(t, predicate, amount, stateSyncedTopic, account.Hash(), account.Hash())
+
+	registry := newTokenRegistry([]TokenMapping{
+		{RootToken: token.Hex(), Bridge: client.POSBridge, ChildToken: "0x0000000000000000000000000000000000000a02", Type: client.ERC20Token},
+		{RootToken: plasmaToken.Hex(), Bridge: client.PlasmaBridge, ChildToken: "0x0000000000000000000000000000000000000b02", Type: client.ERC20Token},
+	})
+
+	contracts := &bridgeContracts{Predicates: []common.Address{predicate}, DepositManager: depositManager}
+
+	ctx := context.Background()
+
+	transfers, _, _, err := collectTransfers(ctx, root, child, registry, contracts, time.Unix(0, 0), time.Unix(1<<32, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(transfers) != 2 {
+		t.Fatalf("expected only transfers emitted by bridge contracts, found %d", len(transfers))
+	}
+
+	for _, v := range transfers {
+		if v.Kind != depositTransfer || v.Account != account || v.Amount != "100" {
+			t.Fatalf("expected deposit of 100 for %s, found %+v", account.Hex(), v)
+		}
+	}
+
+	if transfers[0].Bridge != client.POSBridge || transfers[0].Token != token || transfers[1].Bridge != client.PlasmaBridge || transfers[1].Token != plasmaToken {
+		t.Fatalf("expected POS deposit followed by Plasma one, found %+v, %+v", transfers[0], transfers[1])
+	}
+
+	// Without any bridge contract, no log can be trusted
+	if _, _, _, err := collectTransfers(ctx, root, child, registry, &bridgeContracts{}, time.Unix(0, 0), time.Unix(1<<32, 0)); err == nil {
+		t.Fatal("expected collecting transfers without bridge contracts to fail")
+	}
+}
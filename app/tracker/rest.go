@@ -2,62 +2,47 @@ package tracker
 
 import (
 	"app/nft"
-	"log"
+	"app/tracing"
+	"context"
+	"fmt"
+	"logger"
 	"regexp"
 	"strconv"
 	"strings"
 	"sync"
 
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/gin-contrib/cors"
+	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
-// Minimum & Maximum payload size i.e. these many tx hash can be sent a time & asked to
-// find out their status in POST call
-//
-// Being read from .env file
-func getPayloadSize() (int, int) {
-	min := get("MinPayloadSize")
-
-	_min, err := strconv.ParseUint(min, 10, 32)
-	if err != nil {
-		return 1, 10
-	}
-
-	max := get("MaxPayloadSize")
-
-	_max, err := strconv.ParseUint(max, 10, 32)
-	if err != nil {
-		return 1, 10
-	}
-
-	return int(_min), int(_max)
-}
-
 // Run - Deposit & withdraw flow tracker service's main power horse
 // which exposes some REST API(s) to be used for tracking whole deposit & withdraw life cycle
 // for both Plasma & PoS bridges
-func Run(file string) {
-	err := read(file)
-	if err != nil {
-		log.Fatalln("[!] ", err)
-	}
+//
+// Configuration is read from given file, environment variables & command line arguments,
+// which must be good, otherwise service refuses to start, logging problems found in it
+func Run(file string, args []string) {
+	setUpConfig(file, args)
+	setUpLogging()
+
+	conf := getConfig()
 
-	// reading payload size specified in .env file
-	min, max := getPayloadSize()
+	// Exporting spans to OpenTelemetry collector, if `OTLPEndpoint` is set
+	tracing.Configure(conf.OTLPEndpoint, "bridge-api")
 
 	rootClient, err := getClient(true)
 	if err != nil {
-		log.Fatalln("[!] ", err)
+		appLog.Fatal("Failed to connect to root chain", logger.Fields{"error": err})
 	}
 	childClient, err := getClient(false)
 	if err != nil {
-		log.Fatalln("[!] ", err)
+		appLog.Fatal("Failed to connect to child chain", logger.Fields{"error": err})
 	}
-	_nft, err := nft.NewNft(common.HexToAddress(get("ExitNFT")), rootClient)
+	_nft, err := nft.NewNft(conf.ExitNFT, rootClient)
 	if err != nil {
-		log.Fatalln("[!] ", err)
+		appLog.Fatal("Failed to get instance of ExitNFT", logger.Fields{"error": err})
 	}
 
 	// connecting to database, to be used for persisting status of transactions ( using txHash )
@@ -65,371 +50,161 @@ func Run(file string) {
 	// Performing auto migration
 	migrateDB(db)
 
-	router := gin.Default()
-
-	// Allowing requests from all origins
-	router.Use(cors.Default())
-
-	v1 := router.Group("/v1")
+	router := NewRouter(rootClient, childClient, db, _nft)
 
-	{
+	// Either starting workers in this process or preparing to talk
+	// to them over HTTP, depending upon `WorkerMode`
+	stopWorkers := setUpWorkers(router)
 
-		// Given a non-empty set of approval tx hashes, returns status for each of them
-		v1.POST("/approval", func(c *gin.Context) {
-			var bulkPayload BulkPayload
+	// Keeping token registry up to date, when contracts emitting
+	// `TokenMapped` events are set
+	stopTokenIndexing := startTokenIndexing(rootClient, db)
 
-			if err := c.ShouldBindJSON(&bulkPayload); err != nil {
-				c.JSON(400, gin.H{
-					"msg": "Bad Payload",
-				})
-				return
-			}
+	// Writing reconciliation reports of transfers across bridge, when
+	// `ReconcileInterval` is set
+	stopReconciliation := startReconciliation(rootClient, childClient, db)
 
-			// Expecting at least 1 txHash
-			if len(bulkPayload.TransactionHashes) < min {
-				c.JSON(400, gin.H{
-					"msg": "Empty Payload",
-				})
-				return
-			}
+	// Scanning persisted statuses for stuck transfers & alerting about
+	// them, when `StuckScanInterval` is set
+	stopStuckScanner := startStuckScanner(rootClient, childClient, db, _nft)
 
-			// If more than 10 tx hashes are asked to be tracked
-			// we're simply going to not take this request up
-			if len(bulkPayload.TransactionHashes) > max {
-				c.JSON(400, gin.H{
-					"msg": "Heavy Payload",
-				})
-				return
-			}
-
-			_statuses := make(map[common.Hash]*TransactionState)
-
-			mutex := sync.Mutex{}
-			var wg sync.WaitGroup
+	// Usage of API keys is accumulated in memory & written to DB periodically
+	stopUsageAccounting := startUsageAccounting(db)
 
-			// Starting `n (<=10)` workers concurrently, who are going to find out status
-			// of a specific txHash, wait until all workers complete
-			// and then return json response
-			for _, h := range bulkPayload.unique() {
+	// Warning about routes, which frontend teams won't
+	// know about, from OpenAPI document
+	for _, v := range checkRouteDocs(router) {
+		appLog.Warn("Route not described in OpenAPI document", logger.Fields{"route": v})
+	}
 
-				wg.Add(1)
-				go func(h common.Hash) {
+	// Once in-flight requests & background work are drained, workers are stopped,
+	// buffered usage & spans are written out, before closing connections
+	serve(router, func() {
+		stopStuckScanner()
+		stopReconciliation()
+		stopTokenIndexing()
+		stopWorkers()
+
+		stopUsageAccounting()
+		tracing.Shutdown()
+
+		rootClient.Close()
+		childClient.Close()
+		closeDB(db)
+	})
+}
 
-					_tmp := getApprovalStatus(rootClient, db, h)
+// NewRouter - Creates router with all status checking endpoints registered,
+// given already connected clients & database
+//
+// Configuration must be read before invoking this function
+func NewRouter(rootClient *ethclient.Client, childClient *ethclient.Client, db *gorm.DB, _nft *nft.Nft) *gin.Engine {
+	// Picking up configuration, as read, where problems are only warned about,
+	// as `Run` would've already refused to start with them
+	conf, err := loadConfig(false)
+	if err != nil {
+		appLog.Warn("Bad config", logger.Fields{"error": err})
+	}
+	setConfig(conf)
 
-					mutex.Lock()
-					_statuses[h] = _tmp
-					mutex.Unlock()
+	// At max these many tx(s) are looked up at a time, across all requests
+	setUpPool(conf.MaxConcurrency)
 
-					wg.Done()
-				}(h)
+	// Statuses, locks & rate limiting counters are shared by all replicas, when
+	// `RedisURL` is set, otherwise they're kept in memory
+	if err := setUpSharedCache(conf.RedisURL); err != nil {
+		appLog.Fatal("Failed to set up shared cache", logger.Fields{"error": err})
+	}
 
-			}
-			wg.Wait()
+	// Workers running as separate micro services are talked to over HTTP,
+	// while `Run` starts them in-process, when running `all-in-one`
+	connectToWorkers(conf)
 
-			c.JSON(200, gin.H{
-				"approvalTxStatus": _statuses,
-				"action":           checkIfApprovalTxInProgress(_statuses),
-				"count":            countOfPendingApprovalTx(_statuses),
-			})
-		})
+	router := gin.New()
+	router.Use(gin.Recovery())
 
-		// Given a non-empty set of `depositFor`/ `depositEtherFor` tx hashes ( on root chain )
-		// it can respond with their current statuses
-		v1.POST("/deposit", func(c *gin.Context) {
-			var bulkPayload BulkPayload
+	// Assigning request ID to each request, which is also forwarded to
+	// workers, while logging request once served
+	router.Use(logger.Middleware(httpLog))
 
-			if err := c.ShouldBindJSON(&bulkPayload); err != nil {
-				c.JSON(400, gin.H{
-					"msg": "Bad Payload",
-				})
-				return
-			}
+	// Span for each request, under which all status resolution
+	// stages get traced
+	router.Use(tracing.Middleware())
 
-			// Expecting at least 1 txHash
-			if len(bulkPayload.TransactionHashes) < min {
-				c.JSON(400, gin.H{
-					"msg": "Empty Payload",
-				})
-				return
-			}
+	// Allowing requests from all origins, unless API key is required, in that
+	// case, only from those allowed by API keys
+	router.Use(corsMiddleware(db))
+	router.Use(requestMetrics())
 
-			// If more than 10 tx hashes are asked to be tracked
-			// we're simply going to not take this request up
-			if len(bulkPayload.TransactionHashes) > max {
-				c.JSON(400, gin.H{
-					"msg": "Heavy Payload",
-				})
-				return
-			}
+	// Status of tx(s), which couldn't be found out within `RequestTimeout`
+	// seconds, is responded with as timed out
+	router.Use(requestDeadline())
 
-			_statuses := make(map[common.Hash]*TransactionState)
+	// So that RPC calls can be attributed to respective chain
+	nameChain(rootClient, "root")
+	nameChain(childClient, "child")
 
-			mutex := sync.Mutex{}
-			var wg sync.WaitGroup
+	// Exposing collected metrics, to be scraped by Prometheus
+	router.GET("/metrics", metricsHandler())
 
-			// Starting `n (<=10)` workers concurrently, who are going to find out status
-			// of a specific txHash, wait until all workers complete
-			// and then return json response
-			for _, h := range bulkPayload.unique() {
+	// Health of RPC nodes, DB & workers, this service depends on
+	router.GET("/health", healthHandler(rootClient, childClient, db))
 
-				wg.Add(1)
-				go func(h common.Hash) {
+	// Generated from `routeDocs`, to be used by clients for
+	// knowing request & response schemas
+	router.GET("/openapi.json", openAPIHandler())
 
-					_tmp := getDepositStatus(rootClient, db, h)
+	// Inspecting & correcting persisted statuses, enabled only
+	// when `AdminToken` is set
+	registerAdminRoutes(router, rootClient, childClient, db, _nft)
+	registerStuckRoutes(router, db)
 
-					mutex.Lock()
-					_statuses[h] = _tmp
-					mutex.Unlock()
+	v1 := router.Group("/v1", authenticate(db), rateLimit())
 
-					wg.Done()
-				}(h)
+	{
 
-			}
-			wg.Wait()
+		// Given a non-empty set of approval tx hashes, returns status for each of them
+		v1.POST("/approval", bulkHandler(func(ctx context.Context, h common.Hash) *TransactionState {
+			return getApprovalStatus(ctx, rootClient, db, h)
+		}, approvalEnvelope))
 
-			c.JSON(200, gin.H{
-				"depositTxStatus": _statuses,
-				"action":          checkIfDepositTxInProgress(_statuses),
-				"count":           countOfPendingDepositTx(_statuses),
-			})
-		})
+		// Given a non-empty set of `depositFor`/ `depositEtherFor` tx hashes ( on root chain )
+		// it can respond with their current statuses
+		v1.POST("/deposit", bulkHandler(withTransfers(rootClient, db, func(ctx context.Context, h common.Hash) *TransactionState {
+			return getDepositStatus(ctx, rootClient, db, h)
+		}, depositedTransfers), depositEnvelope))
 
 		// Given a non-empty set of burn tx hashes on child chain, it can track
 		// all of their respective status & returns same
 		//
 		// Note : Please stop using /v1/pos-withdraw for tracking pos withdraw tx status
 		// to be replaced by this one, in near future
-		v1.POST("/pos-burn", func(c *gin.Context) {
-			var bulkPayload BulkPayload
-
-			if err := c.ShouldBindJSON(&bulkPayload); err != nil {
-				c.JSON(400, gin.H{
-					"msg": "Bad Payload",
-				})
-				return
-			}
-
-			// Expecting at least 1 txHash
-			if len(bulkPayload.TransactionHashes) < min {
-				c.JSON(400, gin.H{
-					"msg": "Empty Payload",
-				})
-				return
-			}
-
-			// If more than 10 tx hashes are asked to be tracked
-			// we're simply going to not take this request up
-			if len(bulkPayload.TransactionHashes) > max {
-				c.JSON(400, gin.H{
-					"msg": "Heavy Payload",
-				})
-				return
-			}
-
-			_statuses := gin.H{}
-
-			mutex := sync.Mutex{}
-			var wg sync.WaitGroup
-
-			// Starting `n (<=10)` workers concurrently, who are going to find out status
-			// of a specific txHash, wait until all workers complete
-			// and then return json response
-			for _, h := range bulkPayload.unique() {
-
-				wg.Add(1)
-				go func(h common.Hash) {
-
-					_tmp := getPOSBurnStatus(childClient, db, h)
-
-					mutex.Lock()
-					_statuses[h.Hex()] = _tmp
-					mutex.Unlock()
-
-					wg.Done()
-				}(h)
-
-			}
-			wg.Wait()
-
-			c.JSON(200, _statuses)
-		})
+		v1.POST("/pos-burn", bulkHandler(withTransfers(childClient, db, func(ctx context.Context, h common.Hash) *TransactionState {
+			return getPOSBurnStatus(ctx, childClient, db, h)
+		}, burntTransfers), plainEnvelope))
 
 		// Given a non-empty set of burn tx hashes on child chain, it can track
 		// all of their respective status & returns same
 		//
 		// @todo To be removed in near future, please consider using `/v1/pos-burn` instead of this one
-		v1.POST("/pos-withdraw", func(c *gin.Context) {
-			var bulkPayload BulkPayload
-
-			if err := c.ShouldBindJSON(&bulkPayload); err != nil {
-				c.JSON(400, gin.H{
-					"msg": "Bad Payload",
-				})
-				return
-			}
-
-			// Expecting at least 1 txHash
-			if len(bulkPayload.TransactionHashes) < min {
-				c.JSON(400, gin.H{
-					"msg": "Empty Payload",
-				})
-				return
-			}
-
-			// If more than 10 tx hashes are asked to be tracked
-			// we're simply going to not take this request up
-			if len(bulkPayload.TransactionHashes) > max {
-				c.JSON(400, gin.H{
-					"msg": "Heavy Payload",
-				})
-				return
-			}
-
-			_statuses := gin.H{}
-
-			mutex := sync.Mutex{}
-			var wg sync.WaitGroup
-
-			// Starting `n (<=10)` workers concurrently, who are going to find out status
-			// of a specific txHash, wait until all workers complete
-			// and then return json response
-			for _, h := range bulkPayload.unique() {
-
-				wg.Add(1)
-				go func(h common.Hash) {
-
-					_tmp := getPOSBurnStatus(childClient, db, h)
-
-					mutex.Lock()
-					_statuses[h.Hex()] = _tmp
-					mutex.Unlock()
-
-					wg.Done()
-				}(h)
-
-			}
-			wg.Wait()
-
-			c.JSON(200, _statuses)
-		})
+		v1.POST("/pos-withdraw", bulkHandler(withTransfers(childClient, db, func(ctx context.Context, h common.Hash) *TransactionState {
+			return getPOSBurnStatus(ctx, childClient, db, h)
+		}, burntTransfers), plainEnvelope))
 
 		// Given a non-empty set of exit tx hashes on root chain, it can check their status
 		//
 		// Note: Consider using this endpoint for checking exit tx status on root chain,
 		// /v1/exit to be removed in near future
-		v1.POST("/pos-exit", func(c *gin.Context) {
-			var bulkPayload BulkPayload
-
-			if err := c.ShouldBindJSON(&bulkPayload); err != nil {
-				c.JSON(400, gin.H{
-					"msg": "Bad Payload",
-				})
-				return
-			}
-
-			// Expecting at least 1 txHash
-			if len(bulkPayload.TransactionHashes) < min {
-				c.JSON(400, gin.H{
-					"msg": "Empty Payload",
-				})
-				return
-			}
-
-			// If more than 10 tx hashes are asked to be tracked
-			// we're simply going to not take this request up
-			if len(bulkPayload.TransactionHashes) > max {
-				c.JSON(400, gin.H{
-					"msg": "Heavy Payload",
-				})
-				return
-			}
-
-			_statuses := gin.H{}
-
-			mutex := sync.Mutex{}
-			var wg sync.WaitGroup
-
-			// Starting `n (<=10)` workers concurrently, who are going to find out status
-			// of a specific txHash, wait until all workers complete
-			// and then return json response
-			for _, h := range bulkPayload.unique() {
-
-				wg.Add(1)
-				go func(h common.Hash) {
-
-					_tmp := getPOSExitStatus(rootClient, db, h)
-
-					mutex.Lock()
-					_statuses[h.Hex()] = _tmp
-					mutex.Unlock()
-
-					wg.Done()
-				}(h)
-
-			}
-			wg.Wait()
-
-			c.JSON(200, _statuses)
-		})
+		v1.POST("/pos-exit", bulkHandler(func(ctx context.Context, h common.Hash) *TransactionState {
+			return getPOSExitStatus(ctx, rootClient, db, h)
+		}, plainEnvelope))
 
 		// Given a non-empty set of exit tx hashes on root chain, it can check their status
 		//
 		// @todo To be removed in near future, please consider using `/v1/pos-exit` instead of this one
-		v1.POST("/exit", func(c *gin.Context) {
-			var bulkPayload BulkPayload
-
-			if err := c.ShouldBindJSON(&bulkPayload); err != nil {
-				c.JSON(400, gin.H{
-					"msg": "Bad Payload",
-				})
-				return
-			}
-
-			// Expecting at least 1 txHash
-			if len(bulkPayload.TransactionHashes) < min {
-				c.JSON(400, gin.H{
-					"msg": "Empty Payload",
-				})
-				return
-			}
-
-			// If more than 10 tx hashes are asked to be tracked
-			// we're simply going to not take this request up
-			if len(bulkPayload.TransactionHashes) > max {
-				c.JSON(400, gin.H{
-					"msg": "Heavy Payload",
-				})
-				return
-			}
-
-			_statuses := gin.H{}
-
-			mutex := sync.Mutex{}
-			var wg sync.WaitGroup
-
-			// Starting `n (<=10)` workers concurrently, who are going to find out status
-			// of a specific txHash, wait until all workers complete
-			// and then return json response
-			for _, h := range bulkPayload.unique() {
-
-				wg.Add(1)
-				go func(h common.Hash) {
-
-					_tmp := getPOSExitStatus(rootClient, db, h)
-
-					mutex.Lock()
-					_statuses[h.Hex()] = _tmp
-					mutex.Unlock()
-
-					wg.Done()
-				}(h)
-
-			}
-			wg.Wait()
-
-			c.JSON(200, _statuses)
-		})
+		v1.POST("/exit", bulkHandler(func(ctx context.Context, h common.Hash) *TransactionState {
+			return getPOSExitStatus(ctx, rootClient, db, h)
+		}, plainEnvelope))
 
 		// Given token burn tx hash on child chain, it can track it's state
 		// upto checkpointing state.
@@ -438,60 +213,9 @@ func Run(file string) {
 		// to go for calling `ERC20Predicate.startExitWithBurntTokens(...)`
 		//
 		// Next step to be tracked using `/v1/plasma-confirm` endpoint
-		v1.POST("/plasma-burn", func(c *gin.Context) {
-			var bulkPayload BulkPayload
-
-			if err := c.ShouldBindJSON(&bulkPayload); err != nil {
-				c.JSON(400, gin.H{
-					"msg": "Bad Payload",
-				})
-				return
-			}
-
-			// Expecting at least 1 txHash
-			if len(bulkPayload.TransactionHashes) < min {
-				c.JSON(400, gin.H{
-					"msg": "Empty Payload",
-				})
-				return
-			}
-
-			// If more than 10 tx hashes are asked to be tracked
-			// we're simply going to not take this request up
-			if len(bulkPayload.TransactionHashes) > max {
-				c.JSON(400, gin.H{
-					"msg": "Heavy Payload",
-				})
-				return
-			}
-
-			_statuses := gin.H{}
-
-			mutex := sync.Mutex{}
-			var wg sync.WaitGroup
-
-			// Starting `n (<=10)` workers concurrently, who are going to find out status
-			// of a specific txHash, wait until all workers complete
-			// and then return json response
-			for _, h := range bulkPayload.unique() {
-
-				wg.Add(1)
-				go func(h common.Hash) {
-
-					_tmp := getCheckPointStatus(childClient, db, h)
-
-					mutex.Lock()
-					_statuses[h.Hex()] = _tmp
-					mutex.Unlock()
-
-					wg.Done()
-				}(h)
-
-			}
-			wg.Wait()
-
-			c.JSON(200, _statuses)
-		})
+		v1.POST("/plasma-burn", bulkHandler(withTransfers(childClient, db, func(ctx context.Context, h common.Hash) *TransactionState {
+			return getCheckPointStatus(ctx, childClient, db, h)
+		}, burntTransfers), plainEnvelope))
 
 		// Given a non-empty array of child chain burn tx hashes & root chain
 		// confirm withdraw tx hashes, it can check status of plasma withdraw
@@ -499,229 +223,136 @@ func Run(file string) {
 		v1.POST("/plasma-confirm", func(c *gin.Context) {
 			var plasmaExitBulkPayload PlasmaExitBulkPayload
 
-			if err := c.ShouldBindJSON(&plasmaExitBulkPayload); err != nil {
-				c.JSON(400, gin.H{
-					"msg": "Bad Payload",
-				})
+			if !bindBulkPayload(c, &plasmaExitBulkPayload, func() int { return len(plasmaExitBulkPayload.TransactionHashes) }) {
 				return
 			}
 
-			// Expecting at least 1 txHash
-			if len(plasmaExitBulkPayload.TransactionHashes) < min {
-				c.JSON(400, gin.H{
-					"msg": "Empty Payload",
-				})
-				return
-			}
+			pairs := plasmaExitBulkPayload.Unique()
 
-			// If more than 10 tx hashes are asked to be tracked
-			// we're simply going to not take this request up
-			if len(plasmaExitBulkPayload.TransactionHashes) > max {
-				c.JSON(400, gin.H{
-					"msg": "Heavy Payload",
-				})
-				return
+			// Confirm withdraw tx hashes, against which statuses are to be kept
+			hashes := make([]common.Hash, 0, len(pairs))
+			for _, v := range pairs {
+				hashes = append(hashes, v.ConfirmTxHash)
 			}
 
-			_statuses := gin.H{}
-
-			mutex := sync.Mutex{}
-			var wg sync.WaitGroup
-
-			// Starting `n (<=10)` workers concurrently, who are going to find out status
-			// of a specific txHash, wait until all workers complete
-			// and then return json response
-			for _, v := range plasmaExitBulkPayload.TransactionHashes {
+			// Gin context is not to be touched by workers, which may be
+			// still running after request is responded to
+			route := c.FullPath()
 
-				wg.Add(1)
-				go func(v CheckExitable) {
+			_statuses := resolveStatuses(c.Request.Context(), hashes, func(ctx context.Context, i int) *TransactionState {
+				v := pairs[i]
 
-					_tmp := getPlasmaConfirmStatus(rootClient, db, v.BurnTxHash, v.ConfirmTxHash, _nft)
+				if isEmptyTxHash(v.BurnTxHash) || isEmptyTxHash(v.ConfirmTxHash) {
+					return erroredStatus(fmt.Errorf("empty tx hash"))
+				}
 
-					mutex.Lock()
-					_statuses[v.ConfirmTxHash.Hex()] = _tmp
-					mutex.Unlock()
-
-					wg.Done()
-				}(v)
-
-			}
-			wg.Wait()
+				return cachedStatus(ctx, strings.Join([]string{route, v.BurnTxHash.Hex(), v.ConfirmTxHash.Hex()}, "/"), func(ctx context.Context) *TransactionState {
+					return getPlasmaConfirmStatus(ctx, rootClient, db, v.BurnTxHash, v.ConfirmTxHash, _nft)
+				})
+			})
 
 			c.JSON(200, _statuses)
 		})
 
 		// Given root chain tx hash, obtained after performing `WithdrawManager.processExits(...)`
 		// it'll check their status & return so
-		v1.POST("/plasma-exit", func(c *gin.Context) {
-			var bulkPayload BulkPayload
-
-			if err := c.ShouldBindJSON(&bulkPayload); err != nil {
-				c.JSON(400, gin.H{
-					"msg": "Bad Payload",
-				})
-				return
-			}
-
-			// Expecting at least 1 txHash
-			if len(bulkPayload.TransactionHashes) < min {
-				c.JSON(400, gin.H{
-					"msg": "Empty Payload",
-				})
-				return
-			}
+		v1.POST("/plasma-exit", bulkHandler(func(ctx context.Context, h common.Hash) *TransactionState {
+			return getPlasmaExitStatus(ctx, rootClient, db, h)
+		}, plainEnvelope))
 
-			// If more than 10 tx hashes are asked to be tracked
-			// we're simply going to not take this request up
-			if len(bulkPayload.TransactionHashes) > max {
-				c.JSON(400, gin.H{
-					"msg": "Heavy Payload",
-				})
-				return
-			}
-
-			_statuses := gin.H{}
+	}
 
-			mutex := sync.Mutex{}
-			var wg sync.WaitGroup
+	v2 := router.Group("/v2", authenticate(db), rateLimit())
 
-			// Starting `n (<=10)` workers concurrently, who are going to find out status
-			// of a specific txHash, wait until all workers complete
-			// and then return json response
-			for _, h := range bulkPayload.unique() {
+	{
 
-				wg.Add(1)
-				go func(h common.Hash) {
+		v2.POST("/withdraw", func(c *gin.Context) {
 
-					_tmp := getPlasmaExitStatus(rootClient, db, h)
+			var payload WithdrawTransactions
 
-					mutex.Lock()
-					_statuses[h.Hex()] = _tmp
-					mutex.Unlock()
+			if !bindBulkPayload(c, &payload, func() int { return len(payload.Transactions) }) {
+				return
+			}
 
-					wg.Done()
-				}(h)
+			transactions := payload.Unique()
 
+			// Burn tx hashes, against which statuses are to be kept
+			hashes := make([]common.Hash, 0, len(transactions))
+			for _, v := range transactions {
+				hashes = append(hashes, v.BurnTxHash)
 			}
-			wg.Wait()
 
-			c.JSON(200, _statuses)
-		})
+			// Gin context is not to be touched by workers, which may be
+			// still running after request is responded to
+			route := c.FullPath()
 
-	}
+			// Withdraw txs as resolved i.e. with bridge inferred from burn tx &
+			// confirm/ exit tx hashes, which weren't supplied, discovered
+			resolved := make(map[common.Hash]*WithdrawTransaction, len(transactions))
+			mutex := sync.Mutex{}
 
-	v2 := router.Group("/v2")
+			_states := resolveStatuses(c.Request.Context(), hashes, func(ctx context.Context, i int) *TransactionState {
+				tx := transactions[i]
 
-	{
+				isPOS, _state := resolveBridge(ctx, childClient, db, tx)
+				if _state != nil {
+					return _state
+				}
 
-		v2.POST("/withdraw", func(c *gin.Context) {
+				_tx := discoverTxs(ctx, rootClient, childClient, db, tx, isPOS)
 
-			var payload WithdrawTransactions
+				mutex.Lock()
+				resolved[tx.BurnTxHash] = _tx
+				mutex.Unlock()
 
-			if err := c.ShouldBindJSON(&payload); err != nil {
-				c.JSON(400, gin.H{
-					"msg": "Bad Payload",
-				})
-				return
-			}
+				status := withTransfers(childClient, db, func(ctx context.Context, _ common.Hash) *TransactionState {
+					return getWithdrawTxStatusWithParties(ctx, rootClient, childClient, db, _nft, _tx, isPOS)
+				}, burntTransfers)
 
-			if !(len(payload.Transactions) > 0) {
-				c.JSON(400, gin.H{
-					"msg": "Empty Payload",
+				return cachedStatus(ctx, strings.Join([]string{route, _tx.BurnTxHash.Hex(), _tx.ConfirmWithdrawTxHash.Hex(), _tx.ExitTxHash.Hex(), strconv.FormatBool(isPOS)}, "/"), func(ctx context.Context) *TransactionState {
+					return status(ctx, _tx.BurnTxHash)
 				})
-				return
-			}
+			})
 
-			_statuses := make(map[common.Hash]*WithdrawTransactionStatus)
+			mutex.Lock()
+			defer mutex.Unlock()
 
-			mutex := sync.Mutex{}
-			var wg sync.WaitGroup
-
-			for _, v := range payload.Transactions {
-
-				wg.Add(1)
-				go func(tx *WithdrawTransaction) {
-
-					// --- Function to be used for storing withdraw tx status in shared map
-					storeWithdrawTxStatus := func(state *TransactionState) {
-
-						mutex.Lock()
-						defer mutex.Unlock()
-
-						// Critical section code
-						_statuses[tx.BurnTxHash] = &WithdrawTransactionStatus{
-							Code:    state.Code,
-							Message: state.Message,
-							IsPOS:   tx.IsPOS,
-						}
-
-					}
-					// -- This function deals with critical section of code
-					// which is why its updations are protected using Mutex
-
-					// Scheduling worker done call from now
-					//
-					// to be invoked when returning from this function call stack
-					defer wg.Done()
-
-					// burn hash must be supplied
-					if isEmptyTxHash(tx.BurnTxHash) {
-						return
-					}
-
-					switch tx.IsPOS {
-					case true:
-
-						// If POS exit hash is available, check status using that hash
-						if !isEmptyTxHash(tx.ExitTxHash) {
-							storeWithdrawTxStatus(getPOSExitStatus(rootClient, db, tx.ExitTxHash))
-							return
-						}
-
-						// If only POS burn tx hash is available, try to
-						// check status using that tx hash, whether checkpointed or not
-						//
-						// @note This is being replaced with `getPOSBurnStatus` so that wallet-web
-						// client can obtain whether pos-exited or not status, even when no exit
-						// hash is provided with, only burn hash is what we have
-						//
-						// If you have exit tx hash too, please consider sending in payload along
-						// with burn tx hash
-						//
-						// Converting exit denoting status code to `-10` to match both of
-						// `/v1/pos-exit` & `/v1/plasma-exit`
-						_txStatus := getPOSBurnStatus(childClient, db, tx.BurnTxHash)
-						if _txStatus.Code == -5 {
-							_txStatus.Code = -10
-						}
-
-						storeWithdrawTxStatus(_txStatus)
-
-					case false:
-
-						// If Plasma exit hash is provided with, then check using its status
-						if !isEmptyTxHash(tx.ExitTxHash) {
-							storeWithdrawTxStatus(getReliablePlasmaExitStatus(rootClient, db, tx.BurnTxHash, tx.ConfirmWithdrawTxHash, _nft, tx.ExitTxHash))
-							return
-						}
-
-						// If Plasma confirm withdraw tx hash is given, check using burn tx hash & confirm
-						// withdraw tx hash
-						if !isEmptyTxHash(tx.ConfirmWithdrawTxHash) {
-							storeWithdrawTxStatus(getPlasmaConfirmStatus(rootClient, db, tx.BurnTxHash, tx.ConfirmWithdrawTxHash, _nft))
-							return
-						}
-
-						// If only Plasma burn tx hash is available, try to
-						// check status using that, whether checkpointed or not
-						storeWithdrawTxStatus(getCheckPointStatus(childClient, db, tx.BurnTxHash))
-
-					}
-
-				}(v)
+			_statuses := make(map[common.Hash]*WithdrawTransactionStatus)
 
+			for _, tx := range transactions {
+				state, ok := _states[tx.BurnTxHash]
+				if !ok {
+					continue
+				}
+
+				_status := &WithdrawTransactionStatus{
+					Code:     state.Code,
+					Message:  state.Message,
+					Degraded: state.Degraded,
+					Source:   state.Source,
+					Reason:   state.Reason,
+					Tokens:   state.Tokens,
+					Exit:     state.Exit,
+				}
+
+				// Declared bridge is responded with, when it couldn't be resolved
+				_tx, ok := resolved[tx.BurnTxHash]
+				if !ok {
+					_tx = tx
+				}
+				if _tx.IsPOS != nil {
+					_status.IsPOS = *_tx.IsPOS
+				}
+
+				if _tx.ConfirmWithdrawTxHash != tx.ConfirmWithdrawTxHash {
+					_status.ConfirmWithdrawTxHash = &_tx.ConfirmWithdrawTxHash
+				}
+				if _tx.ExitTxHash != tx.ExitTxHash {
+					_status.ExitTxHash = &_tx.ExitTxHash
+				}
+
+				_statuses[tx.BurnTxHash] = _status
 			}
-			wg.Wait()
 
 			c.JSON(200, gin.H{
 				"withdrawTxStatus": _statuses,
@@ -731,9 +362,74 @@ func Run(file string) {
 
 		})
 
+		// Tokens mapped between root & child chain, as indexed from `TokenMapped`
+		// events, optionally filtered by `bridge` & `type`
+		v2.GET("/tokens", tokensHandler(db))
+
+		// Given token address on either of chains, responds with its counterpart
+		// on other chain, along with type, bridge, decimals & symbol
+		v2.GET("/tokens/:address", tokenHandler(db))
+
 	}
 
-	router.Run(strings.Join([]string{":", get("PORT")}, ""))
+	return router
+}
+
+// Finds out status of withdraw tx, using most advanced tx hash of flow, present in payload,
+// on bridge it's resolved to be of
+//
+// Returns nil when burn tx hash is not supplied
+func getWithdrawTxStatus(ctx context.Context, rootClient *ethclient.Client, childClient *ethclient.Client, db *gorm.DB, _nft *nft.Nft, tx *WithdrawTransaction, isPOS bool) *TransactionState {
+
+	// burn hash must be supplied
+	if isEmptyTxHash(tx.BurnTxHash) {
+		return nil
+	}
+
+	switch isPOS {
+	case true:
+
+		// If POS exit hash is available, check status using that hash
+		if !isEmptyTxHash(tx.ExitTxHash) {
+			return getPOSExitStatus(ctx, rootClient, db, tx.ExitTxHash)
+		}
+
+		// If only POS burn tx hash is available, try to
+		// check status using that tx hash, whether checkpointed or not
+		//
+		// @note This is being replaced with `getPOSBurnStatus` so that wallet-web
+		// client can obtain whether pos-exited or not status, even when no exit
+		// hash is provided with, only burn hash is what we have
+		//
+		// If you have exit tx hash too, please consider sending in payload along
+		// with burn tx hash
+		//
+		// Converting exit denoting status code to `-10` to match both of
+		// `/v1/pos-exit` & `/v1/plasma-exit`
+		_txStatus := getPOSBurnStatus(ctx, childClient, db, tx.BurnTxHash)
+		if _txStatus.Code == -5 {
+			_txStatus.Code = -10
+		}
+
+		return _txStatus
+
+	}
+
+	// If Plasma exit hash is provided with, then check using its status
+	if !isEmptyTxHash(tx.ExitTxHash) {
+		return getReliablePlasmaExitStatus(ctx, rootClient, db, tx.BurnTxHash, tx.ConfirmWithdrawTxHash, _nft, tx.ExitTxHash)
+	}
+
+	// If Plasma confirm withdraw tx hash is given, check using burn tx hash & confirm
+	// withdraw tx hash
+	if !isEmptyTxHash(tx.ConfirmWithdrawTxHash) {
+		return getPlasmaConfirmStatus(ctx, rootClient, db, tx.BurnTxHash, tx.ConfirmWithdrawTxHash, _nft)
+	}
+
+	// If only Plasma burn tx hash is available, try to
+	// check status using that, whether checkpointed or not
+	return getCheckPointStatus(ctx, childClient, db, tx.BurnTxHash)
+
 }
 
 // Calculating what should be higher priority activity for user
@@ -756,7 +452,7 @@ func calculateActionRequiredForWithdraw(statuses map[common.Hash]*WithdrawTransa
 			break
 		}
 
-		if v.Code == -1 || v.Code == -3 || v.Code == -5 || v.Code == -8 || v.Code == -12 {
+		if v.Code == -1 || v.Code == -3 || v.Code == -5 || v.Code == -8 || v.Code == -12 || v.Code == timedOutCode {
 			action = TxInProgress
 		}
 
@@ -772,7 +468,7 @@ func calculateCountOfPendingWithdrawTx(statuses map[common.Hash]*WithdrawTransac
 
 	for _, v := range statuses {
 		switch v.Code {
-		case -1, -3, -4, -5, -8, -9, -12:
+		case -1, -3, -4, -5, -8, -9, -12, timedOutCode:
 			count++
 		}
 	}
@@ -796,6 +492,16 @@ func isEmptyTxHash(hash common.Hash) bool {
 
 }
 
+// Wraps statuses of approval tx(s), along with whether user needs to wait
+// for any of them
+func approvalEnvelope(statuses map[common.Hash]*TransactionState) interface{} {
+	return gin.H{
+		"approvalTxStatus": statuses,
+		"action":           checkIfApprovalTxInProgress(statuses),
+		"count":            countOfPendingApprovalTx(statuses),
+	}
+}
+
 // Given statuses obtained for approval tx(s), it'll check if atleast one of
 // them present in a non-final ( this is a debatable topic ) state or not
 //
@@ -806,7 +512,7 @@ func checkIfApprovalTxInProgress(statuses map[common.Hash]*TransactionState) str
 
 	for _, v := range statuses {
 
-		if v.Code == 7 {
+		if v.Code == 7 || v.Code == timedOutCode {
 			action = "Transaction In Progress"
 			break
 		}
@@ -824,7 +530,7 @@ func countOfPendingApprovalTx(statuses map[common.Hash]*TransactionState) int {
 
 	for _, v := range statuses {
 
-		if v.Code == 7 {
+		if v.Code == 7 || v.Code == timedOutCode {
 			count++
 		}
 
@@ -834,6 +540,16 @@ func countOfPendingApprovalTx(statuses map[common.Hash]*TransactionState) int {
 
 }
 
+// Wraps statuses of deposit tx(s), along with whether user needs to wait
+// for any of them
+func depositEnvelope(statuses map[common.Hash]*TransactionState) interface{} {
+	return gin.H{
+		"depositTxStatus": statuses,
+		"action":          checkIfDepositTxInProgress(statuses),
+		"count":           countOfPendingDepositTx(statuses),
+	}
+}
+
 // Given statuses obtained for deposit tx(s), it'll check if atleast one of
 // them present in a non-final ( this is a debatable topic ) state or not
 //
@@ -844,7 +560,7 @@ func checkIfDepositTxInProgress(statuses map[common.Hash]*TransactionState) stri
 
 	for _, v := range statuses {
 
-		if v.Code == 4 || v.Code == 1 {
+		if v.Code == 4 || v.Code == 1 || v.Code == timedOutCode {
 			action = "Transaction In Progress"
 			break
 		}
@@ -862,7 +578,7 @@ func countOfPendingDepositTx(statuses map[common.Hash]*TransactionState) int {
 
 	for _, v := range statuses {
 
-		if v.Code == 4 || v.Code == 1 {
+		if v.Code == 4 || v.Code == 1 || v.Code == timedOutCode {
 			count++
 		}
 
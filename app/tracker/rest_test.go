@@ -0,0 +1,68 @@
+package tracker
+
+import (
+	"app/internal/testutil"
+	"app/nft"
+	"io/ioutil"
+	"logger"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/viper"
+	"gorm.io/gorm"
+)
+
+// Contract, Plasma withdraw NFTs are minted by, on root chain
+var testExitNFT = common.HexToAddress("0x0000000000000000000000000000000000000e11")
+
+// Router along with stand-ins of chains, workers & DB, it talks to
+type testEnv struct {
+	root    *testutil.Chain
+	child   *testutil.Chain
+	workers *testutil.Workers
+	db      *gorm.DB
+	_db     *testutil.DB
+	nft     *nft.Nft
+	router  *gin.Engine
+}
+
+// Sets up router, talking to stand-ins, where given config params are
+// set on top of those required
+func newTestEnv(t *testing.T, params map[string]interface{}) *testEnv {
+	logger.SetOutput(ioutil.Discard)
+
+	env := &testEnv{
+		root:    testutil.NewChain(),
+		child:   testutil.NewChain(),
+		workers: testutil.NewWorkers(),
+	}
+
+	viper.Reset()
+	viper.Set("RootRPC", "http://localhost:8545")
+	viper.Set("ChildRPC", "http://localhost:8546")
+	viper.Set("CheckPointTracker", env.workers.CheckPointTracker.URL)
+	viper.Set("StateIDManager", env.workers.StateIDManager.URL)
+	viper.Set("POSExitChecker", env.workers.POSExitChecker.URL)
+	viper.Set("ExitNFT", testExitNFT.Hex())
+
+	for k, v := range params {
+		viper.Set(k, v)
+	}
+
+	_nft, err := nft.NewNft(testExitNFT, env.root.Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	env.nft = _nft
+	env.db, env._db = testutil.NewDB()
+	env.router = NewRouter(env.root.Client(), env.child.Client(), env.db, env.nft)
+
+	return env
+}
+
+// Stops serving workers
+func (e *testEnv) close() {
+	e.workers.Close()
+}
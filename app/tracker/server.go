@@ -0,0 +1,86 @@
+package tracker
+
+import (
+	"context"
+	"logger"
+	"sync"
+	"time"
+	"worker-common/server"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Background work is given these long to complete, once in-flight requests
+// are drained, after being asked to shut down
+const shutdownTimeout = time.Second * time.Duration(30)
+
+// Work running in background i.e. beyond request it was started for, e.g.
+// status lookups which couldn't complete before request deadline, which is
+// waited for, before shutting down
+var background sync.WaitGroup
+
+// Runs given function in background, to be waited for, before shutting down
+func inBackground(fn func()) {
+	background.Add(1)
+
+	go func() {
+		defer background.Done()
+		fn()
+	}()
+}
+
+// Waits for background work to complete, unless context gets done before
+// that, returning false in that case
+func waitForBackground(ctx context.Context) bool {
+	done := make(chan struct{})
+	go func() {
+		background.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// Serves given router on `PORT`, until SIGINT/ SIGTERM is received, same as workers,
+// where once in-flight requests are drained, background work is waited for, before
+// `shutdown` is invoked, for releasing resources
+//
+// Configuration is reloaded on SIGHUP or when config file gets modified
+func serve(router *gin.Engine, shutdown func()) {
+	server.Serve(appLog, router, reloadConfig, func() {
+		ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+
+		if !waitForBackground(ctx) {
+			appLog.Warn("Failed to drain background work", logger.Fields{"error": ctx.Err()})
+		}
+
+		shutdown()
+	})
+}
+
+// Reloads configuration from config file, putting it in use only when it's good,
+// where only params marked `Reload` are changed, while rest require restart
+func reloadConfig() {
+	params.Reload(func() {
+		c, _ := loadConfig(true)
+
+		previous := getConfig()
+		setConfig(c)
+
+		if c.MaxConcurrency != previous.MaxConcurrency {
+			setUpPool(c.MaxConcurrency)
+		}
+
+		if c.POSExitChecker != previous.POSExitChecker || c.CheckPointTracker != previous.CheckPointTracker || c.StateIDManager != previous.StateIDManager {
+			connectToWorkers(c)
+		}
+
+		setUpLogging()
+	})
+}
@@ -0,0 +1,259 @@
+package tracker
+
+import (
+	"app/redis"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"logger"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/gin-gonic/gin"
+)
+
+// SharedCache - Store of cached values, locks & counters, which is shared by all replicas
+// of this service when backed by Redis, otherwise kept in memory of this process
+type SharedCache interface {
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	Delete(ctx context.Context, keys ...string) error
+	// Acquires lock, which is held until either released or expired, returning
+	// function to be invoked for releasing it, when acquired
+	Lock(ctx context.Context, key string, ttl time.Duration) (func(), bool, error)
+	// Increments counter, which lives for one window, returning incremented value
+	IncrWindow(ctx context.Context, key string, window time.Duration) (int64, error)
+	Ping(ctx context.Context) error
+}
+
+// Cache shared by replicas, set up during application boot up,
+// depending upon whether `RedisURL` is set or not
+var sharedCache SharedCache = newMemoryCache()
+
+// Sets up shared cache, backed by Redis running at given URL,
+// falling back to in-memory one, when URL is empty
+func setUpSharedCache(url string) error {
+	if url == "" {
+		sharedCache = newMemoryCache()
+		return nil
+	}
+
+	client, err := redis.New(url)
+	if err != nil {
+		return err
+	}
+
+	sharedCache = &redisCache{client: client}
+	return nil
+}
+
+// Whether shared cache is backed by Redis or not
+func sharedCacheIsRedis() bool {
+	_, ok := sharedCache.(*redisCache)
+	return ok
+}
+
+// Random token, identifying holder of lock
+func lockToken() []byte {
+	buffer := make([]byte, 16)
+	rand.Read(buffer)
+
+	return []byte(hex.EncodeToString(buffer))
+}
+
+// redisCache - Shared cache backed by Redis, where all keys are prefixed with `bridge:`
+type redisCache struct {
+	client *redis.Client
+}
+
+func (r *redisCache) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	return r.client.Get(ctx, "bridge:"+key)
+}
+
+func (r *redisCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return r.client.Set(ctx, "bridge:"+key, value, ttl)
+}
+
+func (r *redisCache) Delete(ctx context.Context, keys ...string) error {
+	_keys := make([]string, 0, len(keys))
+	for _, v := range keys {
+		_keys = append(_keys, "bridge:"+v)
+	}
+
+	return r.client.Del(ctx, _keys...)
+}
+
+func (r *redisCache) Lock(ctx context.Context, key string, ttl time.Duration) (func(), bool, error) {
+	token := lockToken()
+
+	ok, err := r.client.SetNX(ctx, "bridge:lock:"+key, token, ttl)
+	if err != nil || !ok {
+		return nil, false, err
+	}
+
+	return func() {
+		// Request context may be already done, but lock still needs to be released
+		_ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+
+		if err := r.client.Release(_ctx, "bridge:lock:"+key, token); err != nil {
+			appLog.Warn("Failed to release lock", logger.Fields{"error": err, "key": key})
+		}
+	}, true, nil
+}
+
+func (r *redisCache) IncrWindow(ctx context.Context, key string, window time.Duration) (int64, error) {
+	return r.client.IncrWindow(ctx, "bridge:"+key, window)
+}
+
+func (r *redisCache) Ping(ctx context.Context) error {
+	return r.client.Ping(ctx)
+}
+
+// memoryCache - Shared cache kept in memory, which is used when Redis is not configured,
+// where locks & counters behave same as Redis backed ones, but only within this process
+type memoryCache struct {
+	values   *ttlCache
+	locks    map[string]time.Time
+	counters map[string]*entry
+	mutex    *sync.Mutex
+}
+
+func newMemoryCache() *memoryCache {
+	return &memoryCache{
+		values:   newTTLCache("", cacheSize),
+		locks:    make(map[string]time.Time),
+		counters: make(map[string]*entry),
+		mutex:    &sync.Mutex{},
+	}
+}
+
+func (m *memoryCache) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	v, ok := m.values.get(key)
+	if !ok {
+		return nil, false, nil
+	}
+
+	return v.([]byte), true, nil
+}
+
+func (m *memoryCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	m.values.set(key, value, ttl)
+	return nil
+}
+
+func (m *memoryCache) Delete(ctx context.Context, keys ...string) error {
+	for _, v := range keys {
+		m.values.delete(v)
+	}
+
+	return nil
+}
+
+func (m *memoryCache) Lock(ctx context.Context, key string, ttl time.Duration) (func(), bool, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	now := time.Now()
+
+	if expires, ok := m.locks[key]; ok && now.Before(expires) {
+		return nil, false, nil
+	}
+
+	expires := now.Add(ttl)
+	m.locks[key] = expires
+
+	return func() {
+		m.mutex.Lock()
+		defer m.mutex.Unlock()
+
+		// Lock expired & acquired by someone else, meanwhile
+		if m.locks[key] == expires {
+			delete(m.locks, key)
+		}
+	}, true, nil
+}
+
+func (m *memoryCache) IncrWindow(ctx context.Context, key string, window time.Duration) (int64, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	now := time.Now()
+
+	counter, ok := m.counters[key]
+	if !ok || now.After(counter.expires) {
+		// Dropping expired counters, so that they don't pile up
+		for k, v := range m.counters {
+			if now.After(v.expires) {
+				delete(m.counters, k)
+			}
+		}
+
+		counter = &entry{value: int64(0), expires: now.Add(window)}
+		m.counters[key] = counter
+	}
+
+	counter.value = counter.value.(int64) + 1
+	return counter.value.(int64), nil
+}
+
+func (m *memoryCache) Ping(ctx context.Context) error {
+	return nil
+}
+
+// Records failure in talking to shared cache, which is not
+// considered fatal, lookup is simply made without cache
+func recordSharedCacheFailure(op string, err error) {
+	metrics.GetOrRegisterCounter(metricName("bridge", "cache", "shared", op, "errors"), registry).Inc(1)
+	appLog.Warn("Failed to talk to shared cache", logger.Fields{"error": err, "op": op})
+}
+
+// Middleware limiting number of requests each client can make per minute, across all
+// replicas when shared cache is backed by Redis, where client is identified by API key,
+// limited as per its own rate limit, if set, otherwise by IP address
+//
+// Requests are let through, when shared cache can't be reached
+func rateLimit() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		client := c.ClientIP()
+		_limit := getConfig().RateLimit
+
+		if apiKey := apiKeyOf(c); apiKey != nil {
+			client = fmt.Sprintf("key-%d", apiKey.ID)
+
+			if apiKey.RateLimit > 0 {
+				_limit = apiKey.RateLimit
+			}
+		}
+
+		if _limit == 0 {
+			c.Next()
+			return
+		}
+
+		window := time.Now().Unix() / 60
+		key := strings.Join([]string{"rate", client, fmt.Sprintf("%d", window)}, ":")
+
+		count, err := sharedCache.IncrWindow(c.Request.Context(), key, time.Minute)
+		if err != nil {
+			recordSharedCacheFailure("incr", err)
+			c.Next()
+			return
+		}
+
+		if count > _limit {
+			metrics.GetOrRegisterCounter("bridge/http/rate_limited", registry).Inc(1)
+
+			c.Header("Retry-After", fmt.Sprintf("%d", (window+1)*60-time.Now().Unix()))
+			c.AbortWithStatusJSON(429, gin.H{
+				"msg": "Too Many Requests",
+			})
+			return
+		}
+
+		c.Next()
+	}
+}
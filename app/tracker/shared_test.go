@@ -0,0 +1,180 @@
+package tracker
+
+import (
+	"app/internal/testutil"
+	"app/redis"
+	"context"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// Sets up shared cache backed by Redis stand-in, returning stand-in along with
+// function to be invoked for falling back to in-memory cache, once done
+func withSharedRedis(t *testing.T) (*testutil.Redis, func()) {
+	server := testutil.NewRedis()
+
+	if err := setUpSharedCache(server.URL()); err != nil {
+		server.Close()
+		t.Fatal(err)
+	}
+
+	return server, func() {
+		setUpSharedCache("")
+		server.Close()
+	}
+}
+
+// Shared cache, as seen by another replica, talking to same Redis
+func replicaCache(t *testing.T, server *testutil.Redis) SharedCache {
+	client, err := redis.New(server.URL())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &redisCache{client: client}
+}
+
+func TestSharedCache(t *testing.T) {
+	server, done := withSharedRedis(t)
+	defer done()
+
+	ctx := context.Background()
+	other := replicaCache(t, server)
+
+	if !sharedCacheIsRedis() {
+		t.Fatal("expected shared cache to be backed by Redis")
+	}
+
+	if err := sharedCache.Set(ctx, "key", []byte("value"), time.Minute); err != nil {
+		t.Fatal(err)
+	}
+
+	if v, ok, err := other.Get(ctx, "key"); err != nil || !ok || string(v) != "value" {
+		t.Fatalf("expected value to be seen by other replica, found %s, %v, %v", v, ok, err)
+	}
+
+	if err := other.Delete(ctx, "key"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok, _ := sharedCache.Get(ctx, "key"); ok {
+		t.Fatal("expected value deleted by other replica to be gone")
+	}
+
+	unlock, ok, err := sharedCache.Lock(ctx, "lock", time.Minute)
+	if err != nil || !ok {
+		t.Fatalf("expected lock to be acquired, found %v, %v", ok, err)
+	}
+
+	if _, ok, _ := other.Lock(ctx, "lock", time.Minute); ok {
+		t.Fatal("expected lock to be held against other replica")
+	}
+
+	unlock()
+
+	if _, ok, _ := other.Lock(ctx, "lock", time.Minute); !ok {
+		t.Fatal("expected released lock to be acquired by other replica")
+	}
+
+	for i := int64(1); i <= 3; i++ {
+		count, err := []SharedCache{sharedCache, other}[i%2].IncrWindow(ctx, "counter", time.Minute)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if count != i {
+			t.Fatalf("expected counter to be %d, found %d", i, count)
+		}
+	}
+}
+
+func TestStatusCoalescedAcrossReplicas(t *testing.T) {
+	server, done := withSharedRedis(t)
+	defer done()
+
+	ctx := context.Background()
+	other := replicaCache(t, server)
+
+	// Other replica is looking up same tx
+	unlock, ok, err := other.Lock(ctx, "status:key", statusLockTTL)
+	if err != nil || !ok {
+		t.Fatalf("expected lock to be acquired, found %v, %v", ok, err)
+	}
+
+	var invoked int32
+	result := make(chan *TransactionState)
+
+	go func() {
+		result <- cachedStatus(ctx, "key", func(ctx context.Context) *TransactionState {
+			atomic.AddInt32(&invoked, 1)
+			return &TransactionState{Code: 1, Message: "Looked Up"}
+		})
+	}()
+
+	// Letting lookup wait on lock, before other replica caches what it found
+	time.Sleep(statusPollInterval * 2)
+
+	if err := other.Set(ctx, "status:key", []byte(`{"code":1,"msg":"Shared"}`), time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	unlock()
+
+	select {
+	case status := <-result:
+		if status.Message != "Shared" {
+			t.Fatalf("expected status found by other replica, found %s", status.Message)
+		}
+	case <-time.After(statusLockTTL):
+		t.Fatal("expected lookup to be served, once other replica cached status")
+	}
+
+	if n := atomic.LoadInt32(&invoked); n != 0 {
+		t.Fatalf("expected status not to be looked up, found %d lookups", n)
+	}
+}
+
+func TestRateLimitAcrossReplicas(t *testing.T) {
+	server := testutil.NewRedis()
+	defer server.Close()
+	defer setUpSharedCache("")
+
+	env := newTestEnv(t, map[string]interface{}{"RedisURL": server.URL(), "RateLimit": 2})
+	defer env.close()
+
+	request := func() int {
+		w := httptest.NewRecorder()
+		env.router.ServeHTTP(w, httptest.NewRequest("POST", "/v1/approval", strings.NewReader("{}")))
+
+		return w.Code
+	}
+
+	// Counter lives for one minute, so its boundary must not be crossed
+	// in between requests
+	for attempt := 0; attempt < 2; attempt++ {
+		window := time.Now().Unix() / 60
+
+		first := request()
+
+		// Next requests land on another replica
+		setUpSharedCache(server.URL())
+
+		second, third := request(), request()
+
+		if time.Now().Unix()/60 != window {
+			continue
+		}
+
+		if first == 429 || second == 429 {
+			t.Fatalf("expected requests within limit to be let through, found %d, %d", first, second)
+		}
+
+		if third != 429 {
+			t.Fatalf("expected request beyond limit to be rejected, found %d", third)
+		}
+
+		return
+	}
+}
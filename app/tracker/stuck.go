@@ -0,0 +1,534 @@
+package tracker
+
+import (
+	"app/nft"
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"logger"
+	"net"
+	"net/http"
+	"net/smtp"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/gin-gonic/gin"
+	"github.com/maticnetwork/deposits-withdrawals-tracking/app/client"
+	"gorm.io/gorm"
+)
+
+// At max these many rows of each status are looked at, in one scan
+const stuckScanLimit = 1000
+
+// Stuck tx, once alerted about, isn't alerted about again for this long
+const stuckRealertAfter = time.Hour * time.Duration(24)
+
+// Each alert is given this long to be delivered
+const alertTimeout = time.Second * time.Duration(10)
+
+// Status, which transfers aren't expected to be held in for long, along with
+// chain, in whose table it's persisted & endpoint, whose status checking
+// function is run once again, before flagging tx as stuck
+type stuckState struct {
+	code     int
+	name     string
+	chain    string
+	endpoint string
+}
+
+// Statuses, which are scanned for stuck transfers
+var stuckStates = []stuckState{
+	{code: 1, name: "en_route", chain: "root", endpoint: client.DepositEndpoint},
+	{code: -3, name: "burnt", chain: "child", endpoint: client.PlasmaBurnEndpoint},
+	{code: -13, name: "exit_not_processed", chain: "root", endpoint: client.PlasmaExitEndpoint},
+}
+
+// Thresholds, beyond which transfers held in respective status are considered
+// stuck, unless overridden using `StuckAfter`
+func defaultStuckAfter() map[int]time.Duration {
+	return map[int]time.Duration{
+		1:   time.Hour,
+		-3:  time.Hour * time.Duration(3),
+		-13: time.Hour,
+	}
+}
+
+// Status of given code, which is scanned for stuck transfers
+func stuckStateOf(code int) (stuckState, bool) {
+	for _, v := range stuckStates {
+		if v.code == code {
+			return v, true
+		}
+	}
+
+	return stuckState{}, false
+}
+
+// Persisted row, as looked up while finding stuck tx(s), from either table
+type stuckRow struct {
+	TransactionHash string    `gorm:"column:txhash"`
+	Code            int       `gorm:"column:code"`
+	Message         string    `gorm:"column:msg"`
+	UpdatedAt       time.Time `gorm:"column:updated_at"`
+}
+
+// Finds persisted statuses, held for longer than their thresholds, of given codes,
+// oldest first, where all stuck-able statuses are looked at, when no code is given
+func findStuckTxs(ctx context.Context, db *gorm.DB, thresholds map[int]time.Duration, codes []int, limit int) ([]*client.StuckTx, error) {
+	now := time.Now().UTC()
+	stuck := make([]*client.StuckTx, 0)
+
+	for _, v := range stuckStates {
+		if len(codes) != 0 && !isOneOfCodes(v.code, codes) {
+			continue
+		}
+
+		threshold, ok := thresholds[v.code]
+		if !ok {
+			continue
+		}
+
+		table := RootChain{}.TableName()
+		if v.chain == "child" {
+			table = ChildChain{}.TableName()
+		}
+
+		var rows []stuckRow
+
+		if err := db.WithContext(ctx).Table(table).Where("code = ? AND updated_at < ?", v.code, now.Add(-threshold)).Order("updated_at").Limit(limit).Find(&rows).Error; err != nil {
+			dbLog.Ctx(ctx).Error("Failed to find stuck txs", logger.Fields{"error": err, "code": v.code})
+			return nil, err
+		}
+
+		for _, row := range rows {
+			stuck = append(stuck, &client.StuckTx{
+				Chain:     v.chain,
+				TxHash:    row.TransactionHash,
+				Code:      row.Code,
+				Message:   row.Message,
+				Since:     row.UpdatedAt,
+				Age:       int64(now.Sub(row.UpdatedAt).Seconds()),
+				Threshold: int64(threshold.Seconds()),
+			})
+		}
+	}
+
+	sort.SliceStable(stuck, func(i, j int) bool {
+		return stuck[i].Since.Before(stuck[j].Since)
+	})
+
+	if len(stuck) > limit {
+		stuck = stuck[:limit]
+	}
+
+	return stuck, nil
+}
+
+// Whether code is one of given ones
+func isOneOfCodes(code int, codes []int) bool {
+	for _, v := range codes {
+		if v == code {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Finds persisted statuses held for too long, finding out status of each of them
+// once again, because it may have moved on without anyone asking for it. Those
+// which are still held in same status, are stuck
+//
+// When status can't be found out reliably now, tx is considered stuck, because
+// that's what is being served to users
+func scanStuckTxs(ctx context.Context, db *gorm.DB, _recheckables map[string]*recheckable) ([]*client.StuckTx, error) {
+	found, err := findStuckTxs(ctx, db, getConfig().StuckAfter, nil, stuckScanLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	hashes := make([]common.Hash, 0, len(found))
+	for _, v := range found {
+		hashes = append(hashes, common.HexToHash(v.TxHash))
+	}
+
+	statuses := resolveStatuses(ctx, hashes, func(ctx context.Context, i int) *TransactionState {
+		state, _ := stuckStateOf(found[i].Code)
+
+		r, ok := _recheckables[state.endpoint]
+		if !ok {
+			return nil
+		}
+
+		_ctx, cancel := context.WithTimeout(ctx, bulkRecheckTimeout)
+		defer cancel()
+
+		return recheckTx(_ctx, db, r, hashes[i], common.Hash{})
+	})
+
+	stuck := make([]*client.StuckTx, 0, len(found))
+	for i, v := range found {
+		if status, ok := statuses[hashes[i]]; ok && !status.Degraded && status.Code != v.Code {
+			continue
+		}
+
+		stuck = append(stuck, v)
+	}
+
+	return stuck, nil
+}
+
+// Records how many tx(s) are stuck in each status, as found in last scan
+func recordStuckTxs(stuck []*client.StuckTx) {
+	counts := make(map[int]int64)
+	for _, v := range stuck {
+		counts[v.Code]++
+	}
+
+	for _, v := range stuckStates {
+		metrics.GetOrRegisterGauge(metricName("bridge", "stuck", v.name), registry).Update(counts[v.code])
+	}
+}
+
+// Key in shared cache, marking stuck tx as alerted about
+func stuckAlertKey(v *client.StuckTx) string {
+	return strings.Join([]string{"stuck-alert", v.Chain, v.TxHash, strconv.Itoa(v.Code)}, ":")
+}
+
+// Sends one alert about stuck tx(s), which haven't been alerted about recently,
+// marking them as alerted about, once delivered
+func alertStuckTxs(ctx context.Context, sink alertSink, stuck []*client.StuckTx) error {
+	fresh := make([]*client.StuckTx, 0, len(stuck))
+
+	for _, v := range stuck {
+		// When shared cache can't be reached, it's better to alert again
+		if _, ok, _ := sharedCache.Get(ctx, stuckAlertKey(v)); ok {
+			continue
+		}
+
+		fresh = append(fresh, v)
+	}
+
+	if len(fresh) == 0 {
+		return nil
+	}
+
+	_ctx, cancel := context.WithTimeout(ctx, alertTimeout)
+	defer cancel()
+
+	if err := sink.send(_ctx, fmt.Sprintf("%d transfer(s) stuck", len(fresh)), fresh); err != nil {
+		return err
+	}
+
+	for _, v := range fresh {
+		if err := sharedCache.Set(ctx, stuckAlertKey(v), []byte{1}, stuckRealertAfter); err != nil {
+			appLog.Ctx(ctx).Warn("Failed to mark stuck tx as alerted", logger.Fields{"error": err, "txHash": v.TxHash})
+		}
+	}
+
+	appLog.Ctx(ctx).Info("Alerted about stuck txs", logger.Fields{"count": len(fresh), "sink": getConfig().AlertSink})
+
+	return nil
+}
+
+// Keeps scanning persisted statuses for stuck transfers every `StuckScanInterval`,
+// in a different thread of execution, alerting about them to `AlertSink`, when set,
+// returning function to be invoked for stopping it
+//
+// Only one replica scans in each interval, when `RedisURL` is set
+func startStuckScanner(rootClient *ethclient.Client, childClient *ethclient.Client, db *gorm.DB, _nft *nft.Nft) func() {
+	interval := getConfig().StuckScanInterval
+	if interval == 0 {
+		return func() {}
+	}
+
+	_recheckables := recheckables(rootClient, childClient, db, _nft)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(interval):
+			}
+
+			// Lock is left to expire, so that other replicas skip this interval,
+			// while it's gone before this one's next scan
+			if _, ok, err := sharedCache.Lock(ctx, "stuck-scan", interval/2); err == nil && !ok {
+				continue
+			}
+
+			stuck, err := scanStuckTxs(ctx, db, _recheckables)
+			if err != nil {
+				appLog.Error("Failed to scan for stuck txs", logger.Fields{"error": err})
+				continue
+			}
+
+			recordStuckTxs(stuck)
+
+			if len(stuck) != 0 {
+				appLog.Warn("Found stuck txs", logger.Fields{"count": len(stuck)})
+			}
+
+			sink := newAlertSink(getConfig())
+			if sink == nil {
+				continue
+			}
+
+			if err := alertStuckTxs(ctx, sink, stuck); err != nil && ctx.Err() == nil {
+				appLog.Error("Failed to alert about stuck txs", logger.Fields{"error": err, "sink": getConfig().AlertSink})
+			}
+		}
+	}()
+
+	return func() {
+		cancel()
+		<-done
+	}
+}
+
+// Destination, alerts about stuck transfers are sent to
+type alertSink interface {
+	send(ctx context.Context, subject string, stuck []*client.StuckTx) error
+}
+
+// Sink alerts are sent to, as configured in `AlertSink`, which is
+// nil, when alerting is disabled
+func newAlertSink(c *Config) alertSink {
+	switch c.AlertSink {
+	case "webhook":
+		return &webhookSink{url: c.AlertURL}
+	case "slack":
+		return &slackSink{url: c.AlertURL}
+	case "smtp":
+		return &smtpSink{url: c.AlertSMTP, from: c.AlertFrom, to: c.AlertTo}
+	}
+
+	return nil
+}
+
+// Human readable alert, listing each stuck tx on its own line
+func alertText(subject string, stuck []*client.StuckTx) string {
+	lines := make([]string, 0, len(stuck)+1)
+	lines = append(lines, subject)
+
+	for _, v := range stuck {
+		lines = append(lines, fmt.Sprintf("- %s chain tx %s held in %d `%s` since %s, for %s, beyond %s", v.Chain, v.TxHash, v.Code, v.Message, v.Since.Format(time.RFC3339), time.Duration(v.Age)*time.Second, time.Duration(v.Threshold)*time.Second))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// Posts JSON payload to URL, expecting 2xx response
+func postJSON(ctx context.Context, url string, payload interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := (&http.Client{Timeout: alertTimeout}).Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("alert responded with status code %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// Posts `{"text": ..., "txs": [...]}` to generic webhook
+type webhookSink struct {
+	url string
+}
+
+func (w *webhookSink) send(ctx context.Context, subject string, stuck []*client.StuckTx) error {
+	return postJSON(ctx, w.url, gin.H{"text": alertText(subject, stuck), "txs": stuck})
+}
+
+// Posts `{"text": ...}` to Slack-compatible incoming webhook
+type slackSink struct {
+	url string
+}
+
+func (s *slackSink) send(ctx context.Context, subject string, stuck []*client.StuckTx) error {
+	return postJSON(ctx, s.url, gin.H{"text": alertText(subject, stuck)})
+}
+
+// Mails alert using SMTP server, authenticating with credentials in URL, if any
+//
+// `smtps` connects over TLS, while `smtp` upgrades to it using STARTTLS, when
+// server offers it, because credentials are only sent over TLS or to localhost
+type smtpSink struct {
+	url  string
+	from string
+	to   []string
+	tls  *tls.Config
+}
+
+func (s *smtpSink) send(ctx context.Context, subject string, stuck []*client.StuckTx) error {
+	u, err := url.Parse(s.url)
+	if err != nil {
+		return err
+	}
+
+	port := "25"
+	if u.Scheme == "smtps" {
+		port = "465"
+	}
+
+	addr := u.Host
+	if u.Port() == "" {
+		addr = net.JoinHostPort(u.Hostname(), port)
+	}
+
+	config := s.tls
+	if config == nil {
+		config = &tls.Config{ServerName: u.Hostname()}
+	}
+
+	conn, err := (&net.Dialer{}).DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	// `net/smtp` doesn't take context, so connection is given up on, once it's done
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetDeadline(deadline); err != nil {
+			return err
+		}
+	}
+
+	if u.Scheme == "smtps" {
+		conn = tls.Client(conn, config)
+	}
+
+	c, err := smtp.NewClient(conn, u.Hostname())
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	if ok, _ := c.Extension("STARTTLS"); ok && u.Scheme == "smtp" {
+		if err := c.StartTLS(config); err != nil {
+			return err
+		}
+	}
+
+	if u.User != nil {
+		password, _ := u.User.Password()
+
+		if err := c.Auth(smtp.PlainAuth("", u.User.Username(), password, u.Hostname())); err != nil {
+			return err
+		}
+	}
+
+	if err := c.Mail(s.from); err != nil {
+		return err
+	}
+
+	for _, v := range s.to {
+		if err := c.Rcpt(v); err != nil {
+			return err
+		}
+	}
+
+	writer, err := c.Data()
+	if err != nil {
+		return err
+	}
+
+	msg := strings.Join([]string{
+		"From: " + s.from,
+		"To: " + strings.Join(s.to, ", "),
+		"Subject: " + subject,
+		"Content-Type: text/plain; charset=utf-8",
+		"",
+		alertText(subject, stuck),
+	}, "\r\n")
+
+	if _, err := writer.Write([]byte(msg)); err != nil {
+		return err
+	}
+
+	if err := writer.Close(); err != nil {
+		return err
+	}
+
+	return c.Quit()
+}
+
+// Registers `/v2/admin/*` endpoints, behind same authentication as admin API
+func registerStuckRoutes(router *gin.Engine, db *gorm.DB) {
+	admin := router.Group("/v2/admin", adminAuth())
+
+	{
+
+		// Persisted statuses held for longer than their thresholds, oldest first,
+		// where only statuses of `code` are looked at, if given
+		admin.GET("/stuck", func(c *gin.Context) {
+			limit := 100
+
+			if v := c.Query("limit"); v != "" {
+				_limit, err := strconv.ParseUint(v, 10, 32)
+				if err != nil || _limit == 0 {
+					c.JSON(400, gin.H{
+						"msg": "Bad Limit",
+					})
+					return
+				}
+
+				limit = int(_limit)
+			}
+
+			var codes []int
+
+			if v := c.Query("code"); v != "" {
+				code, err := strconv.Atoi(v)
+				if _, ok := stuckStateOf(code); err != nil || !ok {
+					c.JSON(400, gin.H{
+						"msg": "Bad Code",
+					})
+					return
+				}
+
+				codes = []int{code}
+			}
+
+			stuck, err := findStuckTxs(c.Request.Context(), db, getConfig().StuckAfter, codes, limit)
+			if err != nil {
+				c.JSON(500, gin.H{
+					"msg": "Failed to Look Up Txs",
+				})
+				return
+			}
+
+			recordAudit(c, db, "view-stuck", "stuck", gin.H{"code": c.Query("code"), "limit": limit, "count": len(stuck)})
+
+			c.JSON(200, &client.StuckResponse{Count: len(stuck), Transactions: stuck})
+		})
+
+	}
+}
@@ -0,0 +1,492 @@
+package tracker
+
+import (
+	"context"
+	"errors"
+	"logger"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/gin-gonic/gin"
+	"github.com/maticnetwork/deposits-withdrawals-tracking/app/client"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Contracts emitting `TokenMapped` events are checked for new ones these often
+const tokenIndexInterval = time.Minute * time.Duration(5)
+
+// `TokenMapped` logs are queried in ranges of these many root chain blocks
+const tokenBlockRange = 10000
+
+var (
+	// `TokenMapped(address,address,bytes32)`, emitted by RootChainManager
+	posTokenMappedTopic = crypto.Keccak256Hash([]byte("TokenMapped(address,address,bytes32)"))
+	// `TokenMapped(address,address)`, emitted by Plasma Registry
+	plasmaTokenMappedTopic = crypto.Keccak256Hash([]byte("TokenMapped(address,address)"))
+)
+
+// Selectors of functions, called for finding out details of mapped token
+var (
+	symbolSelector   = crypto.Keccak256([]byte("symbol()"))[:4]
+	decimalsSelector = crypto.Keccak256([]byte("decimals()"))[:4]
+	isERC721Selector = crypto.Keccak256([]byte("isERC721(address)"))[:4]
+)
+
+// Returned when type, token is mapped with, is not known, so that it can be skipped
+var errUnknownTokenType = errors.New("unknown token type")
+
+// Address, RootChainManager uses for denoting Ether
+var etherAddress = common.HexToAddress("0xEeeeeEeeeEeEeeEeEeEeeEEEeeeeEeeeeeeeEEeE")
+
+// Type of POS token, keyed by type emitted in `TokenMapped` event, which
+// is keccak256 hash of predicate type, token is mapped with
+var posTokenTypes = map[common.Hash]string{
+	crypto.Keccak256Hash([]byte("ERC20")):           client.ERC20Token,
+	crypto.Keccak256Hash([]byte("MintableERC20")):   client.ERC20Token,
+	crypto.Keccak256Hash([]byte("ERC721")):          client.ERC721Token,
+	crypto.Keccak256Hash([]byte("MintableERC721")):  client.ERC721Token,
+	crypto.Keccak256Hash([]byte("ERC1155")):         client.ERC1155Token,
+	crypto.Keccak256Hash([]byte("MintableERC1155")): client.ERC1155Token,
+	crypto.Keccak256Hash([]byte("Ether")):           client.EtherToken,
+}
+
+// TokenMapping - Token mapped between root & child chain, on one of bridges, as
+// found from latest `TokenMapped` event emitted for root token
+type TokenMapping struct {
+	RootToken       string    `gorm:"column:root_token;type:char(42);primaryKey"`
+	Bridge          string    `gorm:"column:bridge;type:varchar(8);primaryKey"`
+	ChildToken      string    `gorm:"column:child_token;type:char(42);index;not null"`
+	Type            string    `gorm:"column:type;type:varchar(8);not null"`
+	Decimals        uint8     `gorm:"column:decimals;type:smallint;not null;default:0"`
+	Symbol          string    `gorm:"column:symbol;type:varchar;not null;default:''"`
+	BlockNumber     uint64    `gorm:"column:block;type:bigint;not null"`
+	TransactionHash string    `gorm:"column:txhash;type:char(66);not null"`
+	UpdatedAt       time.Time `gorm:"column:updated_at"`
+}
+
+// TableName - Overriding default table name
+func (TokenMapping) TableName() string {
+	return "token_mapping"
+}
+
+// IndexCursor - Block upto which ( inclusive ) some index has been built
+type IndexCursor struct {
+	Name  string `gorm:"column:name;type:varchar;primaryKey"`
+	Block uint64 `gorm:"column:block;type:bigint;not null"`
+}
+
+// TableName - Overriding default table name
+func (IndexCursor) TableName() string {
+	return "index_cursor"
+}
+
+// Last block indexed under given name, where false is returned, when
+// nothing has been indexed yet
+func getIndexCursor(ctx context.Context, db *gorm.DB, name string) (uint64, bool, error) {
+	var cursor IndexCursor
+
+	if err := db.WithContext(ctx).Where("name = ?", name).First(&cursor).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, false, nil
+		}
+
+		return 0, false, err
+	}
+
+	return cursor.Block, true, nil
+}
+
+// Remembers block upto which index has been built, under given name
+func putIndexCursor(ctx context.Context, db *gorm.DB, name string, block uint64) error {
+	return db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "name"}},
+		DoUpdates: clause.AssignmentColumns([]string{"block"}),
+	}).Create(&IndexCursor{Name: name, Block: block}).Error
+}
+
+// Contract emitting `TokenMapped` events, on one of bridges
+type tokenSource struct {
+	bridge   string
+	contract common.Address
+	topic    common.Hash
+	// Finds out type of token, given `TokenMapped` log
+	typeOf func(ctx context.Context, _log *types.Log) (string, error)
+}
+
+// Contracts emitting `TokenMapped` events, which are set in config
+func tokenSources(rootClient *ethclient.Client, conf *Config) []*tokenSource {
+	sources := make([]*tokenSource, 0, 2)
+
+	if conf.RootChainManager != (common.Address{}) {
+		sources = append(sources, &tokenSource{
+			bridge:   client.POSBridge,
+			contract: conf.RootChainManager,
+			topic:    posTokenMappedTopic,
+			typeOf: func(ctx context.Context, _log *types.Log) (string, error) {
+				if common.BytesToAddress(_log.Topics[1].Bytes()) == etherAddress {
+					return client.EtherToken, nil
+				}
+
+				if _type, ok := posTokenTypes[_log.Topics[3]]; ok {
+					return _type, nil
+				}
+
+				return "", errUnknownTokenType
+			},
+		})
+	}
+
+	if conf.PlasmaRegistry != (common.Address{}) {
+		registry := conf.PlasmaRegistry
+
+		sources = append(sources, &tokenSource{
+			bridge:   client.PlasmaBridge,
+			contract: registry,
+			topic:    plasmaTokenMappedTopic,
+			typeOf: func(ctx context.Context, _log *types.Log) (string, error) {
+				data, err := callContract(ctx, rootClient, registry, append(append([]byte{}, isERC721Selector...), _log.Topics[1].Bytes()...))
+				if err != nil {
+					return "", err
+				}
+
+				if new(big.Int).SetBytes(data).Sign() != 0 {
+					return client.ERC721Token, nil
+				}
+
+				return client.ERC20Token, nil
+			},
+		})
+	}
+
+	return sources
+}
+
+// Calls contract on chain given client is connected to, at latest block
+func callContract(ctx context.Context, client *ethclient.Client, to common.Address, data []byte) ([]byte, error) {
+	start := time.Now()
+
+	out, err := client.CallContract(ctx, ethereum.CallMsg{To: &to, Data: data}, nil)
+	recordRPCCall(chainOf(client), "eth_call", start, err)
+
+	return out, err
+}
+
+// Decodes return value of `symbol()`, which is string for most of tokens, while
+// some older ones return bytes32, empty if it can't be decoded
+func decodeSymbol(data []byte) string {
+	if len(data) == 32 {
+		return strings.ToValidUTF8(strings.TrimRight(string(data), "\x00"), "")
+	}
+
+	if len(data) < 64 {
+		return ""
+	}
+
+	offset := new(big.Int).SetBytes(data[:32])
+	if !offset.IsUint64() || offset.Uint64() > uint64(len(data)-32) {
+		return ""
+	}
+
+	start := offset.Uint64() + 32
+
+	length := new(big.Int).SetBytes(data[offset.Uint64():start])
+	if !length.IsUint64() || length.Uint64() > uint64(len(data))-start {
+		return ""
+	}
+
+	return strings.ToValidUTF8(string(data[start:start+length.Uint64()]), "")
+}
+
+// Finds out symbol & decimals of mapped token, by calling it on root chain,
+// where failures are only logged, as they're not required for bridging
+func describeToken(ctx context.Context, rootClient *ethclient.Client, mapping *TokenMapping) {
+	if mapping.Type == client.EtherToken {
+		mapping.Symbol, mapping.Decimals = "ETH", 18
+		return
+	}
+
+	token := common.HexToAddress(mapping.RootToken)
+
+	if data, err := callContract(ctx, rootClient, token, symbolSelector); err == nil {
+		mapping.Symbol = decodeSymbol(data)
+	} else {
+		appLog.Ctx(ctx).Warn("Failed to fetch token symbol", logger.Fields{"error": err, "token": mapping.RootToken})
+	}
+
+	// Only fungible tokens have decimals
+	if mapping.Type != client.ERC20Token {
+		return
+	}
+
+	data, err := callContract(ctx, rootClient, token, decimalsSelector)
+	if err != nil || len(data) < 32 {
+		appLog.Ctx(ctx).Warn("Failed to fetch token decimals", logger.Fields{"error": err, "token": mapping.RootToken})
+		return
+	}
+
+	if decimals := new(big.Int).SetBytes(data[:32]); decimals.IsUint64() && decimals.Uint64() <= 255 {
+		mapping.Decimals = uint8(decimals.Uint64())
+	}
+}
+
+// Indexes `TokenMapped` events emitted by given contract, since last indexed block,
+// or `startBlock` when nothing has been indexed yet, upto latest block
+func indexTokenMappings(ctx context.Context, rootClient *ethclient.Client, db *gorm.DB, source *tokenSource, startBlock uint64) {
+	name := strings.Join([]string{"token_mapped", source.bridge}, "/")
+
+	from := startBlock
+
+	last, ok, err := getIndexCursor(ctx, db, name)
+	if err != nil {
+		dbLog.Error("Failed to read index cursor", logger.Fields{"error": err, "index": name})
+		return
+	}
+	if ok {
+		from = last + 1
+	}
+
+	start := time.Now()
+
+	header, err := rootClient.HeaderByNumber(ctx, nil)
+	recordRPCCall(chainOf(rootClient), "eth_getBlockByNumber", start, err)
+	if err != nil {
+		appLog.Error("Failed to fetch latest block", logger.Fields{"error": err, "index": name})
+		return
+	}
+
+	for head := header.Number.Uint64(); from <= head; from += tokenBlockRange {
+		to := from + tokenBlockRange - 1
+		if to > head {
+			to = head
+		}
+
+		start := time.Now()
+
+		logs, err := rootClient.FilterLogs(ctx, ethereum.FilterQuery{
+			FromBlock: new(big.Int).SetUint64(from),
+			ToBlock:   new(big.Int).SetUint64(to),
+			Addresses: []common.Address{source.contract},
+			Topics:    [][]common.Hash{{source.topic}},
+		})
+		recordRPCCall(chainOf(rootClient), "eth_getLogs", start, err)
+		if err != nil {
+			appLog.Error("Failed to fetch `TokenMapped` logs", logger.Fields{"error": err, "index": name, "from": from, "to": to})
+			return
+		}
+
+		for i := range logs {
+			_log := &logs[i]
+
+			if _log.Removed || len(_log.Topics) < 3 || (source.bridge == client.POSBridge && len(_log.Topics) < 4) {
+				continue
+			}
+
+			_type, err := source.typeOf(ctx, _log)
+			if errors.Is(err, errUnknownTokenType) {
+				appLog.Warn("Skipping token mapped with unknown type", logger.Fields{"index": name, "txHash": _log.TxHash.Hex()})
+				continue
+			}
+			if err != nil {
+				appLog.Error("Failed to find out type of mapped token", logger.Fields{"error": err, "index": name, "txHash": _log.TxHash.Hex()})
+				return
+			}
+
+			mapping := &TokenMapping{
+				RootToken:       common.BytesToAddress(_log.Topics[1].Bytes()).Hex(),
+				Bridge:          source.bridge,
+				ChildToken:      common.BytesToAddress(_log.Topics[2].Bytes()).Hex(),
+				Type:            _type,
+				BlockNumber:     _log.BlockNumber,
+				TransactionHash: _log.TxHash.Hex(),
+				UpdatedAt:       time.Now().UTC(),
+			}
+			describeToken(ctx, rootClient, mapping)
+
+			// Token getting remapped, replaces earlier mapping
+			if err := db.WithContext(ctx).Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "root_token"}, {Name: "bridge"}},
+				DoUpdates: clause.AssignmentColumns([]string{"child_token", "type", "decimals", "symbol", "block", "txhash", "updated_at"}),
+			}).Create(mapping).Error; err != nil {
+				dbLog.Error("Failed to put token mapping", logger.Fields{"error": err, "token": mapping.RootToken})
+				return
+			}
+
+			appLog.Info("Indexed token mapping", logger.Fields{"bridge": mapping.Bridge, "rootToken": mapping.RootToken, "childToken": mapping.ChildToken, "type": mapping.Type})
+		}
+
+		if err := putIndexCursor(ctx, db, name, to); err != nil {
+			dbLog.Error("Failed to put index cursor", logger.Fields{"error": err, "index": name, "block": to})
+			return
+		}
+	}
+}
+
+// Starts indexing token mappings from contracts set in config, in a different thread
+// of execution, returning function to be invoked for stopping it, during shutdown
+func startTokenIndexing(rootClient *ethclient.Client, db *gorm.DB) func() {
+	conf := getConfig()
+
+	sources := tokenSources(rootClient, conf)
+	if len(sources) == 0 {
+		return func() {}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		for {
+			for _, v := range sources {
+				indexTokenMappings(ctx, rootClient, db, v, conf.TokenStartBlock)
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(tokenIndexInterval):
+			}
+		}
+	}()
+
+	return func() {
+		cancel()
+		<-done
+	}
+}
+
+// Mappings of token, given its address on either of chains, one per bridge
+func findTokenMappings(ctx context.Context, db *gorm.DB, address common.Address) ([]*client.TokenMapping, error) {
+	var rows []TokenMapping
+
+	if err := db.WithContext(ctx).Where("root_token = ? OR child_token = ?", address.Hex(), address.Hex()).Order("bridge").Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	mappings := make([]*client.TokenMapping, 0, len(rows))
+	for _, v := range rows {
+		mapping := &client.TokenMapping{
+			Address:     address,
+			Chain:       "root",
+			Counterpart: common.HexToAddress(v.ChildToken),
+			Type:        v.Type,
+			Bridge:      v.Bridge,
+			Decimals:    v.Decimals,
+			Symbol:      v.Symbol,
+		}
+
+		if v.RootToken != address.Hex() {
+			mapping.Chain, mapping.Counterpart = "child", common.HexToAddress(v.RootToken)
+		}
+
+		mappings = append(mappings, mapping)
+	}
+
+	return mappings, nil
+}
+
+// Whether value is one of given values or not
+func oneOf(value string, values ...string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Handler of `GET /v2/tokens`, responding with all mapped tokens, optionally
+// filtered by `bridge` & `type` query params
+func tokensHandler(db *gorm.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		query := db.WithContext(c.Request.Context())
+
+		if bridge := c.Query("bridge"); bridge != "" {
+			if !oneOf(bridge, client.POSBridge, client.PlasmaBridge) {
+				c.JSON(400, gin.H{
+					"msg": "Bad Bridge",
+				})
+				return
+			}
+
+			query = query.Where("bridge = ?", bridge)
+		}
+
+		if _type := c.Query("type"); _type != "" {
+			if !oneOf(_type, client.ERC20Token, client.ERC721Token, client.ERC1155Token, client.EtherToken) {
+				c.JSON(400, gin.H{
+					"msg": "Bad Type",
+				})
+				return
+			}
+
+			query = query.Where("type = ?", _type)
+		}
+
+		var rows []TokenMapping
+
+		if err := query.Order("bridge, root_token").Find(&rows).Error; err != nil {
+			dbLog.Ctx(c.Request.Context()).Error("Failed to look up token mappings", logger.Fields{"error": err})
+
+			c.JSON(500, gin.H{
+				"msg": "Failed to Look Up Tokens",
+			})
+			return
+		}
+
+		tokens := make([]*client.Token, 0, len(rows))
+		for _, v := range rows {
+			tokens = append(tokens, &client.Token{
+				RootToken:  common.HexToAddress(v.RootToken),
+				ChildToken: common.HexToAddress(v.ChildToken),
+				Type:       v.Type,
+				Bridge:     v.Bridge,
+				Decimals:   v.Decimals,
+				Symbol:     v.Symbol,
+			})
+		}
+
+		c.JSON(200, &client.TokensResponse{Tokens: tokens})
+	}
+}
+
+// Handler of `GET /v2/tokens/:address`, responding with mappings of token, given
+// its address on either of chains
+func tokenHandler(db *gorm.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !common.IsHexAddress(c.Param("address")) {
+			c.JSON(400, gin.H{
+				"msg": "Bad Address",
+			})
+			return
+		}
+
+		mappings, err := findTokenMappings(c.Request.Context(), db, common.HexToAddress(c.Param("address")))
+		if err != nil {
+			dbLog.Ctx(c.Request.Context()).Error("Failed to look up token mappings", logger.Fields{"error": err})
+
+			c.JSON(500, gin.H{
+				"msg": "Failed to Look Up Token",
+			})
+			return
+		}
+
+		if len(mappings) == 0 {
+			c.JSON(404, gin.H{
+				"msg": "Token Not Mapped",
+			})
+			return
+		}
+
+		c.JSON(200, &client.TokenResponse{Mappings: mappings})
+	}
+}
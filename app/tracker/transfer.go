@@ -0,0 +1,361 @@
+package tracker
+
+import (
+	"context"
+	"errors"
+	"logger"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/maticnetwork/deposits-withdrawals-tracking/app/client"
+	"gorm.io/gorm"
+)
+
+// Topics of events emitted on root chain, when tokens are locked for depositing
+var (
+	// Emitted by POS predicates, where amount/ token ID(s) are in data
+	lockedERC20Topics = []common.Hash{
+		crypto.Keccak256Hash([]byte("LockedERC20(address,address,address,uint256)")),
+		crypto.Keccak256Hash([]byte("LockedMintableERC20(address,address,address,uint256)")),
+	}
+	lockedERC721Topics = []common.Hash{
+		crypto.Keccak256Hash([]byte("LockedERC721(address,address,address,uint256)")),
+		crypto.Keccak256Hash([]byte("LockedMintableERC721(address,address,address,uint256)")),
+	}
+	lockedERC721BatchTopics = []common.Hash{
+		crypto.Keccak256Hash([]byte("LockedERC721Batch(address,address,address,uint256[])")),
+		crypto.Keccak256Hash([]byte("LockedMintableERC721Batch(address,address,address,uint256[])")),
+	}
+	lockedERC1155Topics = []common.Hash{
+		crypto.Keccak256Hash([]byte("LockedBatchERC1155(address,address,address,uint256[],uint256[])")),
+		crypto.Keccak256Hash([]byte("LockedBatchMintableERC1155(address,address,address,uint256[],uint256[])")),
+	}
+	lockedEtherTopic = crypto.Keccak256Hash([]byte("LockedEther(address,address,uint256)"))
+	// Emitted by Plasma `DepositManager`, for both ERC20 & ERC721
+	newDepositBlockTopic = crypto.Keccak256Hash([]byte("NewDepositBlock(address,address,uint256,uint256)"))
+)
+
+// Types of non-indexed event params, amounts & token IDs are found in
+var (
+	uint256Type, _      = abi.NewType("uint256", "", nil)
+	uint256ArrayType, _ = abi.NewType("uint256[]", "", nil)
+)
+
+// Decodes data of log, made of given types, each being either `uint256` or
+// `uint256[]`, into lists of decimal strings, one per type
+func decodeUints(data []byte, _types ...abi.Type) ([][]string, error) {
+	args := make(abi.Arguments, 0, len(_types))
+	for _, v := range _types {
+		args = append(args, abi.Argument{Type: v})
+	}
+
+	values, err := args.UnpackValues(data)
+	if err != nil {
+		return nil, err
+	}
+
+	decoded := make([][]string, 0, len(values))
+	for _, v := range values {
+		switch _v := v.(type) {
+		case *big.Int:
+			decoded = append(decoded, []string{_v.String()})
+		case []*big.Int:
+			_tmp := make([]string, 0, len(_v))
+			for _, n := range _v {
+				_tmp = append(_tmp, n.String())
+			}
+			decoded = append(decoded, _tmp)
+		default:
+			return nil, errors.New("unexpected type of value")
+		}
+	}
+
+	return decoded, nil
+}
+
+// Whether topic is one of given topics or not
+func isOneOf(topic common.Hash, topics []common.Hash) bool {
+	for _, v := range topics {
+		if v == topic {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Appends tokens moved, merging them into last one, if it's of same token, so that
+// burning many tokens of same collection, one transfer at a time, is reported once
+func appendTransfer(transfers []*client.TokenTransfer, transfer *client.TokenTransfer) []*client.TokenTransfer {
+	if n := len(transfers); n > 0 {
+		if last := transfers[n-1]; last.Token == transfer.Token && last.Type == transfer.Type && last.Type != client.ERC20Token {
+			last.TokenIDs = append(last.TokenIDs, transfer.TokenIDs...)
+			last.Amounts = append(last.Amounts, transfer.Amounts...)
+			return transfers
+		}
+	}
+
+	return append(transfers, transfer)
+}
+
+// Tokens moved by `Transfer`, `TransferSingle` or `TransferBatch` log, along with
+// sender & receiver, where ERC20 & ERC721 are told apart by whether token ID is
+// indexed in `Transfer` or not
+//
+// Returns nil, when it's not one of them
+func movedTokens(_log *types.Log) (*client.TokenTransfer, common.Address, common.Address, error) {
+	if len(_log.Topics) == 0 {
+		return nil, common.Address{}, common.Address{}, nil
+	}
+
+	switch _log.Topics[0] {
+	case transferTopic:
+		if len(_log.Topics) < 3 {
+			break
+		}
+
+		from, to := common.BytesToAddress(_log.Topics[1].Bytes()), common.BytesToAddress(_log.Topics[2].Bytes())
+
+		if len(_log.Topics) == 4 {
+			return &client.TokenTransfer{Token: _log.Address, Type: client.ERC721Token, TokenIDs: []string{_log.Topics[3].Big().String()}}, from, to, nil
+		}
+
+		values, err := decodeUints(_log.Data, uint256Type)
+		if err != nil {
+			return nil, from, to, err
+		}
+
+		return &client.TokenTransfer{Token: _log.Address, Type: client.ERC20Token, Amounts: values[0]}, from, to, nil
+
+	case transferSingleTopic, transferBatchTopic:
+		if len(_log.Topics) != 4 {
+			break
+		}
+
+		from, to := common.BytesToAddress(_log.Topics[2].Bytes()), common.BytesToAddress(_log.Topics[3].Bytes())
+
+		_type := uint256Type
+		if _log.Topics[0] == transferBatchTopic {
+			_type = uint256ArrayType
+		}
+
+		values, err := decodeUints(_log.Data, _type, _type)
+		if err != nil {
+			return nil, from, to, err
+		}
+
+		return &client.TokenTransfer{Token: _log.Address, Type: client.ERC1155Token, TokenIDs: values[0], Amounts: values[1]}, from, to, nil
+
+	}
+
+	return nil, common.Address{}, common.Address{}, nil
+}
+
+// Tokens burnt on child chain, as found in logs of burn tx i.e. transfers
+// to zero address
+//
+// Plasma child tokens, which don't emit `Transfer` while being burnt i.e. MRC20,
+// are found from `Withdraw`
+func burntTransfers(_ context.Context, _ *gorm.DB, receipt *types.Receipt) ([]*client.TokenTransfer, error) {
+	transfers := make([]*client.TokenTransfer, 0)
+
+	var withdraws []*types.Log
+
+	for _, _log := range receipt.Logs {
+		if len(_log.Topics) != 0 && _log.Topics[0] == plasmaWithdrawTopic {
+			withdraws = append(withdraws, _log)
+			continue
+		}
+
+		transfer, _, to, err := movedTokens(_log)
+		if err != nil {
+			return nil, err
+		}
+
+		if transfer != nil && to == (common.Address{}) {
+			transfers = appendTransfer(transfers, transfer)
+		}
+	}
+
+	if len(transfers) != 0 {
+		return transfers, nil
+	}
+
+	for _, _log := range withdraws {
+		values, err := decodeUints(_log.Data, uint256Type, uint256Type, uint256Type)
+		if err != nil {
+			return nil, err
+		}
+
+		transfers = appendTransfer(transfers, &client.TokenTransfer{Token: _log.Address, Type: client.ERC20Token, Amounts: values[0]})
+	}
+
+	return transfers, nil
+}
+
+// Tokens locked/ exited by POS predicate, as found in its `Locked*`/ `Exited*` log,
+// along with root token & account, tokens were deposited for/ exited to, where
+// data is laid out same for both, depending upon type of token
+//
+// Returns nil, when it's not one of them
+func predicateTransfer(_log *types.Log) (*client.TokenTransfer, common.Address, error) {
+	if len(_log.Topics) == 0 {
+		return nil, common.Address{}, nil
+	}
+
+	topic := _log.Topics[0]
+
+	var (
+		_type  string
+		_types []abi.Type
+	)
+
+	switch {
+	case isOneOf(topic, lockedERC20Topics), isOneOf(topic, exitedERC20Topics):
+		_type, _types = client.ERC20Token, []abi.Type{uint256Type}
+	case isOneOf(topic, lockedERC721Topics), isOneOf(topic, exitedERC721Topics):
+		_type, _types = client.ERC721Token, []abi.Type{uint256Type}
+	case isOneOf(topic, lockedERC721BatchTopics), isOneOf(topic, exitedERC721BatchTopics):
+		_type, _types = client.ERC721Token, []abi.Type{uint256ArrayType}
+	case isOneOf(topic, exitedERC1155Topics):
+		_type, _types = client.ERC1155Token, []abi.Type{uint256Type, uint256Type}
+	case isOneOf(topic, lockedERC1155Topics), isOneOf(topic, exitedERC1155BatchTopics):
+		_type, _types = client.ERC1155Token, []abi.Type{uint256ArrayType, uint256ArrayType}
+	case topic == lockedEtherTopic, topic == exitedEtherTopic:
+		_type, _types = client.EtherToken, []abi.Type{uint256Type}
+	default:
+		return nil, common.Address{}, nil
+	}
+
+	// `Locked*` index depositor, receiver & root token, while `Exited*` index exitor
+	// & root token, where Ether ones don't index token
+	locked := topic == lockedEtherTopic || isOneOf(topic, lockedERC20Topics) || isOneOf(topic, lockedERC721Topics) || isOneOf(topic, lockedERC721BatchTopics) || isOneOf(topic, lockedERC1155Topics)
+
+	indexed := 3
+	if !locked {
+		indexed = 2
+	}
+	if _type != client.EtherToken {
+		indexed++
+	}
+	if len(_log.Topics) != indexed {
+		return nil, common.Address{}, nil
+	}
+
+	values, err := decodeUints(_log.Data, _types...)
+	if err != nil {
+		return nil, common.Address{}, err
+	}
+
+	transfer := &client.TokenTransfer{Token: etherAddress, Type: _type}
+	if _type != client.EtherToken {
+		transfer.Token = common.BytesToAddress(_log.Topics[indexed-1].Bytes())
+	}
+
+	switch {
+	case _type == client.ERC20Token, _type == client.EtherToken:
+		transfer.Amounts = values[0]
+	case _type == client.ERC721Token:
+		transfer.TokenIDs = values[0]
+	default:
+		transfer.TokenIDs, transfer.Amounts = values[0], values[1]
+	}
+
+	account := _log.Topics[1]
+	if locked {
+		account = _log.Topics[2]
+	}
+
+	return transfer, common.BytesToAddress(account.Bytes()), nil
+}
+
+// Tokens locked on root chain, as found in logs of deposit tx, emitted by POS
+// predicates or Plasma `DepositManager`
+//
+// Type of token deposited using Plasma bridge is looked up in token registry, which
+// is taken as ERC20, when it's not there
+func depositedTransfers(ctx context.Context, db *gorm.DB, receipt *types.Receipt) ([]*client.TokenTransfer, error) {
+	transfers := make([]*client.TokenTransfer, 0)
+
+	for _, _log := range receipt.Logs {
+		if len(_log.Topics) == 3 && _log.Topics[0] == newDepositBlockTopic {
+			values, err := decodeUints(_log.Data, uint256Type, uint256Type)
+			if err != nil {
+				return nil, err
+			}
+
+			token := common.BytesToAddress(_log.Topics[2].Bytes())
+
+			transfer := &client.TokenTransfer{Token: token, Type: client.ERC20Token, Amounts: values[0]}
+			if plasmaTokenType(ctx, db, token) == client.ERC721Token {
+				transfer = &client.TokenTransfer{Token: token, Type: client.ERC721Token, TokenIDs: values[0]}
+			}
+
+			transfers = appendTransfer(transfers, transfer)
+			continue
+		}
+
+		transfer, _, err := predicateTransfer(_log)
+		if err != nil {
+			return nil, err
+		}
+
+		// Only locked ones are of deposit
+		if transfer != nil && !isOneOf(_log.Topics[0], posExitedTopics) {
+			transfers = appendTransfer(transfers, transfer)
+		}
+	}
+
+	return transfers, nil
+}
+
+// Type of root token, as mapped on Plasma bridge, empty when not found
+func plasmaTokenType(ctx context.Context, db *gorm.DB, token common.Address) string {
+	var mapping TokenMapping
+
+	if err := db.WithContext(ctx).Where("root_token = ? AND bridge = ?", token.Hex(), client.PlasmaBridge).First(&mapping).Error; err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			dbLog.Ctx(ctx).Error("Failed to look up token mapping", logger.Fields{"error": err, "token": token.Hex()})
+		}
+
+		return ""
+	}
+
+	return mapping.Type
+}
+
+// Wraps status checking function, so that tokens moved by tx, as found out
+// from its receipt using `transfers`, are put in status
+func withTransfers(chain *ethclient.Client, db *gorm.DB, status func(context.Context, common.Hash) *TransactionState, transfers func(context.Context, *gorm.DB, *types.Receipt) ([]*client.TokenTransfer, error)) func(context.Context, common.Hash) *TransactionState {
+	return func(ctx context.Context, txHash common.Hash) *TransactionState {
+		_state := status(ctx, txHash)
+		if _state == nil {
+			return _state
+		}
+
+		receipt, err := getTransactionReceipt(ctx, chain, txHash)
+		if err != nil {
+			statusLog.Ctx(ctx).Warn("Failed to fetch tx receipt, for decoding tokens moved", logger.Fields{"error": err, "txHash": txHash.Hex()})
+			return _state
+		}
+		if receipt == nil || receipt.Status == 0 {
+			return _state
+		}
+
+		_transfers, err := transfers(ctx, db, receipt)
+		if err != nil {
+			statusLog.Ctx(ctx).Warn("Failed to decode tokens moved", logger.Fields{"error": err, "txHash": txHash.Hex()})
+			return _state
+		}
+
+		if len(_transfers) != 0 {
+			_state.Tokens = _transfers
+		}
+
+		return _state
+	}
+}
@@ -0,0 +1,212 @@
+package tracker
+
+import (
+	"app/tracing"
+	"context"
+	"logger"
+	"math/big"
+	"net/http"
+	"sync"
+
+	cpt "check-point-tracker/app"
+	sim "state-id-manager/app"
+	ssi "state-sender-indexer/app"
+
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/gin-gonic/gin"
+	"github.com/maticnetwork/deposits-withdrawals-tracking/app/client"
+)
+
+// CheckPointTracker - Answers whether given child chain block has been checkpointed
+// or not, implemented by both in-process `check-point-tracker` & HTTP client of same
+type CheckPointTracker interface {
+	IsCheckPointed(ctx context.Context, blockNumber *big.Int) (bool, error)
+}
+
+// StateIDManager - Provides latest `lastStateId` of child chain & how each state sync
+// landed on it, implemented by both in-process `state-id-manager` & HTTP client of same
+//
+// `State` returns nil, when state sync is not yet indexed
+type StateIDManager interface {
+	LastStateID(ctx context.Context) (*big.Int, error)
+	State(ctx context.Context, id *big.Int) (*client.CommittedState, error)
+}
+
+// In-process `state-id-manager`, whose committed states are handed out in
+// same form, as they're received over HTTP
+type localStateIDManager struct {
+	*sim.Manager
+}
+
+// State - Looks up committed state by `stateId`, in index of in-process manager
+func (l *localStateIDManager) State(ctx context.Context, id *big.Int) (*client.CommittedState, error) {
+	state := l.Manager.State(id)
+	if state == nil {
+		return nil, nil
+	}
+
+	_state := &client.CommittedState{
+		ID:          state.ID,
+		BlockNumber: state.BlockNumber,
+		TxHash:      state.TxHash,
+		Success:     state.Success,
+		SyncTime:    state.SyncTime,
+		Contract:    state.Contract,
+		RootTxHash:  state.RootTxHash,
+		Data:        state.Data,
+	}
+
+	if state.Decoded != nil {
+		_state.Decoded = &client.SyncData{
+			Type:       state.Decoded.Type,
+			User:       state.Decoded.User,
+			RootToken:  state.Decoded.RootToken,
+			ChildToken: state.Decoded.ChildToken,
+			TokenType:  state.Decoded.TokenType,
+			Payload:    state.Decoded.Payload,
+		}
+	}
+
+	return _state, nil
+}
+
+// Workers to be used by status checking functions, set up during
+// application boot up, depending upon `WorkerMode`
+//
+// `pos-exit-checker` is always reached over HTTP
+var (
+	checkPointTracker CheckPointTracker
+	stateIDManager    StateIDManager
+	posExitChecker    *client.POSExitChecker
+	// Guards workers, which are replaced when their URLs are changed on reload
+	workersMutex = &sync.RWMutex{}
+)
+
+// Puts given workers in use, for all lookups starting from now on
+func setWorkers(_checkPointTracker CheckPointTracker, _stateIDManager StateIDManager, _posExitChecker *client.POSExitChecker) {
+	workersMutex.Lock()
+	defer workersMutex.Unlock()
+
+	checkPointTracker = _checkPointTracker
+	stateIDManager = _stateIDManager
+	posExitChecker = _posExitChecker
+}
+
+// `check-point-tracker` in use
+func getCheckPointTracker() CheckPointTracker {
+	workersMutex.RLock()
+	defer workersMutex.RUnlock()
+
+	return checkPointTracker
+}
+
+// `state-id-manager` in use
+func getStateIDManager() StateIDManager {
+	workersMutex.RLock()
+	defer workersMutex.RUnlock()
+
+	return stateIDManager
+}
+
+// `pos-exit-checker` in use
+func getPOSExitChecker() *client.POSExitChecker {
+	workersMutex.RLock()
+	defer workersMutex.RUnlock()
+
+	return posExitChecker
+}
+
+// Makes given HTTP client forward request ID & trace context, carried by
+// context of each request, to workers, so that their logs & spans can be
+// correlated with ours
+func instrument(httpClient *http.Client) {
+	httpClient.Transport = logger.Transport(tracing.Transport(http.DefaultTransport))
+}
+
+// HTTP client of `check-point-tracker`, running at given URL
+func newCheckPointTracker(url string) *client.CheckPointTracker {
+	_checkPointTracker := client.NewCheckPointTracker(url)
+	instrument(_checkPointTracker.HTTPClient)
+
+	return _checkPointTracker
+}
+
+// HTTP client of `state-id-manager`, running at given URL
+func newStateIDManager(url string) *client.StateIDManager {
+	_stateIDManager := client.NewStateIDManager(url)
+	instrument(_stateIDManager.HTTPClient)
+
+	return _stateIDManager
+}
+
+// HTTP client of `pos-exit-checker`, running at given URL
+func newPOSExitChecker(url string) *client.POSExitChecker {
+	_posExitChecker := client.NewPOSExitChecker(url)
+	instrument(_posExitChecker.HTTPClient)
+
+	return _posExitChecker
+}
+
+// Prepares to talk to workers over HTTP, using URLs in given config, where
+// in-process workers are kept as they are, when running `all-in-one`
+func connectToWorkers(c *Config) {
+	_posExitChecker := newPOSExitChecker(c.POSExitChecker)
+
+	if c.WorkerMode == "all-in-one" {
+		setWorkers(getCheckPointTracker(), getStateIDManager(), _posExitChecker)
+		return
+	}
+
+	_checkPointTracker, _stateIDManager := cacheWorkers(newCheckPointTracker(c.CheckPointTracker), newStateIDManager(c.StateIDManager))
+	setWorkers(_checkPointTracker, _stateIDManager, _posExitChecker)
+}
+
+// Sets up workers, to be talked to, while checking transaction status, returning
+// function to be invoked for stopping them, during shutdown
+//
+// When `WorkerMode` is `all-in-one`, Go workers are started in this process
+// itself & their endpoints are exposed under `/workers/*`, otherwise they're
+// expected to be running as separate micro services, reachable over HTTP
+func setUpWorkers(router *gin.Engine) func() {
+	conf := getConfig()
+
+	// Already connected to, while setting up router
+	if conf.WorkerMode != "all-in-one" {
+		return func() {}
+	}
+
+	_checkPointTracker := cpt.NewTracker(conf.checkPointTrackerConfig())
+	_checkPointTracker.Start()
+
+	_stateIDManager, err := sim.NewManager(conf.stateIDManagerConfig())
+	if err != nil {
+		workerLog.Fatal("Failed to create `state-id-manager`", logger.Fields{"error": err})
+	}
+	_stateIDManager.Start()
+
+	_stateSenderIndexer, err := ssi.NewIndexer(conf.stateSenderIndexerConfig())
+	if err != nil {
+		workerLog.Fatal("Failed to create `state-sender-indexer`", logger.Fields{"error": err})
+	}
+	_stateSenderIndexer.Start()
+
+	_cachedCheckPointTracker, _cachedStateIDManager := cacheWorkers(_checkPointTracker, &localStateIDManager{_stateIDManager})
+	setWorkers(_cachedCheckPointTracker, _cachedStateIDManager, newPOSExitChecker(conf.POSExitChecker))
+
+	// so that `/metrics` also serves metrics of workers
+	workerRegistries = []metrics.Registry{cpt.Metrics, sim.Metrics, ssi.Metrics}
+
+	_checkPointTracker.Routes(router.Group("/workers/check-point-tracker"))
+	_stateIDManager.Routes(router.Group("/workers/state-id-manager"))
+	_stateSenderIndexer.Routes(router.Group("/workers/state-sender-indexer"))
+
+	workerLog.Info("Running workers in-process")
+
+	return func() {
+		_checkPointTracker.Stop()
+		_stateIDManager.Stop()
+		_stateSenderIndexer.Stop()
+
+		workerLog.Info("Stopped in-process workers")
+	}
+}
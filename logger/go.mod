@@ -0,0 +1,15 @@
+module logger
+
+go 1.13
+
+require (
+	github.com/gin-gonic/gin v1.6.3
+	github.com/go-playground/validator/v10 v10.4.1 // indirect
+	github.com/golang/protobuf v1.4.3 // indirect
+	github.com/google/uuid v1.1.2
+	github.com/json-iterator/go v1.1.10 // indirect
+	github.com/ugorji/go v1.1.13 // indirect
+	golang.org/x/sys v0.0.0-20201029020603-3518587229cd // indirect
+	google.golang.org/protobuf v1.25.0 // indirect
+	gopkg.in/yaml.v2 v2.3.0 // indirect
+)
@@ -0,0 +1,241 @@
+// Package logger - Structured JSON logging, shared by bridge API & its workers
+//
+// Each log line is a JSON object carrying `time`, `level`, `component` & `msg`,
+// along with any other fields passed, where minimum level to be logged can be
+// configured per component
+package logger
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Level - Severity of log entry
+type Level int
+
+// Supported log levels, in increasing order of severity
+const (
+	DebugLevel Level = iota
+	InfoLevel
+	WarnLevel
+	ErrorLevel
+	FatalLevel
+)
+
+func (l Level) String() string {
+	switch l {
+	case DebugLevel:
+		return "debug"
+	case InfoLevel:
+		return "info"
+	case WarnLevel:
+		return "warn"
+	case ErrorLevel:
+		return "error"
+	case FatalLevel:
+		return "fatal"
+	}
+
+	return "unknown"
+}
+
+// ParseLevel - Parses level from its name, case insensitively
+func ParseLevel(name string) (Level, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "debug":
+		return DebugLevel, nil
+	case "info", "":
+		return InfoLevel, nil
+	case "warn", "warning":
+		return WarnLevel, nil
+	case "error":
+		return ErrorLevel, nil
+	case "fatal":
+		return FatalLevel, nil
+	}
+
+	return InfoLevel, fmt.Errorf("bad log level `%s`", name)
+}
+
+// Fields - Extra key value pairs to be logged along with message
+type Fields map[string]interface{}
+
+// Global logging configuration, shared by all loggers
+var (
+	output       io.Writer = os.Stderr
+	defaultLevel           = InfoLevel
+	levels                 = make(map[string]Level)
+	mutex                  = &sync.RWMutex{}
+)
+
+// Configure - Sets minimum level to be logged by all components, along with
+// per component overrides, given in `component=level,component=level` form
+//
+// e.g. Configure("info", "db=warn,check-point-tracker=debug")
+func Configure(level string, perComponent string) error {
+	_default, _levels, err := parseLevels(level, perComponent)
+	if err != nil {
+		return err
+	}
+
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	defaultLevel = _default
+	levels = _levels
+
+	return nil
+}
+
+// ValidateLevels - Checks whether levels, as accepted by `Configure`, can be parsed
+// or not, without applying them
+func ValidateLevels(level string, perComponent string) error {
+	_, _, err := parseLevels(level, perComponent)
+	return err
+}
+
+// Parses default level & per component overrides
+func parseLevels(level string, perComponent string) (Level, map[string]Level, error) {
+	_default, err := ParseLevel(level)
+	if err != nil {
+		return InfoLevel, nil, err
+	}
+
+	_levels := make(map[string]Level)
+
+	for _, v := range strings.Split(perComponent, ",") {
+		if strings.TrimSpace(v) == "" {
+			continue
+		}
+
+		parts := strings.SplitN(v, "=", 2)
+		if len(parts) != 2 {
+			return InfoLevel, nil, errors.New("per component log level must be in `component=level` form")
+		}
+
+		_level, err := ParseLevel(parts[1])
+		if err != nil {
+			return InfoLevel, nil, err
+		}
+
+		_levels[strings.TrimSpace(parts[0])] = _level
+	}
+
+	return _default, _levels, nil
+}
+
+// SetOutput - Sets where log lines to be written, defaults to stderr
+func SetOutput(w io.Writer) {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	output = w
+}
+
+// Minimum level to be logged by given component
+func levelOf(component string) Level {
+	mutex.RLock()
+	defer mutex.RUnlock()
+
+	if _level, ok := levels[component]; ok {
+		return _level
+	}
+
+	return defaultLevel
+}
+
+// Logger - Writes log lines on behalf of one component, carrying
+// some fields with each of them
+type Logger struct {
+	component string
+	fields    Fields
+}
+
+// New - Creates logger for given component
+func New(component string) *Logger {
+	return &Logger{component: component, fields: Fields{}}
+}
+
+// With - Creates logger, which carries given fields along with
+// already carried ones, with each log line
+func (l *Logger) With(fields Fields) *Logger {
+	_fields := make(Fields, len(l.fields)+len(fields))
+
+	for k, v := range l.fields {
+		_fields[k] = v
+	}
+	for k, v := range fields {
+		_fields[k] = v
+	}
+
+	return &Logger{component: l.component, fields: _fields}
+}
+
+// Writes log line, if level is enabled for this component
+func (l *Logger) log(level Level, msg string, fields []Fields) {
+	if level < levelOf(l.component) {
+		return
+	}
+
+	entry := make(Fields, len(l.fields)+4)
+
+	for k, v := range l.fields {
+		entry[k] = v
+	}
+	for _, f := range fields {
+		for k, v := range f {
+			// errors don't get marshalled into anything meaningful
+			if err, ok := v.(error); ok && err != nil {
+				v = err.Error()
+			}
+			entry[k] = v
+		}
+	}
+
+	entry["time"] = time.Now().UTC().Format(time.RFC3339Nano)
+	entry["level"] = level.String()
+	entry["component"] = l.component
+	entry["msg"] = msg
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		data = []byte(fmt.Sprintf(`{"level":"error","component":%q,"msg":"failed to marshal log entry"}`, l.component))
+	}
+
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	output.Write(append(data, '\n'))
+}
+
+// Debug - Logs at debug level
+func (l *Logger) Debug(msg string, fields ...Fields) {
+	l.log(DebugLevel, msg, fields)
+}
+
+// Info - Logs at info level
+func (l *Logger) Info(msg string, fields ...Fields) {
+	l.log(InfoLevel, msg, fields)
+}
+
+// Warn - Logs at warn level
+func (l *Logger) Warn(msg string, fields ...Fields) {
+	l.log(WarnLevel, msg, fields)
+}
+
+// Error - Logs at error level
+func (l *Logger) Error(msg string, fields ...Fields) {
+	l.log(ErrorLevel, msg, fields)
+}
+
+// Fatal - Logs at fatal level & kills process
+func (l *Logger) Fatal(msg string, fields ...Fields) {
+	l.log(FatalLevel, msg, fields)
+	os.Exit(1)
+}
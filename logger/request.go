@@ -0,0 +1,96 @@
+package logger
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// RequestIDHeader - HTTP header carrying request ID, which is generated by bridge API
+// & forwarded to workers, so that one user request can be traced end to end
+const RequestIDHeader = "X-Request-ID"
+
+type requestIDKey struct{}
+
+// WithRequestID - Returns context carrying given request ID
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestID - Request ID carried by context, empty if none
+func RequestID(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// Ctx - Creates logger, which carries request ID, if any, present in context
+func (l *Logger) Ctx(ctx context.Context) *Logger {
+	id := RequestID(ctx)
+	if id == "" {
+		return l
+	}
+
+	return l.With(Fields{"requestId": id})
+}
+
+// Middleware - Picks up request ID from `X-Request-ID` header or generates new one,
+// puts it in request context & response header, then logs request once served
+func Middleware(l *Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		id := c.GetHeader(RequestIDHeader)
+		if id == "" {
+			id = uuid.New().String()
+		}
+
+		c.Request = c.Request.WithContext(WithRequestID(c.Request.Context(), id))
+		c.Header(RequestIDHeader, id)
+
+		c.Next()
+
+		fields := Fields{
+			"requestId": id,
+			"method":    c.Request.Method,
+			"endpoint":  c.FullPath(),
+			"path":      c.Request.URL.Path,
+			"status":    c.Writer.Status(),
+			"duration":  time.Since(start).String(),
+			"ip":        c.ClientIP(),
+		}
+
+		if len(c.Errors) != 0 {
+			fields["errors"] = c.Errors.String()
+		}
+
+		l.Info("Served request", fields)
+	}
+}
+
+// Transport - Wraps given transport, so that request ID carried by context of
+// each outgoing request is forwarded in `X-Request-ID` header
+func Transport(base http.RoundTripper) http.RoundTripper {
+	return &requestIDTransport{base: base}
+}
+
+type requestIDTransport struct {
+	base http.RoundTripper
+}
+
+// RoundTrip - Sends request, carrying request ID, if any, present in its context
+func (r *requestIDTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if id := RequestID(req.Context()); id != "" && req.Header.Get(RequestIDHeader) == "" {
+		// Given request must not be modified
+		req = req.Clone(req.Context())
+		req.Header.Set(RequestIDHeader, id)
+	}
+
+	return r.base.RoundTrip(req)
+}
@@ -2,7 +2,8 @@ package app
 
 import (
 	"context"
-	"log"
+	"fmt"
+	"logger"
 	"sync"
 	"time"
 
@@ -12,16 +13,22 @@ import (
 )
 
 // Tracks checkpointing status by listening for `NewHeaderBlock(address,uint256,uint256,uint256,uint256,bytes32)`
-// event on rootchain contract
-func trackCheckPointing(_storage *CheckPointedBlockRange, _mutex *sync.Mutex) {
-	client := getClient()
-	if client == nil {
-		return
+// event on rootchain contract, until `done` is closed
+//
+// Returns error, when connection to root chain or subscription fails, so
+// that it can be run once again
+func trackCheckPointing(config *Config, _storage *CheckPointedBlockRange, _mutex *sync.Mutex, done <-chan struct{}) error {
+	client, err := getClient(config.RPC)
+	if err != nil {
+		return fmt.Errorf("failed to connect to root chain : %w", err)
 	}
 
-	root := getRootChain(client)
-	if root == nil {
-		return
+	// scheduling disconnection
+	defer client.Close()
+
+	root, err := getRootChain(client, config.RootChain)
+	if err != nil {
+		return fmt.Errorf("failed to get instance of RootChain : %w", err)
 	}
 
 	// Closure to be used for attempting to read
@@ -37,10 +44,11 @@ func trackCheckPointing(_storage *CheckPointedBlockRange, _mutex *sync.Mutex) {
 			_mutex.Unlock()
 			// -- ends here, releasing lock
 
-			log.Printf("[+] Fetched last checkpointed block number [ %s ]\n", lastChildBlock.String())
+			appLog.Info("Fetched last checkpointed block number", logger.Fields{"block": lastChildBlock.String()})
 		} else {
 
-			log.Printf("[!] Failed to fetch last checkpointed block number : %s\n", err.Error())
+			appLog.Error("Failed to fetch last checkpointed block number", logger.Fields{"error": err})
+			collector.RPCFailure("getLastChildBlock")
 
 		}
 
@@ -54,12 +62,11 @@ func trackCheckPointing(_storage *CheckPointedBlockRange, _mutex *sync.Mutex) {
 	// when ever new checkpoint is submitted, this event to be emitted on root chain
 	logs := make(chan types.Log)
 	subs, err := client.SubscribeFilterLogs(context.Background(), ethereum.FilterQuery{
-		Addresses: []common.Address{common.HexToAddress(get("RootChain"))},
+		Addresses: []common.Address{common.HexToAddress(config.RootChain)},
 		Topics:    [][]common.Hash{{common.HexToHash("0xba5de06d22af2685c6c7765f60067f7d2b08c2d29f53cdf14d67f6d1c9bfb527")}},
 	}, logs)
 	if err != nil {
-		log.Fatalln("[!] ", err)
-		return
+		return fmt.Errorf("failed to subscribe to `NewHeaderBlock` logs : %w", err)
 	}
 
 	// scheduling unsubscription
@@ -68,19 +75,22 @@ func trackCheckPointing(_storage *CheckPointedBlockRange, _mutex *sync.Mutex) {
 	for {
 
 		select {
+		case <-done:
+
+			appLog.Info("Stopped tracking checkpoints")
+			return nil
+
 		case err := <-subs.Err():
 
-			// Intentionally crashing program when subscription gets cancelled so that systemd
-			// will restart service and we'll again get to subscribe to this event
-			//
-			// Make sure systemd is configured to restart this service when craashed
-			log.Fatalln("[!] ", err)
+			// Subscription gets cancelled, when connection to RPC node is lost,
+			// so it's left to caller to connect & subscribe once again
+			return fmt.Errorf("`NewHeaderBlock` subscription failed : %w", err)
 
 		case _log := <-logs:
 
 			_parsed, err := root.ParseNewHeaderBlock(_log)
 			if err != nil {
-				log.Println("[!] ", err)
+				appLog.Error("Failed to parse `NewHeaderBlock` log", logger.Fields{"error": err, "txHash": _log.TxHash.Hex()})
 				continue
 			}
 
@@ -95,7 +105,7 @@ func trackCheckPointing(_storage *CheckPointedBlockRange, _mutex *sync.Mutex) {
 			// over channel, from RPC node
 			lastTimeRead = time.Now().UTC()
 
-			log.Println("[+] Updated Checkpoint info : ", _parsed.Start.String(), " <-> ", _parsed.End.String())
+			appLog.Info("Updated checkpoint info", logger.Fields{"start": _parsed.Start.String(), "end": _parsed.End.String()})
 
 		case <-time.After(time.Minute * time.Duration(30)):
 
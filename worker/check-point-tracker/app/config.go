@@ -1,15 +1,61 @@
 package app
 
-import "github.com/spf13/viper"
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"worker-common/config"
 
-// Reading configuration into memory
-func read(file string) error {
-	viper.SetConfigFile(file)
+	"github.com/ethereum/go-ethereum/common"
+)
 
-	return viper.ReadInConfig()
+// Config - Everything required for tracking checkpoints, to be supplied
+// when using this micro service as library, from another process
+type Config struct {
+	RPC       string
+	RootChain string
 }
 
-// Retrieving value for given key
-func get(key string) string {
-	return viper.GetString(key)
+// Configuration params of this micro service, where those required by every
+// worker i.e. `PORT`, `LogLevel` & `LogLevels` are added by `config.New`
+var params = config.New("check-point-tracker", appLog, []config.Key{
+	{Name: "RPC", Usage: "Root chain RPC URL"},
+	{Name: "RootChain", Usage: "Address of RootChain contract"},
+}, func() error {
+	return loadConfig().Validate()
+})
+
+// Builds config from values read from `.env` file, environment variables & flags
+func loadConfig() *Config {
+	return &Config{
+		RPC:       config.Get("RPC"),
+		RootChain: config.Get("RootChain"),
+	}
+}
+
+// Validate - Checks whether config is good enough for tracking checkpoints or not
+func (c *Config) Validate() error {
+	problems := make([]string, 0)
+
+	if !config.IsURL(c.RPC, "http", "https", "ws", "wss") {
+		problems = append(problems, fmt.Sprintf("`RPC` must be RPC URL, found `%s`", c.RPC))
+	}
+
+	if !common.IsHexAddress(c.RootChain) {
+		problems = append(problems, fmt.Sprintf("`RootChain` must be address, found `%s`", c.RootChain))
+	}
+
+	if len(problems) != 0 {
+		return errors.New(strings.Join(problems, "; "))
+	}
+
+	return nil
+}
+
+// CheckConfig - Prints effective configuration, with secrets redacted, followed by
+// problems found in it, if any, given path to config file & command line arguments
+//
+// Exits with non-zero status code, when configuration is not good
+func CheckConfig(file string, args []string) {
+	params.Check(file, args)
 }
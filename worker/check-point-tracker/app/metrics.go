@@ -0,0 +1,10 @@
+package app
+
+import "worker-common/metrics"
+
+// Metrics of this worker, named under `check_point_tracker/`
+var collector = metrics.New("check_point_tracker")
+
+// Metrics - All metrics collected by this worker, kept in own registry so
+// that metrics of geth packages, we depend on, don't show up
+var Metrics = collector.Registry
@@ -1,10 +1,8 @@
 package app
 
 import (
-	"log"
-	"math/big"
-	"strings"
-	"sync"
+	"logger"
+	"worker-common/server"
 
 	"github.com/gin-gonic/gin"
 )
@@ -12,73 +10,39 @@ import (
 // Run - Exposing REST API for querying what's latest checkpoint status
 // and also given child chain block number, it can be checked whether block has
 // been checkpointed or not
-func Run(file string) {
-	err := read(file)
+func Run(file string, args []string) {
+	err := params.Read(file, args)
 	if err != nil {
-		log.Fatalln("[!] ", err)
+		appLog.Fatal("Failed to read config", logger.Fields{"error": err, "file": file})
 		return
 	}
 
-	checkPointedBlockRange := &CheckPointedBlockRange{
-		Start: big.NewInt(0),
-		End:   big.NewInt(0),
-	}
-	mutex := &sync.Mutex{}
-
-	go trackCheckPointing(checkPointedBlockRange, mutex)
+	setUpLogging()
 
-	router := gin.Default()
+	if err := params.Validate(); err != nil {
+		appLog.Fatal("Bad config", logger.Fields{"error": err})
+		return
+	}
 
-	// GET endpoint for obtaining latest checkpoint's included block range
-	router.GET("/", func(c *gin.Context) {
-		mutex.Lock()
-		start := checkPointedBlockRange.Start.String()
-		end := checkPointedBlockRange.End.String()
-		mutex.Unlock()
+	config := loadConfig()
 
-		c.JSON(200, gin.H{
-			"start": start,
-			"end":   end,
-		})
-	})
+	tracker := NewTracker(config)
+	tracker.Start()
 
-	// When given with blockNumber on child chain, it can respond with `code`
-	// to denote whether this child chain block has been checkpointed or not
-	router.POST("/", func(c *gin.Context) {
-		var payload Payload
+	router := gin.New()
+	router.Use(gin.Recovery())
 
-		if err := c.ShouldBindJSON(&payload); err != nil {
-			c.JSON(400, gin.H{
-				"msg": "Bad Payload",
-			})
-			return
-		}
+	// Picking up request ID forwarded by bridge API, while logging
+	// request once served
+	router.Use(logger.Middleware(appLog))
+	router.Use(collector.Middleware())
 
-		_tmp := big.NewInt(0)
-		_tmp, ok := _tmp.SetString(payload.BlockNumber, 10)
-		if !ok {
-			c.JSON(400, gin.H{
-				"msg": "Bad Payload",
-			})
-			return
-		}
+	tracker.Routes(router)
 
-		// this is critical section code, accessed after
-		// acquiring lock
-		mutex.Lock()
-		if checkPointedBlockRange.End.Cmp(_tmp) >= 0 {
-			c.JSON(200, gin.H{
-				"code": 1,
-				"msg":  "Check Pointed",
-			})
-		} else {
-			c.JSON(200, gin.H{
-				"code": 0,
-				"msg":  "Not Check Pointed",
-			})
-		}
-		mutex.Unlock()
-	})
+	// Exposing collected metrics, to be scraped by Prometheus
+	router.GET("/metrics", collector.Handler())
 
-	router.Run(strings.Join([]string{":", get("PORT")}, ""))
+	server.Serve(appLog, router, func() {
+		params.Reload(setUpLogging)
+	}, tracker.Stop)
 }
@@ -0,0 +1,133 @@
+package app
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"worker-common/retry"
+
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/gin-gonic/gin"
+)
+
+// Tracker - Keeps track of latest checkpoint's child block range, which can be
+// either exposed over HTTP ( see `Run` ) or used directly from another process
+type Tracker struct {
+	config  *Config
+	storage *CheckPointedBlockRange
+	mutex   *sync.Mutex
+	done    chan struct{}
+	wg      *sync.WaitGroup
+}
+
+// NewTracker - Creates new checkpoint tracker, which will start tracking
+// only after `Start` is invoked
+func NewTracker(config *Config) *Tracker {
+	return &Tracker{
+		config: config,
+		storage: &CheckPointedBlockRange{
+			Start: big.NewInt(0),
+			End:   big.NewInt(0),
+		},
+		mutex: &sync.Mutex{},
+		done:  make(chan struct{}),
+		wg:    &sync.WaitGroup{},
+	}
+}
+
+// Start - Starts listening for checkpoints in a different thread of execution,
+// which connects & subscribes once again, after backing off, when it fails
+//
+// Latest checkpoint's block range is also exposed as metrics
+func (t *Tracker) Start() {
+	metrics.NewRegisteredFunctionalGauge(collector.Name("checkpoint", "start"), Metrics, func() int64 {
+		start, _ := t.Range()
+		return start.Int64()
+	})
+	metrics.NewRegisteredFunctionalGauge(collector.Name("checkpoint", "end"), Metrics, func() int64 {
+		_, end := t.Range()
+		return end.Int64()
+	})
+
+	t.wg.Add(1)
+	go func() {
+		defer t.wg.Done()
+
+		retry.Forever(t.done, appLog, "track checkpoints", func() error {
+			return trackCheckPointing(t.config, t.storage, t.mutex, t.done)
+		})
+	}()
+}
+
+// Stop - Stops tracking checkpoints, unsubscribing & disconnecting from
+// root chain, returns once it's done
+func (t *Tracker) Stop() {
+	close(t.done)
+	t.wg.Wait()
+}
+
+// Range - Latest checkpoint's included child block range
+func (t *Tracker) Range() (*big.Int, *big.Int) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	return big.NewInt(0).Set(t.storage.Start), big.NewInt(0).Set(t.storage.End)
+}
+
+// IsCheckPointed - Given child chain block number, checks whether
+// it has been checkpointed or not
+func (t *Tracker) IsCheckPointed(ctx context.Context, blockNumber *big.Int) (bool, error) {
+	// this is critical section code, accessed after
+	// acquiring lock
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	return t.storage.End.Cmp(blockNumber) >= 0, nil
+}
+
+// Routes - Registers HTTP endpoints of this micro service, on given router
+func (t *Tracker) Routes(router gin.IRoutes) {
+	// GET endpoint for obtaining latest checkpoint's included block range
+	router.GET("/", func(c *gin.Context) {
+		start, end := t.Range()
+
+		c.JSON(200, gin.H{
+			"start": start.String(),
+			"end":   end.String(),
+		})
+	})
+
+	// When given with blockNumber on child chain, it can respond with `code`
+	// to denote whether this child chain block has been checkpointed or not
+	router.POST("/", func(c *gin.Context) {
+		var payload Payload
+
+		if err := c.ShouldBindJSON(&payload); err != nil {
+			c.JSON(400, gin.H{
+				"msg": "Bad Payload",
+			})
+			return
+		}
+
+		_tmp := big.NewInt(0)
+		_tmp, ok := _tmp.SetString(payload.BlockNumber, 10)
+		if !ok {
+			c.JSON(400, gin.H{
+				"msg": "Bad Payload",
+			})
+			return
+		}
+
+		if checkPointed, _ := t.IsCheckPointed(c, _tmp); checkPointed {
+			c.JSON(200, gin.H{
+				"code": 1,
+				"msg":  "Check Pointed",
+			})
+		} else {
+			c.JSON(200, gin.H{
+				"code": 0,
+				"msg":  "Not Check Pointed",
+			})
+		}
+	})
+}
@@ -3,6 +3,8 @@ module check-point-tracker
 go 1.13
 
 require (
+	logger v0.0.0
+	worker-common v0.0.0
 	github.com/StackExchange/wmi v0.0.0-20190523213315-cbe66965904d // indirect
 	github.com/aristanetworks/goarista v0.0.0-20201012165903-2cb20defcd66 // indirect
 	github.com/btcsuite/btcd v0.21.0-beta // indirect
@@ -44,3 +46,7 @@ require (
 	google.golang.org/protobuf v1.25.0 // indirect
 	gopkg.in/ini.v1 v1.62.0 // indirect
 )
+
+replace logger => ../../logger
+
+replace worker-common => ../common
@@ -0,0 +1,297 @@
+// Package config - Configuration of each worker, read from `.env` file, environment
+// variables & command line flags, which can be reloaded while it's running & printed
+// along with problems in it, using `config check`
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"logger"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// Key - One configuration param, which can be set in `.env` file, as environment
+// variable named same, in upper case e.g. `ROOTCHAIN` or as command line flag
+// e.g. `-RootChain`, in increasing order of precedence
+//
+// Params marked `Reload` can be changed without restarting, by sending SIGHUP or
+// editing config file
+type Key struct {
+	Name   string
+	Usage  string
+	Secret bool
+	Reload bool
+}
+
+// Params required by every worker, when running as micro service
+var serviceKeys = []Key{
+	{Name: "PORT", Usage: "Port to listen on"},
+	{Name: "LogLevel", Usage: "Minimum level to be logged", Reload: true},
+	{Name: "LogLevels", Usage: "Per component log levels e.g. `db=warn`", Reload: true},
+}
+
+// Params - Configuration params of one worker, along with values they had, when
+// read last time, so that changed ones can be found out on reload
+type Params struct {
+	name     string
+	keys     []Key
+	log      *logger.Logger
+	validate func() error
+	loaded   map[string]string
+}
+
+// New - Configuration params of worker with given name, along with those required
+// by every worker, where `validate` checks worker's own params, as currently read
+func New(name string, log *logger.Logger, keys []Key, validate func() error) *Params {
+	return &Params{
+		name:     name,
+		keys:     append(append([]Key{}, keys...), serviceKeys...),
+		log:      log,
+		validate: validate,
+		loaded:   make(map[string]string),
+	}
+}
+
+// Keys - All configuration params, in order they're printed
+func (p *Params) Keys() []Key {
+	return p.keys
+}
+
+// Read - Reads configuration into memory, from file if present, environment variables
+// & command line flags, where `-config` flag overrides path of file
+func (p *Params) Read(file string, args []string) error {
+	flags := flag.NewFlagSet(p.name, flag.ContinueOnError)
+
+	path := flags.String("config", file, "Path to config file")
+	for _, v := range p.keys {
+		flags.String(v.Name, "", v.Usage)
+	}
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	viper.AutomaticEnv()
+
+	if _, err := os.Stat(*path); err == nil {
+		viper.SetConfigFile(*path)
+
+		if err := viper.ReadInConfig(); err != nil {
+			return err
+		}
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+
+	flags.Visit(func(f *flag.Flag) {
+		if f.Name != "config" {
+			viper.Set(f.Name, f.Value.String())
+		}
+	})
+
+	p.remember()
+	return nil
+}
+
+// Remembers values of all configuration params, as read
+func (p *Params) remember() {
+	for _, v := range p.keys {
+		p.loaded[v.Name] = Get(v.Name)
+	}
+}
+
+// Reads config file once again, returning names of params which have been
+// changed, while those which can't be changed without restart, are warned
+// about & kept as they were
+func (p *Params) reread() ([]string, error) {
+	if viper.ConfigFileUsed() != "" {
+		if err := viper.ReadInConfig(); err != nil {
+			return nil, err
+		}
+	}
+
+	changed := make([]string, 0)
+
+	for _, v := range p.keys {
+		if Get(v.Name) == p.loaded[v.Name] {
+			continue
+		}
+
+		if !v.Reload {
+			p.log.Warn("Config param can't be changed without restart", logger.Fields{"key": v.Name})
+			viper.Set(v.Name, p.loaded[v.Name])
+			continue
+		}
+
+		changed = append(changed, v.Name)
+	}
+
+	return changed, nil
+}
+
+// Watch - Keeps checking config file for modification every `interval`, in a different
+// thread of execution, notifying over returned channel, whenever it's modified
+func Watch(interval time.Duration) <-chan struct{} {
+	modified := make(chan struct{}, 1)
+
+	file := viper.ConfigFileUsed()
+	if file == "" {
+		return modified
+	}
+
+	go func() {
+		last := modTime(file)
+
+		for {
+			<-time.After(interval)
+
+			if _tmp := modTime(file); !_tmp.Equal(last) {
+				last = _tmp
+
+				select {
+				case modified <- struct{}{}:
+				default:
+				}
+			}
+		}
+	}()
+
+	return modified
+}
+
+// Last time file was modified, zero time if it can't be found out
+func modTime(file string) time.Time {
+	info, err := os.Stat(file)
+	if err != nil {
+		return time.Time{}
+	}
+
+	return info.ModTime()
+}
+
+// Reload - Reloads configuration from config file, where only params marked `Reload`
+// are changed, when they're good, before `apply` is invoked for putting them in use,
+// while rest require restart
+func (p *Params) Reload(apply func()) {
+	changed, err := p.reread()
+	if err != nil {
+		p.log.Error("Failed to reload config", logger.Fields{"error": err})
+		return
+	}
+
+	if len(changed) == 0 {
+		p.log.Info("Config not changed")
+		return
+	}
+
+	if err := p.Validate(); err != nil {
+		p.log.Error("Bad config, not reloaded", logger.Fields{"error": err})
+		return
+	}
+
+	apply()
+	p.remember()
+
+	p.log.Info("Reloaded config", logger.Fields{"changed": changed})
+}
+
+// Validate - Checks whether configuration params, only required when running as micro
+// service i.e. not as library, are good or not, along with those of worker itself
+func (p *Params) Validate() error {
+	problems := make([]string, 0)
+
+	if err := p.validate(); err != nil {
+		problems = append(problems, err.Error())
+	}
+
+	if port, err := strconv.ParseUint(Get("PORT"), 10, 16); err != nil || port == 0 {
+		problems = append(problems, fmt.Sprintf("`PORT` must be port number, found `%s`", Get("PORT")))
+	}
+
+	if err := logger.ValidateLevels(Get("LogLevel"), Get("LogLevels")); err != nil {
+		problems = append(problems, fmt.Sprintf("bad `LogLevel`/ `LogLevels` : %s", err.Error()))
+	}
+
+	if len(problems) != 0 {
+		return errors.New(strings.Join(problems, "; "))
+	}
+
+	return nil
+}
+
+// Check - Prints effective configuration, with secrets redacted, followed by
+// problems found in it, if any, given path to config file & command line arguments
+//
+// Exits with non-zero status code, when configuration is not good
+func (p *Params) Check(file string, args []string) {
+	if err := p.Read(file, args); err != nil {
+		fmt.Fprintf(os.Stderr, "Error : %s\n", err.Error())
+		os.Exit(2)
+	}
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(writer, "Key\tValue")
+
+	for _, v := range p.keys {
+		fmt.Fprintf(writer, "%s\t%s\n", v.Name, Redact(v, Get(v.Name)))
+	}
+
+	writer.Flush()
+
+	if err := p.Validate(); err != nil {
+		fmt.Fprintf(os.Stderr, "\nBad config :\n  - %s\n", strings.Join(strings.Split(err.Error(), "; "), "\n  - "))
+		os.Exit(1)
+	}
+
+	fmt.Println("\nConfig is good")
+}
+
+// Get - Value of given configuration param
+func Get(key string) string {
+	return viper.GetString(key)
+}
+
+// IsURL - Checks whether value is URL with one of given schemes or not
+func IsURL(value string, schemes ...string) bool {
+	u, err := url.Parse(value)
+	if err != nil || u.Host == "" {
+		return false
+	}
+
+	for _, v := range schemes {
+		if u.Scheme == v {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Redact - Value of configuration param, as to be printed, where secrets are
+// hidden, along with passwords present in URLs
+func Redact(key Key, value string) string {
+	if value == "" {
+		return value
+	}
+
+	if key.Secret {
+		return "********"
+	}
+
+	if u, err := url.Parse(value); err == nil && u.User != nil {
+		if _, ok := u.User.Password(); ok {
+			u.User = url.UserPassword(u.User.Username(), "********")
+			return u.String()
+		}
+	}
+
+	return value
+}
@@ -0,0 +1,138 @@
+package index
+
+import (
+	"context"
+	"fmt"
+	"logger"
+	"math/big"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// Number of blocks to be queried in one `eth_getLogs` call, while
+// catching up with chain
+const blockRangeSize = 1000
+
+// Block number from where indexing is to be started, which is last indexed one,
+// if any, otherwise `startBlock`, when it's set, or else latest block
+func startBlock(client *ethclient.Client, index *Index, _startBlock string) (uint64, error) {
+	if last := index.LastBlock(); last != 0 {
+		return last, nil
+	}
+
+	if start, err := strconv.ParseUint(_startBlock, 10, 64); err == nil {
+		return start, nil
+	}
+
+	header, err := client.HeaderByNumber(context.Background(), nil)
+	if err != nil {
+		return 0, err
+	}
+
+	return header.Number.Uint64(), nil
+}
+
+// Follow - First catches up with chain by querying all past logs matching `query`,
+// from where indexing was left off, & then keeps listening for new ones, handing each
+// of them to `handle`, until `done` is closed, where `event` names logs being indexed
+//
+// Block is marked as indexed, only once its logs are handled, so that when handling
+// one fails, error is returned, for it to be run once again, resuming from last
+// indexed block, same as when connection to chain, catching up or subscription fails
+func Follow(client *ethclient.Client, index *Index, query ethereum.FilterQuery, _startBlock string, log *logger.Logger, event string, handle func(*types.Log) error, done <-chan struct{}) error {
+	// subscribing first, so that no log gets missed while catching up,
+	// states getting indexed twice is not a problem
+	logs := make(chan types.Log)
+	subs, err := client.SubscribeFilterLogs(context.Background(), query, logs)
+	if err != nil {
+		return fmt.Errorf("failed to subscribe to `%s` logs : %w", event, err)
+	}
+
+	// scheduling unsubscription
+	defer subs.Unsubscribe()
+
+	from, err := startBlock(client, index, _startBlock)
+	if err != nil {
+		return fmt.Errorf("failed to determine start block : %w", err)
+	}
+
+	header, err := client.HeaderByNumber(context.Background(), nil)
+	if err != nil {
+		return fmt.Errorf("failed to fetch latest block : %w", err)
+	}
+
+	for head := header.Number.Uint64(); from <= head; from += blockRangeSize {
+		select {
+		case <-done:
+			return nil
+		default:
+		}
+
+		to := from + blockRangeSize - 1
+		if to > head {
+			to = head
+		}
+
+		query.FromBlock = new(big.Int).SetUint64(from)
+		query.ToBlock = new(big.Int).SetUint64(to)
+
+		_logs, err := client.FilterLogs(context.Background(), query)
+		if err != nil {
+			return fmt.Errorf("failed to fetch `%s` logs in %d..%d : %w", event, from, to, err)
+		}
+
+		for i := range _logs {
+			if err := handleLog(index, &_logs[i], handle); err != nil {
+				return err
+			}
+		}
+
+		if err := index.SetLastBlock(to); err != nil {
+			log.Error("Failed to persist last indexed block", logger.Fields{"error": err, "block": to})
+		}
+	}
+
+	log.Info(fmt.Sprintf("Caught up with `%s` logs", event), logger.Fields{"block": header.Number.String()})
+
+	for {
+
+		select {
+		case <-done:
+
+			log.Info(fmt.Sprintf("Stopped indexing `%s` logs", event))
+			return nil
+
+		case err := <-subs.Err():
+
+			// Subscription gets cancelled, when connection to RPC node is lost,
+			// so it's left to caller to connect & subscribe once again
+			return fmt.Errorf("`%s` subscription failed : %w", event, err)
+
+		case _log := <-logs:
+
+			if err := handleLog(index, &_log, handle); err != nil {
+				return err
+			}
+
+		}
+
+	}
+
+}
+
+// Hands log over to `handle`, marking block it's in as indexed, once it's
+// handled, unless it's removed due to reorg
+func handleLog(index *Index, _log *types.Log, handle func(*types.Log) error) error {
+	if err := handle(_log); err != nil {
+		return err
+	}
+
+	if _log.Removed {
+		return nil
+	}
+
+	return index.SetLastBlock(_log.BlockNumber)
+}
@@ -0,0 +1,16 @@
+// Package index - On disk index of states, kept by workers tracking state syncs,
+// keyed by `stateId`
+package index
+
+import "math/big"
+
+// ParseStateID - Parses `stateId`, given in decimal form, where negative ones are
+// rejected, because they're keyed same as their positive counterparts
+func ParseStateID(id string) (*big.Int, bool) {
+	_id, ok := big.NewInt(0).SetString(id, 10)
+	if !ok || _id.Sign() < 0 {
+		return nil, false
+	}
+
+	return _id, true
+}
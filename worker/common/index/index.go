@@ -0,0 +1,120 @@
+package index
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+// Prefix used for keys holding states, followed by 32 bytes big endian
+// encoded `stateId`, so that iteration happens in ascending order of `stateId`
+var statePrefix = []byte("s")
+
+// Key holding last block number, which was indexed
+var lastBlockKey = []byte("b")
+
+// Index - Persistent, on disk index of states, kept as JSON, keyed by `stateId`,
+// along with block, indexing has progressed upto
+type Index struct {
+	db *leveldb.DB
+}
+
+// Open - Opens ( or creates ) leveldb backed index in given directory
+func Open(dir string) (*Index, error) {
+	db, err := leveldb.OpenFile(dir, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Index{db: db}, nil
+}
+
+// Converts `stateId` to key, to be used for storing it in index
+func stateKey(id *big.Int) []byte {
+	return append(append([]byte{}, statePrefix...), common.BigToHash(id).Bytes()...)
+}
+
+// Put - Persists state in index, overwriting if already present
+func (i *Index) Put(id *big.Int, state interface{}) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+
+	return i.db.Put(stateKey(id), data, nil)
+}
+
+// Remove - Removes state from index, to be invoked when log gets removed
+// due to chain reorganization
+func (i *Index) Remove(id *big.Int) error {
+	return i.db.Delete(stateKey(id), nil)
+}
+
+// Get - Looks up state by `stateId`, decoding it into `state`, returns false
+// if not indexed
+func (i *Index) Get(id *big.Int, state interface{}) bool {
+	data, err := i.db.Get(stateKey(id), nil)
+	if err != nil {
+		return false
+	}
+
+	return json.Unmarshal(data, state) == nil
+}
+
+// Range - Indexed states with `from <= stateId <= to`, in ascending order of
+// `stateId`, as JSON, capped at `max` entries
+func (i *Index) Range(from *big.Int, to *big.Int, max int) [][]byte {
+	iter := i.db.NewIterator(&util.Range{
+		Start: stateKey(from),
+		Limit: stateKey(big.NewInt(0).Add(to, big.NewInt(1))),
+	}, nil)
+	defer iter.Release()
+
+	states := make([][]byte, 0)
+
+	for iter.Next() && len(states) < max {
+		states = append(states, append([]byte{}, iter.Value()...))
+	}
+
+	return states
+}
+
+// LatestID - Highest `stateId` present in index, 0 if nothing indexed yet
+func (i *Index) LatestID() *big.Int {
+	iter := i.db.NewIterator(util.BytesPrefix(statePrefix), nil)
+	defer iter.Release()
+
+	if !iter.Last() {
+		return big.NewInt(0)
+	}
+
+	return big.NewInt(0).SetBytes(iter.Key()[len(statePrefix):])
+}
+
+// LastBlock - Last indexed block number, 0 if nothing indexed yet
+func (i *Index) LastBlock() uint64 {
+	data, err := i.db.Get(lastBlockKey, nil)
+	if err != nil || len(data) != 8 {
+		return 0
+	}
+
+	return binary.BigEndian.Uint64(data)
+}
+
+// SetLastBlock - Remembers upto which block we've indexed, so that on
+// restart indexing can be resumed from there
+func (i *Index) SetLastBlock(number uint64) error {
+	data := make([]byte, 8)
+	binary.BigEndian.PutUint64(data, number)
+
+	return i.db.Put(lastBlockKey, data, nil)
+}
+
+// Close - Closes index, which must not be used after that
+func (i *Index) Close() error {
+	return i.db.Close()
+}
@@ -0,0 +1,83 @@
+// Package metrics - Metrics collected by each worker, kept in its own registry,
+// named under its prefix e.g. `check_point_tracker/`, exported in Prometheus
+// exposition format
+package metrics
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/ethereum/go-ethereum/metrics/prometheus"
+	"github.com/gin-gonic/gin"
+)
+
+// Metrics need to be enabled before any of them gets registered, otherwise
+// no-op versions are handed out
+func init() {
+	metrics.Enabled = true
+}
+
+// Collector - Metrics of one worker, where `Registry` is kept separate so that
+// metrics of geth packages, we depend on, don't show up
+type Collector struct {
+	Prefix   string
+	Registry metrics.Registry
+}
+
+// New - Creates collector, naming all metrics under given prefix
+func New(prefix string) *Collector {
+	return &Collector{
+		Prefix:   prefix,
+		Registry: metrics.NewRegistry(),
+	}
+}
+
+// Name - Prometheus metric names can't have anything other than `[a-zA-Z0-9_:]`,
+// where `/` gets converted to `_` while exporting, so all other characters are
+// replaced with `_`, while name is prefixed
+func (c *Collector) Name(parts ...string) string {
+	parts = append([]string{c.Prefix}, parts...)
+
+	for i, v := range parts {
+		parts[i] = strings.Trim(v, "/")
+	}
+
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '/':
+			return r
+		}
+		return '_'
+	}, strings.Join(parts, "/"))
+}
+
+// RPCFailure - Records failed RPC call, made for given purpose
+func (c *Collector) RPCFailure(method string) {
+	metrics.GetOrRegisterCounter(c.Name("rpc", method, "errors"), c.Registry).Inc(1)
+}
+
+// Middleware - Counts requests per endpoint & status code returned, along
+// with time spent in serving them
+func (c *Collector) Middleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		start := time.Now()
+
+		ctx.Next()
+
+		// Unmatched routes are not worth having separate metrics for
+		route := ctx.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+
+		metrics.GetOrRegisterTimer(c.Name("http", route), c.Registry).UpdateSince(start)
+		metrics.GetOrRegisterCounter(c.Name("http", route, fmt.Sprintf("%d", ctx.Writer.Status())), c.Registry).Inc(1)
+	}
+}
+
+// Handler - Serves all collected metrics, in Prometheus exposition format
+func (c *Collector) Handler() gin.HandlerFunc {
+	return gin.WrapH(prometheus.Handler(c.Registry))
+}
@@ -0,0 +1,52 @@
+// Package retry - Keeps long running work of workers going, by running it once
+// again after backing off, whenever it fails, so that failure of RPC node doesn't
+// bring down whole process, which may be hosting other things too
+package retry
+
+import (
+	"logger"
+	"time"
+)
+
+// Waiting between consecutive failures starts at `minBackoff`, getting
+// doubled with each of them, upto `maxBackoff`
+const (
+	minBackoff = time.Second
+	maxBackoff = time.Minute
+)
+
+// Forever - Keeps running `fn` until `done` is closed, where `fn` is expected to return
+// only when either it fails or `done` is closed. Whenever it fails, error is logged
+// & it's run once again after backing off, where back off is reset, when it ran for
+// longer than `maxBackoff`, before failing
+func Forever(done <-chan struct{}, log *logger.Logger, name string, fn func() error) {
+	backoff := minBackoff
+
+	for {
+		started := time.Now()
+
+		err := fn()
+
+		select {
+		case <-done:
+			return
+		default:
+		}
+
+		if time.Since(started) > maxBackoff {
+			backoff = minBackoff
+		}
+
+		log.Error("Failed, retrying", logger.Fields{"task": name, "error": err, "retryIn": backoff.String()})
+
+		select {
+		case <-done:
+			return
+		case <-time.After(backoff):
+		}
+
+		if backoff *= 2; backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+	}
+}
@@ -0,0 +1,78 @@
+// Package server - Serves HTTP API of worker, running as micro service, reloading its
+// configuration while running & draining in-flight requests, before shutting down
+package server
+
+import (
+	"context"
+	"logger"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+	"worker-common/config"
+)
+
+// In-flight requests are given these long to complete, once asked to shut down
+const shutdownTimeout = time.Second * time.Duration(30)
+
+// Config file is checked for modification these often, to be reloaded
+const configPollInterval = time.Second * time.Duration(10)
+
+// Serve - Serves HTTP API using given handler on `PORT`, until SIGINT/ SIGTERM is received,
+// when in-flight requests are drained, before `stop` is invoked
+//
+// Configuration is reloaded using `reload` on SIGHUP or when config file gets modified,
+// while all of these are logged using given logger
+func Serve(log *logger.Logger, handler http.Handler, reload func(), stop func()) {
+	server := &http.Server{
+		Addr:    strings.Join([]string{":", config.Get("PORT")}, ""),
+		Handler: handler,
+	}
+
+	failed := make(chan error, 1)
+	go func() {
+		if err := server.ListenAndServe(); err != http.ErrServerClosed {
+			failed <- err
+		}
+	}()
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM, syscall.SIGHUP)
+
+	modified := config.Watch(configPollInterval)
+
+	for {
+
+		select {
+		case err := <-failed:
+			log.Fatal("Failed to serve", logger.Fields{"error": err})
+
+		case <-modified:
+			reload()
+
+		case sig := <-signals:
+			if sig == syscall.SIGHUP {
+				reload()
+				continue
+			}
+
+			log.Info("Shutting down", logger.Fields{"signal": sig.String()})
+
+			ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+
+			if err := server.Shutdown(ctx); err != nil {
+				log.Warn("Failed to drain in-flight requests", logger.Fields{"error": err})
+			}
+
+			cancel()
+
+			stop()
+
+			log.Info("Shut down")
+			return
+		}
+
+	}
+}
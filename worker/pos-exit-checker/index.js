@@ -7,8 +7,28 @@ const app = require('express')()
 const { json } = require('express')
 
 app.use(json())
-app.use((req, _, next) => {
-    console.log(`${new Date().toISOString()} | '/' | ${req.ip}`)
+// Logging each served request as JSON line, along with request ID
+// forwarded by bridge API, so that it can be correlated with its logs
+app.use((req, res, next) => {
+    const start = Date.now()
+    const requestId = req.get('X-Request-ID') || ''
+
+    res.set('X-Request-ID', requestId)
+    res.on('finish', () => {
+        console.log(JSON.stringify({
+            time: new Date().toISOString(),
+            level: 'info',
+            component: 'pos-exit-checker',
+            msg: 'Served request',
+            requestId,
+            method: req.method,
+            path: req.path,
+            status: res.statusCode,
+            duration: `${Date.now() - start}ms`,
+            ip: req.ip,
+        }))
+    })
+
     next()
 })
 
@@ -23,8 +43,12 @@ const matic = new Matic({
     maticProvider: process.env.ChildRPC.startsWith('http') ? new Web3.providers.HttpProvider(process.env.ChildRPC) : new Web3.providers.WebsocketProvider(process.env.ChildRPC),
 })
 
+// Set once plasma client gets initialized, before that plasma withdraw
+// exitability can't be checked
+let ready = false
+
 // If failed to initialize, simply kills self
-matic.initialize().then(_ => { }).catch(e => { console.log(e); process.exit(1); })
+matic.initialize().then(_ => { ready = true }).catch(e => { console.log(e); process.exit(1); })
 
 // Obtaining instance of matic pos client, to be used for checking whether
 // given `burnTxHash` has been exited on root chain or not
@@ -37,6 +61,97 @@ const client = new MaticPOSClient(
     }
 )
 
+// Web3 instances, for reading burn tx receipts from child chain & checking
+// whether exit has been processed, with `RootChainManager` on root chain
+const rootWeb3 = new Web3(process.env.RootRPC.startsWith('http') ? new Web3.providers.HttpProvider(process.env.RootRPC) : new Web3.providers.WebsocketProvider(process.env.RootRPC))
+const childWeb3 = new Web3(process.env.ChildRPC.startsWith('http') ? new Web3.providers.HttpProvider(process.env.ChildRPC) : new Web3.providers.WebsocketProvider(process.env.ChildRPC))
+
+// When `RootChainManager` address is not set, only ERC20 exits can be checked,
+// using `matic.js`
+const rootChainManager = process.env.RootChainManager ? new rootWeb3.eth.Contract([{
+    constant: true,
+    inputs: [{ name: '', type: 'bytes32' }],
+    name: 'processedExits',
+    outputs: [{ name: '', type: 'bool' }],
+    stateMutability: 'view',
+    type: 'function',
+}], process.env.RootChainManager) : null
+
+// Signatures of events, one of which is emitted by child token while being burnt,
+// depending upon its type, where `RootChainManager` marks exit processed
+// against that log
+const TRANSFER_EVENT_SIG = '0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef' // ERC20/ ERC721
+const WITHDRAWN_BATCH_EVENT_SIG = '0xf871896b17e9cb7a64941c62c188a4f5c621b86800e3d15452ece01ce56073df' // ERC721 batch
+const TRANSFER_SINGLE_EVENT_SIG = '0xc3d58168c5ae7397731d063d5bbf3d657854427343f4c083240f7aacaa2d0f62' // ERC1155
+const TRANSFER_BATCH_EVENT_SIG = '0x4a39dc06d4c0dbc64b70af90fd698a233a518aa5d07e595d983b8c0526c8f7fb' // ERC1155 batch
+
+const ZERO_TOPIC = '0x0000000000000000000000000000000000000000000000000000000000000000'
+
+// Index of burn log in receipt, where ERC721 batch burn is identified by `WithdrawnBatch`,
+// ERC1155 ones by transfer to zero address, followed by ERC20/ ERC721 ones
+const burnLogIndex = receipt => {
+    const topics = log => log.topics.map(v => v.toLowerCase())
+
+    const batch = receipt.logs.findIndex(v => topics(v)[0] === WITHDRAWN_BATCH_EVENT_SIG)
+    if (batch !== -1) {
+        return batch
+    }
+
+    const erc1155 = receipt.logs.findIndex(v => (topics(v)[0] === TRANSFER_SINGLE_EVENT_SIG || topics(v)[0] === TRANSFER_BATCH_EVENT_SIG) && topics(v)[3] === ZERO_TOPIC)
+    if (erc1155 !== -1) {
+        return erc1155
+    }
+
+    return receipt.logs.findIndex(v => topics(v)[0] === TRANSFER_EVENT_SIG && topics(v)[2] === ZERO_TOPIC)
+}
+
+// RLP encoded integer, as hex string without `0x` prefix
+const rlpEncodeInt = n => {
+    if (n === 0) {
+        return '80'
+    }
+
+    let hex = n.toString(16)
+    if (hex.length % 2 !== 0) {
+        hex = `0${hex}`
+    }
+
+    return n < 128 ? hex : `${(0x80 + hex.length / 2).toString(16)}${hex}`
+}
+
+// Hash, `RootChainManager` marks exit processed against, computed same way as it does,
+// from block number, nibbles of path of receipt in receipts trie i.e. RLP encoded
+// tx index & index of burn log in receipt
+const exitHash = (receipt, logIndex) => {
+    const nibbles = rlpEncodeInt(receipt.transactionIndex).split('').map(v => `0${v}`).join('')
+
+    return rootWeb3.utils.soliditySha3(
+        { t: 'uint256', v: receipt.blockNumber },
+        { t: 'bytes', v: `0x${nibbles}` },
+        { t: 'uint256', v: logIndex },
+    )
+}
+
+// Checks whether POS withdraw of any of ERC20, ERC721 & ERC1155 ( batch ) tokens,
+// has been exited or not, given burn tx hash
+const isExitProcessed = async txHash => {
+    if (rootChainManager === null) {
+        return client.isERC20ExitProcessed(txHash)
+    }
+
+    const receipt = await childWeb3.eth.getTransactionReceipt(txHash)
+    if (receipt === null) {
+        throw new Error('Burn tx not found')
+    }
+
+    const logIndex = burnLogIndex(receipt)
+    if (logIndex === -1) {
+        throw new Error('Burn log not found')
+    }
+
+    return rootChainManager.methods.processedExits(exitHash(receipt, logIndex)).call()
+}
+
 // POST endpoint to be exposed, which accepts burnTxHash from child chain &
 // checks whether exit has been processed on root chain or not
 app.post('/', (req, res) => {
@@ -48,7 +163,7 @@ app.post('/', (req, res) => {
         return res.status(400).json({ msg: 'Bad Payload' }).end()
     }
 
-    client.isERC20ExitProcessed(req.body.txHash)
+    isExitProcessed(req.body.txHash)
         .then(v => v ? res.status(200).json({ code: 1, msg: 'Exited' }).end() : res.status(200).json({ code: 0, msg: 'Not Exited' }).end())
         .catch(_ => res.status(400).json({ msg: 'Bad Payload' }).end())
 })
@@ -69,6 +184,12 @@ app.post('/exit-time', (req, res) => {
         .catch(_ => res.status(400).json({ msg: 'Bad Payload' }).end())
 })
 
+// Health check endpoint, to be used by bridge API for figuring out
+// whether this service is reachable & ready to serve requests
+app.get('/health', (_, res) => {
+    ready ? res.status(200).json({ healthy: true }).end() : res.status(503).json({ healthy: false, msg: 'Not Ready' }).end()
+})
+
 createServer(app).listen(process.env.PORT || 7003, process.env.HOST || '127.0.0.1', _ => {
     console.log(`[+] Ready to accept requests on :${process.env.PORT}`)
 })
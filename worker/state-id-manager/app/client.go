@@ -8,13 +8,13 @@ import (
 )
 
 // Connects to RPC endpoint on child chain
-func getClient() (*ethclient.Client, error) {
-	return ethclient.Dial(get("RPC"))
+func getClient(config *Config) (*ethclient.Client, error) {
+	return ethclient.Dial(config.RPC)
 }
 
 // Obtains an instance of state receiver contract on child chain
-func getStateReceiver(client *ethclient.Client) *receiver.Receiver {
-	_receiver, err := receiver.NewReceiver(common.HexToAddress(get("StateReceiver")), client)
+func getStateReceiver(config *Config, client *ethclient.Client) *receiver.Receiver {
+	_receiver, err := receiver.NewReceiver(common.HexToAddress(config.StateReceiver), client)
 	if err != nil {
 		return nil
 	}
@@ -0,0 +1,101 @@
+package app
+
+import (
+	"fmt"
+	"logger"
+	"time"
+	"worker-common/index"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Topic of `StateCommitted(uint256,bool)`, emitted by StateReceiver on child chain
+// when ever one state gets committed
+var stateCommittedTopic = common.HexToHash("0x5a22725590b0a51c923940223f7458512164b1113359a735e86e7f27f44791ee")
+
+// Given `StateCommitted` log, builds committed state entry by combining
+// child chain info & Heimdall's record of same state sync
+func toCommittedState(config *Config, _log *types.Log) *CommittedState {
+	id := _log.Topics[1].Big()
+
+	state := &CommittedState{
+		ID:          id.String(),
+		BlockNumber: _log.BlockNumber,
+		TxHash:      _log.TxHash.Hex(),
+		Success:     len(_log.Data) == 32 && _log.Data[31] == 1,
+	}
+
+	record := getEventRecord(config, state.ID)
+	if record == nil {
+		return state
+	}
+
+	state.Contract = record.Result.Contract
+	state.RootTxHash = record.Result.TxHash
+	state.Data = record.Result.Data
+
+	if syncTime, err := time.Parse(time.RFC3339, record.Result.RecordTime); err == nil {
+		state.SyncTime = syncTime.Unix()
+	}
+
+	if data, err := hexutil.Decode(record.Result.Data); err == nil {
+		state.Decoded = decodeSyncData(data)
+	}
+
+	return state
+}
+
+// Puts/ removes state in index, depending upon whether log was
+// removed due to reorg or not
+//
+// Returns error, when state couldn't be indexed, so that block it's in
+// isn't marked as indexed & gets indexed once again
+func indexLog(config *Config, _index *Index, _log *types.Log) error {
+	if len(_log.Topics) < 2 {
+		return nil
+	}
+
+	id := _log.Topics[1].Big()
+
+	if _log.Removed {
+		if err := _index.Remove(id); err != nil {
+			return fmt.Errorf("failed to remove reorged committed state %s : %w", id.String(), err)
+		}
+		return nil
+	}
+
+	if err := _index.put(toCommittedState(config, _log)); err != nil {
+		return fmt.Errorf("failed to index committed state %s : %w", id.String(), err)
+	}
+
+	appLog.Debug("Indexed committed state", logger.Fields{"stateId": id.String(), "txHash": _log.TxHash.Hex()})
+
+	return nil
+}
+
+// This function is supposed to be run in a different thread of execution,
+// which first catches up with chain by querying all past `StateCommitted` logs
+// & then keeps listening for new ones, putting each of them in index, until
+// `done` is closed
+//
+// Returns error, when connection to child chain, catching up, subscription or
+// indexing fails, so that it can be run once again, resuming from last indexed block
+func indexCommittedStates(config *Config, _index *Index, done <-chan struct{}) error {
+	client, err := getClient(config)
+	if err != nil {
+		return fmt.Errorf("failed to connect to child chain : %w", err)
+	}
+
+	// scheduling disconnection
+	defer client.Close()
+
+	return index.Follow(client, _index.Index, ethereum.FilterQuery{
+		Addresses: []common.Address{common.HexToAddress(config.StateReceiver)},
+		Topics:    [][]common.Hash{{stateCommittedTopic}},
+	}, config.StartBlock, appLog, "StateCommitted", func(_log *types.Log) error {
+		return indexLog(config, _index, _log)
+	}, done)
+}
@@ -1,16 +1,86 @@
 package app
 
-import "github.com/spf13/viper"
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"worker-common/config"
 
-// Reading configuration params in memory, during
-// application boot up
-func read(file string) error {
-	viper.SetConfigFile(file)
+	"github.com/ethereum/go-ethereum/common"
+)
 
-	return viper.ReadInConfig()
+// Config - Everything required for tracking `lastStateId` & indexing committed
+// states, to be supplied when using this micro service as library, from another process
+type Config struct {
+	RPC           string
+	StateReceiver string
+	DataDir       string
+	StartBlock    string
+	HeimdallAPI   string
 }
 
-// Retrieves value for specified key
-func get(key string) string {
-	return viper.GetString(key)
+// Configuration params of this micro service, where those required by every
+// worker i.e. `PORT`, `LogLevel` & `LogLevels` are added by `config.New`
+var params = config.New("state-id-manager", appLog, []config.Key{
+	{Name: "RPC", Usage: "Child chain RPC URL"},
+	{Name: "StateReceiver", Usage: "Address of StateReceiver contract"},
+	{Name: "DataDir", Usage: "Directory, index is kept in"},
+	{Name: "StartBlock", Usage: "Child chain block, indexing starts from"},
+	{Name: "HeimdallAPI", Usage: "Heimdall REST API URL"},
+}, func() error {
+	return loadConfig().Validate()
+})
+
+// Builds config from values read from `.env` file, environment variables & flags
+func loadConfig() *Config {
+	return &Config{
+		RPC:           config.Get("RPC"),
+		StateReceiver: config.Get("StateReceiver"),
+		DataDir:       config.Get("DataDir"),
+		StartBlock:    config.Get("StartBlock"),
+		HeimdallAPI:   config.Get("HeimdallAPI"),
+	}
+}
+
+// Validate - Checks whether config is good enough for tracking `lastStateId` & indexing
+// committed states or not
+func (c *Config) Validate() error {
+	problems := make([]string, 0)
+
+	if !config.IsURL(c.RPC, "http", "https", "ws", "wss") {
+		problems = append(problems, fmt.Sprintf("`RPC` must be RPC URL, found `%s`", c.RPC))
+	}
+
+	if !common.IsHexAddress(c.StateReceiver) {
+		problems = append(problems, fmt.Sprintf("`StateReceiver` must be address, found `%s`", c.StateReceiver))
+	}
+
+	if c.DataDir == "" {
+		problems = append(problems, "`DataDir` is required")
+	}
+
+	if c.StartBlock != "" {
+		if _, err := strconv.ParseUint(c.StartBlock, 10, 64); err != nil {
+			problems = append(problems, fmt.Sprintf("`StartBlock` must be block number, found `%s`", c.StartBlock))
+		}
+	}
+
+	if c.HeimdallAPI != "" && !config.IsURL(c.HeimdallAPI, "http", "https") {
+		problems = append(problems, fmt.Sprintf("`HeimdallAPI` must be URL, found `%s`", c.HeimdallAPI))
+	}
+
+	if len(problems) != 0 {
+		return errors.New(strings.Join(problems, "; "))
+	}
+
+	return nil
+}
+
+// CheckConfig - Prints effective configuration, with secrets redacted, followed by
+// problems found in it, if any, given path to config file & command line arguments
+//
+// Exits with non-zero status code, when configuration is not good
+func CheckConfig(file string, args []string) {
+	params.Check(file, args)
 }
@@ -9,3 +9,47 @@ import (
 type LastStateID struct {
 	ID *big.Int
 }
+
+// CommittedState - One state sync, as it landed on child chain, which is
+// kept in local index so that it can be queried using its `stateId`
+//
+// Child chain side information is obtained from `StateCommitted(uint256,bool)`
+// event emitted by StateReceiver, where as `contract`, `data` & `syncTime` are
+// fetched from Heimdall's clerk module
+type CommittedState struct {
+	ID          string    `json:"id"`
+	BlockNumber uint64    `json:"blockNumber"`
+	TxHash      string    `json:"txHash"`
+	Success     bool      `json:"success"`
+	SyncTime    int64     `json:"syncTime"`
+	Contract    string    `json:"contract"`
+	RootTxHash  string    `json:"rootTxHash"`
+	Data        string    `json:"data"`
+	Decoded     *SyncData `json:"decoded,omitempty"`
+}
+
+// SyncData - Decoded form of state sync data, emitted by root chain's
+// StateSender, when it's generated by POS bridge's RootChainManager
+//
+// For other kind of syncs, only `type` will be set to `UNKNOWN`
+type SyncData struct {
+	Type       string `json:"type"`
+	User       string `json:"user,omitempty"`
+	RootToken  string `json:"rootToken,omitempty"`
+	ChildToken string `json:"childToken,omitempty"`
+	TokenType  string `json:"tokenType,omitempty"`
+	Payload    string `json:"payload,omitempty"`
+}
+
+// EventRecord - State sync record as returned by Heimdall's
+// `/clerk/event-record/:id` endpoint
+type EventRecord struct {
+	Result struct {
+		ID         uint64 `json:"id"`
+		Contract   string `json:"contract"`
+		Data       string `json:"data"`
+		TxHash     string `json:"tx_hash"`
+		LogIndex   uint64 `json:"log_index"`
+		RecordTime string `json:"record_time"`
+	} `json:"result"`
+}
@@ -0,0 +1,109 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// Sync types, used by POS bridge's RootChainManager, when sending state
+// to child chain
+var (
+	depositSyncType  = common.HexToHash("0x87a7811f4bfedea3d341ad165680ae306b01aaeacc205d227629cf157dd9f821")
+	mapTokenSyncType = common.HexToHash("0x2cef46a936bdc5b7e6e8c71aa04560c41cf7d88bb26901a7e7f4936ff02accad")
+)
+
+// Fetches state sync record from Heimdall, given `stateId`
+//
+// Returns nil, if `HeimdallAPI` is not configured or record couldn't be fetched
+func getEventRecord(config *Config, id string) *EventRecord {
+	if config.HeimdallAPI == "" {
+		return nil
+	}
+
+	client := http.Client{Timeout: time.Second * time.Duration(10)}
+
+	resp, err := client.Get(fmt.Sprintf("%s/clerk/event-record/%s", strings.TrimSuffix(config.HeimdallAPI, "/"), id))
+	if err != nil {
+		return nil
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return nil
+	}
+
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil
+	}
+
+	var record EventRecord
+	if err := json.Unmarshal(data, &record); err != nil {
+		return nil
+	}
+
+	return &record
+}
+
+// Decodes state sync data, which is of form `abi.encode(bytes32 syncType, bytes syncData)`
+// when sent by RootChainManager
+func decodeSyncData(data []byte) *SyncData {
+	bytes32Type, _ := abi.NewType("bytes32", "", nil)
+	bytesType, _ := abi.NewType("bytes", "", nil)
+	addressType, _ := abi.NewType("address", "", nil)
+
+	unknown := &SyncData{Type: "UNKNOWN"}
+
+	values, err := abi.Arguments{{Type: bytes32Type}, {Type: bytesType}}.UnpackValues(data)
+	if err != nil || len(values) != 2 {
+		return unknown
+	}
+
+	syncType := common.Hash(values[0].([32]byte))
+	syncData := values[1].([]byte)
+
+	switch syncType {
+
+	case depositSyncType:
+
+		// abi.encode(address user, address rootToken, bytes depositData)
+		_values, err := abi.Arguments{{Type: addressType}, {Type: addressType}, {Type: bytesType}}.UnpackValues(syncData)
+		if err != nil || len(_values) != 3 {
+			return unknown
+		}
+
+		return &SyncData{
+			Type:      "DEPOSIT",
+			User:      _values[0].(common.Address).Hex(),
+			RootToken: _values[1].(common.Address).Hex(),
+			Payload:   hexutil.Encode(_values[2].([]byte)),
+		}
+
+	case mapTokenSyncType:
+
+		// abi.encode(address rootToken, address childToken, bytes32 tokenType)
+		_values, err := abi.Arguments{{Type: addressType}, {Type: addressType}, {Type: bytes32Type}}.UnpackValues(syncData)
+		if err != nil || len(_values) != 3 {
+			return unknown
+		}
+
+		return &SyncData{
+			Type:       "MAP_TOKEN",
+			RootToken:  _values[0].(common.Address).Hex(),
+			ChildToken: _values[1].(common.Address).Hex(),
+			TokenType:  common.Hash(_values[2].([32]byte)).Hex(),
+		}
+
+	}
+
+	return unknown
+}
@@ -0,0 +1,67 @@
+package app
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"worker-common/index"
+)
+
+// Maximum number of states to be returned in one range query
+const maxRangeSize = 100
+
+// Returned when `stateId` of committed state can't be parsed
+var errBadStateID = errors.New("bad state id")
+
+// Index - Persistent, on disk index of committed states, keyed by `stateId`,
+// along with last child chain block, which was indexed
+type Index struct {
+	*index.Index
+}
+
+// Opens ( or creates ) leveldb backed index in given directory
+func openIndex(dir string) (*Index, error) {
+	_index, err := index.Open(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Index{Index: _index}, nil
+}
+
+// Persists committed state in index, overwriting if already present
+func (i *Index) put(state *CommittedState) error {
+	id, ok := big.NewInt(0).SetString(state.ID, 10)
+	if !ok {
+		return errBadStateID
+	}
+
+	return i.Put(id, state)
+}
+
+// Looks up committed state by `stateId`, returns nil if not indexed
+func (i *Index) get(id *big.Int) *CommittedState {
+	var state CommittedState
+	if !i.Get(id, &state) {
+		return nil
+	}
+
+	return &state
+}
+
+// Returns all indexed states with `from <= stateId <= to`, in
+// ascending order of `stateId`, capped at `maxRangeSize` entries
+func (i *Index) getRange(from *big.Int, to *big.Int) []*CommittedState {
+	states := make([]*CommittedState, 0)
+
+	for _, v := range i.Range(from, to, maxRangeSize) {
+		var state CommittedState
+		if err := json.Unmarshal(v, &state); err != nil {
+			continue
+		}
+
+		states = append(states, &state)
+	}
+
+	return states
+}
@@ -0,0 +1,17 @@
+package app
+
+import (
+	"logger"
+	"worker-common/config"
+)
+
+// Logger of this micro service, which is also used for logging served requests
+var appLog = logger.New("state-id-manager")
+
+// Sets up log levels, as specified in config file, where `LogLevel` is
+// default level for all components, unless overridden in `LogLevels`
+func setUpLogging() {
+	if err := logger.Configure(config.Get("LogLevel"), config.Get("LogLevels")); err != nil {
+		appLog.Fatal("Failed to configure logging", logger.Fields{"error": err})
+	}
+}
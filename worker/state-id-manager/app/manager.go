@@ -0,0 +1,168 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"logger"
+	"math/big"
+	"sync"
+	"worker-common/index"
+	"worker-common/retry"
+
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/gin-gonic/gin"
+)
+
+// Returned when `lastStateId` hasn't yet been read from chain
+var errNotReady = errors.New("`lastStateId` not yet fetched")
+
+// Manager - Keeps track of `lastStateId` & indexes committed states, which can
+// be either exposed over HTTP ( see `Run` ) or used directly from another process
+type Manager struct {
+	config  *Config
+	index   *Index
+	stateID *LastStateID
+	mutex   *sync.Mutex
+	done    chan struct{}
+	wg      *sync.WaitGroup
+}
+
+// NewManager - Opens index of committed states & creates new manager, which will
+// start tracking only after `Start` is invoked
+func NewManager(config *Config) (*Manager, error) {
+	index, err := openIndex(config.DataDir)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Manager{
+		config: config,
+		index:  index,
+		stateID: &LastStateID{
+			ID: big.NewInt(0),
+		},
+		mutex: &sync.Mutex{},
+		done:  make(chan struct{}),
+		wg:    &sync.WaitGroup{},
+	}, nil
+}
+
+// Start - Starts polling `lastStateId` & indexing committed states,
+// in different threads of execution, each of which is run once again,
+// after backing off, when it fails
+//
+// Latest `lastStateId` is also exposed as metric
+func (m *Manager) Start() {
+	metrics.NewRegisteredFunctionalGauge(collector.Name("last_state_id"), Metrics, func() int64 {
+		m.mutex.Lock()
+		defer m.mutex.Unlock()
+
+		return m.stateID.ID.Int64()
+	})
+
+	m.wg.Add(2)
+	go func() {
+		defer m.wg.Done()
+
+		retry.Forever(m.done, appLog, "poll `lastStateId`", func() error {
+			return getLastStateID(m.config, m.stateID, m.mutex, m.done)
+		})
+	}()
+	go func() {
+		defer m.wg.Done()
+
+		retry.Forever(m.done, appLog, "index committed states", func() error {
+			return indexCommittedStates(m.config, m.index, m.done)
+		})
+	}()
+}
+
+// Stop - Stops polling `lastStateId` & indexing committed states, unsubscribing
+// & disconnecting from child chain, before closing index, returns once it's done
+func (m *Manager) Stop() {
+	close(m.done)
+	m.wg.Wait()
+
+	if err := m.index.Close(); err != nil {
+		appLog.Error("Failed to close index", logger.Fields{"error": err})
+	}
+}
+
+// LastStateID - Latest `lastStateId` read from StateReceiver contract
+func (m *Manager) LastStateID(ctx context.Context) (*big.Int, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if m.stateID.ID.Sign() == 0 {
+		return nil, errNotReady
+	}
+
+	return big.NewInt(0).Set(m.stateID.ID), nil
+}
+
+// State - Looks up committed state by `stateId`, returns nil if not indexed
+func (m *Manager) State(id *big.Int) *CommittedState {
+	return m.index.get(id)
+}
+
+// Routes - Registers HTTP endpoints of this micro service, on given router
+func (m *Manager) Routes(router gin.IRoutes) {
+	router.GET("/", func(c *gin.Context) {
+		m.mutex.Lock()
+		_id := m.stateID.ID.String()
+		m.mutex.Unlock()
+
+		c.JSON(200, gin.H{
+			"id": _id,
+		})
+	})
+
+	// Given `stateId`, responds with details of that state sync,
+	// as it was committed on child chain
+	router.GET("/state/:id", func(c *gin.Context) {
+		id, ok := index.ParseStateID(c.Param("id"))
+		if !ok {
+			c.JSON(400, gin.H{
+				"msg": "Bad State ID",
+			})
+			return
+		}
+
+		state := m.index.get(id)
+		if state == nil {
+			c.JSON(404, gin.H{
+				"msg": "Not Found",
+			})
+			return
+		}
+
+		c.JSON(200, state)
+	})
+
+	// Given `from` & `to` query params, responds with all committed
+	// states in that range ( both inclusive ), ordered by `stateId`
+	//
+	// At max 100 entries are returned, for obtaining more, send another
+	// request with `from` set to last received `stateId` + 1
+	router.GET("/states", func(c *gin.Context) {
+		from, ok := index.ParseStateID(c.Query("from"))
+		if !ok {
+			c.JSON(400, gin.H{
+				"msg": "Bad Range",
+			})
+			return
+		}
+
+		to, ok := index.ParseStateID(c.Query("to"))
+		if !ok || from.Cmp(to) > 0 {
+			c.JSON(400, gin.H{
+				"msg": "Bad Range",
+			})
+			return
+		}
+
+		c.JSON(200, gin.H{
+			"states": m.index.getRange(from, to),
+		})
+	})
+}
@@ -1,43 +1,56 @@
 package app
 
 import (
-	"log"
-	"math/big"
-	"strings"
-	"sync"
+	"logger"
+	"worker-common/server"
 
 	"github.com/gin-gonic/gin"
 )
 
-// Run - REST API runner function, exposing only one
-// GET endpoint, for obtaining, latest `lastStateId`
-// value, which this micro service fetches by talking to
-// StateReceiver contract
-func Run(file string) {
-	err := read(file)
+// Run - REST API runner function, exposing GET endpoint
+// for obtaining, latest `lastStateId` value, which this micro
+// service fetches by talking to StateReceiver contract
+//
+// Along with that, each committed state is indexed & can be
+// queried either by `stateId` or by range of `stateId`(s)
+func Run(file string, args []string) {
+	err := params.Read(file, args)
 	if err != nil {
-		log.Fatalln("[!] ", err)
+		appLog.Fatal("Failed to read config", logger.Fields{"error": err, "file": file})
 		return
 	}
 
-	stateID := &LastStateID{
-		ID: big.NewInt(0),
+	setUpLogging()
+
+	if err := params.Validate(); err != nil {
+		appLog.Fatal("Bad config", logger.Fields{"error": err})
+		return
+	}
+
+	config := loadConfig()
+
+	manager, err := NewManager(config)
+	if err != nil {
+		appLog.Fatal("Failed to open index", logger.Fields{"error": err})
+		return
 	}
-	mutex := &sync.Mutex{}
 
-	go getLastStateID(stateID, mutex)
+	manager.Start()
+
+	router := gin.New()
+	router.Use(gin.Recovery())
 
-	router := gin.Default()
+	// Picking up request ID forwarded by bridge API, while logging
+	// request once served
+	router.Use(logger.Middleware(appLog))
+	router.Use(collector.Middleware())
 
-	router.GET("/", func(c *gin.Context) {
-		mutex.Lock()
-		_id := stateID.ID.String()
-		mutex.Unlock()
+	manager.Routes(router)
 
-		c.JSON(200, gin.H{
-			"id": _id,
-		})
-	})
+	// Exposing collected metrics, to be scraped by Prometheus
+	router.GET("/metrics", collector.Handler())
 
-	router.Run(strings.Join([]string{":", get("PORT")}, ""))
+	server.Serve(appLog, router, func() {
+		params.Reload(setUpLogging)
+	}, manager.Stop)
 }
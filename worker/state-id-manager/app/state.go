@@ -1,7 +1,9 @@
 package app
 
 import (
-	"log"
+	"errors"
+	"fmt"
+	"logger"
 	"sync"
 	"time"
 )
@@ -12,37 +14,47 @@ import (
 //
 // This value can be used by checking whether a certain root chain
 // deposit transaction has successfully been synced in or not
-func getLastStateID(stateID *LastStateID, mutex *sync.Mutex) {
-	client, err := getClient()
+//
+// Keeps doing so until `done` is closed, returns error only when it fails
+// to connect to child chain, so that it can be run once again
+func getLastStateID(config *Config, stateID *LastStateID, mutex *sync.Mutex, done <-chan struct{}) error {
+	client, err := getClient(config)
 	if err != nil {
-		log.Fatalln("[!] ", err)
-		return
+		return fmt.Errorf("failed to connect to child chain : %w", err)
 	}
 
-	receiver := getStateReceiver(client)
+	// scheduling disconnection
+	defer client.Close()
+
+	receiver := getStateReceiver(config, client)
 	if receiver == nil {
-		log.Fatalln("[!] Failed to get instance of StateReceiver")
-		return
+		return errors.New("failed to get instance of StateReceiver")
 	}
 
 	updateStateID := func() {
 		id, err := receiver.LastStateId(nil)
 		if err != nil {
-			log.Println("[!] ", err)
+			appLog.Error("Failed to fetch `lastStateId`", logger.Fields{"error": err})
+			collector.RPCFailure("lastStateId")
 			return
 		}
 
 		mutex.Lock()
 		if stateID.ID.Cmp(id) == -1 {
 			stateID.ID = id
-			log.Println("[+] Updated `lastStateId` : ", stateID.ID.String())
+			appLog.Info("Updated `lastStateId`", logger.Fields{"stateId": stateID.ID.String()})
 		}
 		mutex.Unlock()
 	}
 
 	for {
 		updateStateID()
-		time.Sleep(time.Minute * time.Duration(3))
+
+		select {
+		case <-done:
+			return nil
+		case <-time.After(time.Minute * time.Duration(3)):
+		}
 	}
 
 }
@@ -3,7 +3,14 @@ module state-id-manager
 go 1.13
 
 require (
-	github.com/ethereum/go-ethereum v1.9.22
+	logger v0.0.0
+	worker-common v0.0.0
+	github.com/ethereum/go-ethereum v1.9.23
 	github.com/gin-gonic/gin v1.6.3
 	github.com/spf13/viper v1.7.1
+	github.com/syndtr/goleveldb v1.0.1-0.20200815110645-5c35d600f0ca
 )
+
+replace logger => ../../logger
+
+replace worker-common => ../common
@@ -1,7 +1,8 @@
 package main
 
 import (
-	"log"
+	"logger"
+	"os"
 	"path/filepath"
 	app "state-id-manager/app"
 )
@@ -9,9 +10,15 @@ import (
 func main() {
 	path, err := filepath.Abs(".env")
 	if err != nil {
-		log.Fatalln("[!] ", err)
+		logger.New("state-id-manager").Fatal("Failed to resolve config file path", logger.Fields{"error": err})
 		return
 	}
 
-	app.Run(path)
+	// Printing effective config & problems in it, instead of running service
+	if len(os.Args) > 2 && os.Args[1] == "config" && os.Args[2] == "check" {
+		app.CheckConfig(path, os.Args[3:])
+		return
+	}
+
+	app.Run(path, os.Args[1:])
 }
@@ -137,7 +137,7 @@ func bindReceiver(address common.Address, caller bind.ContractCaller, transactor
 // sets the output to result. The result type might be a single field for simple
 // returns, a slice of interfaces for anonymous returns and a struct for named
 // returns.
-func (_Receiver *ReceiverRaw) Call(opts *bind.CallOpts, result interface{}, method string, params ...interface{}) error {
+func (_Receiver *ReceiverRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
 	return _Receiver.Contract.ReceiverCaller.contract.Call(opts, result, method, params...)
 }
 
@@ -156,7 +156,7 @@ func (_Receiver *ReceiverRaw) Transact(opts *bind.TransactOpts, method string, p
 // sets the output to result. The result type might be a single field for simple
 // returns, a slice of interfaces for anonymous returns and a struct for named
 // returns.
-func (_Receiver *ReceiverCallerRaw) Call(opts *bind.CallOpts, result interface{}, method string, params ...interface{}) error {
+func (_Receiver *ReceiverCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
 	return _Receiver.Contract.contract.Call(opts, result, method, params...)
 }
 
@@ -175,12 +175,17 @@ func (_Receiver *ReceiverTransactorRaw) Transact(opts *bind.TransactOpts, method
 //
 // Solidity: function SYSTEM_ADDRESS() view returns(address)
 func (_Receiver *ReceiverCaller) SYSTEMADDRESS(opts *bind.CallOpts) (common.Address, error) {
-	var (
-		ret0 = new(common.Address)
-	)
-	out := ret0
-	err := _Receiver.contract.Call(opts, out, "SYSTEM_ADDRESS")
-	return *ret0, err
+	var out []interface{}
+	err := _Receiver.contract.Call(opts, &out, "SYSTEM_ADDRESS")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
 }
 
 // SYSTEMADDRESS is a free data retrieval call binding the contract method 0x3434735f.
@@ -201,12 +206,17 @@ func (_Receiver *ReceiverCallerSession) SYSTEMADDRESS() (common.Address, error)
 //
 // Solidity: function lastStateId() view returns(uint256)
 func (_Receiver *ReceiverCaller) LastStateId(opts *bind.CallOpts) (*big.Int, error) {
-	var (
-		ret0 = new(*big.Int)
-	)
-	out := ret0
-	err := _Receiver.contract.Call(opts, out, "lastStateId")
-	return *ret0, err
+	var out []interface{}
+	err := _Receiver.contract.Call(opts, &out, "lastStateId")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
 }
 
 // LastStateId is a free data retrieval call binding the contract method 0x5407ca67.
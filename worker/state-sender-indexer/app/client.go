@@ -0,0 +1,38 @@
+package app
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// Selector of `lastStateId()` function of child chain's StateReceiver contract
+var lastStateIDSelector = hexutil.MustDecode("0x5407ca67")
+
+// Connects to RPC endpoint on root/ child chain
+func getClient(config *Config, isRoot bool) (*ethclient.Client, error) {
+	if isRoot {
+		return ethclient.Dial(config.RootRPC)
+	}
+
+	return ethclient.Dial(config.ChildRPC)
+}
+
+// Reads `lastStateId` from child chain's StateReceiver contract
+func getLastStateID(config *Config, client *ethclient.Client) (*big.Int, error) {
+	receiver := common.HexToAddress(config.StateReceiver)
+
+	data, err := client.CallContract(context.Background(), ethereum.CallMsg{
+		To:   &receiver,
+		Data: lastStateIDSelector,
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return big.NewInt(0).SetBytes(data), nil
+}
@@ -0,0 +1,93 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"worker-common/config"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Config - Everything required for indexing state syncs & tracking backlog, to
+// be supplied when using this micro service as library, from another process
+type Config struct {
+	RootRPC       string
+	ChildRPC      string
+	StateSender   string
+	StateReceiver string
+	DataDir       string
+	StartBlock    string
+}
+
+// Configuration params of this micro service, where those required by every
+// worker i.e. `PORT`, `LogLevel` & `LogLevels` are added by `config.New`
+var params = config.New("state-sender-indexer", appLog, []config.Key{
+	{Name: "RootRPC", Usage: "Root chain RPC URL"},
+	{Name: "ChildRPC", Usage: "Child chain RPC URL"},
+	{Name: "StateSender", Usage: "Address of StateSender contract"},
+	{Name: "StateReceiver", Usage: "Address of StateReceiver contract"},
+	{Name: "DataDir", Usage: "Directory, index is kept in"},
+	{Name: "StartBlock", Usage: "Root chain block, indexing starts from"},
+}, func() error {
+	return loadConfig().Validate()
+})
+
+// Builds config from values read from `.env` file, environment variables & flags
+func loadConfig() *Config {
+	return &Config{
+		RootRPC:       config.Get("RootRPC"),
+		ChildRPC:      config.Get("ChildRPC"),
+		StateSender:   config.Get("StateSender"),
+		StateReceiver: config.Get("StateReceiver"),
+		DataDir:       config.Get("DataDir"),
+		StartBlock:    config.Get("StartBlock"),
+	}
+}
+
+// Validate - Checks whether config is good enough for indexing state syncs & tracking
+// backlog or not
+func (c *Config) Validate() error {
+	problems := make([]string, 0)
+
+	if !config.IsURL(c.RootRPC, "http", "https", "ws", "wss") {
+		problems = append(problems, fmt.Sprintf("`RootRPC` must be RPC URL, found `%s`", c.RootRPC))
+	}
+
+	if !config.IsURL(c.ChildRPC, "http", "https", "ws", "wss") {
+		problems = append(problems, fmt.Sprintf("`ChildRPC` must be RPC URL, found `%s`", c.ChildRPC))
+	}
+
+	if !common.IsHexAddress(c.StateSender) {
+		problems = append(problems, fmt.Sprintf("`StateSender` must be address, found `%s`", c.StateSender))
+	}
+
+	if !common.IsHexAddress(c.StateReceiver) {
+		problems = append(problems, fmt.Sprintf("`StateReceiver` must be address, found `%s`", c.StateReceiver))
+	}
+
+	if c.DataDir == "" {
+		problems = append(problems, "`DataDir` is required")
+	}
+
+	if c.StartBlock != "" {
+		if _, err := strconv.ParseUint(c.StartBlock, 10, 64); err != nil {
+			problems = append(problems, fmt.Sprintf("`StartBlock` must be block number, found `%s`", c.StartBlock))
+		}
+	}
+
+	if len(problems) != 0 {
+		return errors.New(strings.Join(problems, "; "))
+	}
+
+	return nil
+}
+
+// CheckConfig - Prints effective configuration, with secrets redacted, followed by
+// problems found in it, if any, given path to config file & command line arguments
+//
+// Exits with non-zero status code, when configuration is not good
+func CheckConfig(file string, args []string) {
+	params.Check(file, args)
+}
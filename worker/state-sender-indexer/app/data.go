@@ -0,0 +1,34 @@
+package app
+
+import "math/big"
+
+// SyncedState - One `StateSynced(uint256,address,bytes)` event emitted by
+// root chain's StateSender contract, along with time when it was emitted
+type SyncedState struct {
+	ID          string `json:"id"`
+	Contract    string `json:"contract"`
+	Data        string `json:"data"`
+	BlockNumber uint64 `json:"blockNumber"`
+	TxHash      string `json:"txHash"`
+	Timestamp   uint64 `json:"timestamp"`
+}
+
+// Backlog - Shared structure holding latest state id emitted on root chain
+// & latest state id committed on child chain, difference of which is number
+// of states yet to be synced by Heimdall's bridge
+type Backlog struct {
+	LatestID     *big.Int
+	LastSyncedID *big.Int
+}
+
+// BacklogStatus - Current state sync backlog, as responded to client
+//
+// `oldestPending*` fields are set only when backlog is non-zero
+type BacklogStatus struct {
+	LatestID            string `json:"latestId"`
+	LastSyncedID        string `json:"lastSyncedId"`
+	Backlog             string `json:"backlog"`
+	OldestPendingID     string `json:"oldestPendingId,omitempty"`
+	OldestPendingTxHash string `json:"oldestPendingTxHash,omitempty"`
+	OldestPendingAge    uint64 `json:"oldestPendingAge,omitempty"`
+}
@@ -0,0 +1,46 @@
+package app
+
+import (
+	"errors"
+	"math/big"
+	"worker-common/index"
+)
+
+// Returned when `stateId` of synced state can't be parsed
+var errBadStateID = errors.New("bad state id")
+
+// Index - Persistent, on disk index of states synced from root chain, keyed by `stateId`,
+// along with last root chain block, which was indexed
+type Index struct {
+	*index.Index
+}
+
+// Opens ( or creates ) leveldb backed index in given directory
+func openIndex(dir string) (*Index, error) {
+	_index, err := index.Open(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Index{Index: _index}, nil
+}
+
+// Persists synced state in index, overwriting if already present
+func (i *Index) put(state *SyncedState) error {
+	id, ok := big.NewInt(0).SetString(state.ID, 10)
+	if !ok {
+		return errBadStateID
+	}
+
+	return i.Put(id, state)
+}
+
+// Looks up synced state by `stateId`, returns nil if not indexed
+func (i *Index) get(id *big.Int) *SyncedState {
+	var state SyncedState
+	if !i.Get(id, &state) {
+		return nil
+	}
+
+	return &state
+}
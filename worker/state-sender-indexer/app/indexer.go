@@ -0,0 +1,174 @@
+package app
+
+import (
+	"logger"
+	"math/big"
+	"sync"
+	"time"
+	"worker-common/index"
+	"worker-common/retry"
+
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/gin-gonic/gin"
+)
+
+// Indexer - Indexes state syncs on root chain & keeps track of backlog, which can
+// be either exposed over HTTP ( see `Run` ) or used directly from another process
+type Indexer struct {
+	config  *Config
+	index   *Index
+	backlog *Backlog
+	mutex   *sync.Mutex
+	done    chan struct{}
+	wg      *sync.WaitGroup
+}
+
+// NewIndexer - Opens index of synced states & creates new indexer, which will
+// start indexing only after `Start` is invoked
+func NewIndexer(config *Config) (*Indexer, error) {
+	index, err := openIndex(config.DataDir)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Indexer{
+		config: config,
+		index:  index,
+		backlog: &Backlog{
+			LatestID:     index.LatestID(),
+			LastSyncedID: big.NewInt(0),
+		},
+		mutex: &sync.Mutex{},
+		done:  make(chan struct{}),
+		wg:    &sync.WaitGroup{},
+	}, nil
+}
+
+// Start - Starts indexing state syncs & polling `lastStateId`, in different
+// threads of execution, each of which is run once again, after backing off,
+// when it fails
+//
+// Backlog is also exposed as metrics
+func (i *Indexer) Start() {
+	metrics.NewRegisteredFunctionalGauge(collector.Name("latest_state_id"), Metrics, func() int64 {
+		i.mutex.Lock()
+		defer i.mutex.Unlock()
+
+		return i.backlog.LatestID.Int64()
+	})
+	metrics.NewRegisteredFunctionalGauge(collector.Name("last_synced_state_id"), Metrics, func() int64 {
+		i.mutex.Lock()
+		defer i.mutex.Unlock()
+
+		return i.backlog.LastSyncedID.Int64()
+	})
+	metrics.NewRegisteredFunctionalGauge(collector.Name("backlog"), Metrics, func() int64 {
+		i.mutex.Lock()
+		defer i.mutex.Unlock()
+
+		if i.backlog.LatestID.Cmp(i.backlog.LastSyncedID) <= 0 {
+			return 0
+		}
+
+		return big.NewInt(0).Sub(i.backlog.LatestID, i.backlog.LastSyncedID).Int64()
+	})
+
+	i.wg.Add(2)
+	go func() {
+		defer i.wg.Done()
+
+		retry.Forever(i.done, appLog, "index state syncs", func() error {
+			return indexSyncedStates(i.config, i.index, i.backlog, i.mutex, i.done)
+		})
+	}()
+	go func() {
+		defer i.wg.Done()
+
+		retry.Forever(i.done, appLog, "poll `lastStateId`", func() error {
+			return trackLastStateID(i.config, i.backlog, i.mutex, i.done)
+		})
+	}()
+}
+
+// Stop - Stops indexing state syncs & polling `lastStateId`, unsubscribing &
+// disconnecting from chains, before closing index, returns once it's done
+func (i *Indexer) Stop() {
+	close(i.done)
+	i.wg.Wait()
+
+	if err := i.index.Close(); err != nil {
+		appLog.Error("Failed to close index", logger.Fields{"error": err})
+	}
+}
+
+// Status - Computes current state sync backlog i.e. how many states are
+// yet to be synced & since when oldest of them is waiting
+func (i *Indexer) Status() *BacklogStatus {
+	i.mutex.Lock()
+	latest := big.NewInt(0).Set(i.backlog.LatestID)
+	lastSynced := big.NewInt(0).Set(i.backlog.LastSyncedID)
+	i.mutex.Unlock()
+
+	size := big.NewInt(0)
+	if latest.Cmp(lastSynced) > 0 {
+		size.Sub(latest, lastSynced)
+	}
+
+	status := &BacklogStatus{
+		LatestID:     latest.String(),
+		LastSyncedID: lastSynced.String(),
+		Backlog:      size.String(),
+	}
+
+	// Nothing pending, no need to look up oldest one
+	if size.Sign() == 0 {
+		return status
+	}
+
+	oldest := big.NewInt(0).Add(lastSynced, big.NewInt(1))
+	status.OldestPendingID = oldest.String()
+
+	// Age can be computed only when oldest pending state has been indexed
+	if state := i.index.get(oldest); state != nil {
+		status.OldestPendingTxHash = state.TxHash
+		status.OldestPendingAge = uint64(time.Now().Unix()) - state.Timestamp
+	}
+
+	return status
+}
+
+// State - Looks up synced state by `stateId`, returns nil if not indexed
+func (i *Indexer) State(id *big.Int) *SyncedState {
+	return i.index.get(id)
+}
+
+// Routes - Registers HTTP endpoints of this micro service, on given router
+func (i *Indexer) Routes(router gin.IRoutes) {
+	// GET endpoint for obtaining current state sync backlog i.e. how many
+	// states are yet to be synced & since when oldest of them is waiting
+	router.GET("/", func(c *gin.Context) {
+		c.JSON(200, i.Status())
+	})
+
+	// Given `stateId`, responds with `StateSynced` event details, as
+	// emitted on root chain
+	router.GET("/state/:id", func(c *gin.Context) {
+		id, ok := index.ParseStateID(c.Param("id"))
+		if !ok {
+			c.JSON(400, gin.H{
+				"msg": "Bad State ID",
+			})
+			return
+		}
+
+		state := i.State(id)
+		if state == nil {
+			c.JSON(404, gin.H{
+				"msg": "Not Found",
+			})
+			return
+		}
+
+		c.JSON(200, state)
+	})
+}
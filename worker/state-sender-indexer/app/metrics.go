@@ -0,0 +1,10 @@
+package app
+
+import "worker-common/metrics"
+
+// Metrics of this worker, named under `state_sender_indexer/`
+var collector = metrics.New("state_sender_indexer")
+
+// Metrics - All metrics collected by this worker, kept in own registry so
+// that metrics of geth packages, we depend on, don't show up
+var Metrics = collector.Registry
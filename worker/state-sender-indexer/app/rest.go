@@ -0,0 +1,55 @@
+package app
+
+import (
+	"logger"
+	"worker-common/server"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Run - Exposing REST API for querying how many states emitted on root chain
+// are yet to be synced into child chain, along with how long oldest of them
+// has been waiting
+//
+// Each `StateSynced` event is indexed, so that it can also be looked up by `stateId`
+func Run(file string, args []string) {
+	err := params.Read(file, args)
+	if err != nil {
+		appLog.Fatal("Failed to read config", logger.Fields{"error": err, "file": file})
+		return
+	}
+
+	setUpLogging()
+
+	if err := params.Validate(); err != nil {
+		appLog.Fatal("Bad config", logger.Fields{"error": err})
+		return
+	}
+
+	config := loadConfig()
+
+	indexer, err := NewIndexer(config)
+	if err != nil {
+		appLog.Fatal("Failed to open index", logger.Fields{"error": err})
+		return
+	}
+
+	indexer.Start()
+
+	router := gin.New()
+	router.Use(gin.Recovery())
+
+	// Picking up request ID forwarded by bridge API, while logging
+	// request once served
+	router.Use(logger.Middleware(appLog))
+	router.Use(collector.Middleware())
+
+	indexer.Routes(router)
+
+	// Exposing collected metrics, to be scraped by Prometheus
+	router.GET("/metrics", collector.Handler())
+
+	server.Serve(appLog, router, func() {
+		params.Reload(setUpLogging)
+	}, indexer.Stop)
+}
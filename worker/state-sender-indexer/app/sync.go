@@ -0,0 +1,149 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"logger"
+	"math/big"
+	"sync"
+	"time"
+	"worker-common/index"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// Topic of `StateSynced(uint256,address,bytes)`, emitted by StateSender on root chain
+// when ever some state is sent to child chain
+var stateSyncedTopic = common.HexToHash("0x103fed9db65eac19c4d870f49ab7520fe03b99f1838e5996caf47e9e43308392")
+
+// Decodes non-indexed part of `StateSynced` log i.e. `bytes data`
+func decodeStateSyncedData(data []byte) []byte {
+	if len(data) < 64 {
+		return nil
+	}
+
+	size := big.NewInt(0).SetBytes(data[32:64]).Uint64()
+	if uint64(len(data)) < 64+size {
+		return nil
+	}
+
+	return data[64 : 64+size]
+}
+
+// Puts/ removes state in index, depending upon whether log was
+// removed due to reorg or not, along with updating latest state id seen
+//
+// Returns error, when state couldn't be indexed, so that block it's in
+// isn't marked as indexed & gets indexed once again
+func indexLog(client *ethclient.Client, _index *Index, _log *types.Log, backlog *Backlog, mutex *sync.Mutex) error {
+	if len(_log.Topics) < 3 {
+		return nil
+	}
+
+	id := _log.Topics[1].Big()
+
+	if _log.Removed {
+		if err := _index.Remove(id); err != nil {
+			return fmt.Errorf("failed to remove reorged synced state %s : %w", id.String(), err)
+		}
+		return nil
+	}
+
+	header, err := client.HeaderByHash(context.Background(), _log.BlockHash)
+	if err != nil {
+		return fmt.Errorf("failed to fetch block header of synced state %s : %w", id.String(), err)
+	}
+
+	if err := _index.put(&SyncedState{
+		ID:          id.String(),
+		Contract:    common.BytesToAddress(_log.Topics[2].Bytes()).Hex(),
+		Data:        hexutil.Encode(decodeStateSyncedData(_log.Data)),
+		BlockNumber: _log.BlockNumber,
+		TxHash:      _log.TxHash.Hex(),
+		Timestamp:   header.Time,
+	}); err != nil {
+		return fmt.Errorf("failed to index synced state %s : %w", id.String(), err)
+	}
+
+	// -- Critical section of code, acquiring exclusive lock
+	mutex.Lock()
+	if backlog.LatestID.Cmp(id) == -1 {
+		backlog.LatestID = id
+	}
+	mutex.Unlock()
+	// -- ends here, releasing lock
+
+	appLog.Debug("Indexed synced state", logger.Fields{"stateId": id.String(), "txHash": _log.TxHash.Hex()})
+
+	return nil
+}
+
+// This function is supposed to be run in a different thread of execution,
+// which first catches up with root chain by querying all past `StateSynced` logs
+// & then keeps listening for new ones, putting each of them in index, until
+// `done` is closed
+//
+// Returns error, when connection to root chain, catching up, subscription or
+// indexing fails, so that it can be run once again, resuming from last indexed block
+func indexSyncedStates(config *Config, _index *Index, backlog *Backlog, mutex *sync.Mutex, done <-chan struct{}) error {
+	client, err := getClient(config, true)
+	if err != nil {
+		return fmt.Errorf("failed to connect to root chain : %w", err)
+	}
+
+	// scheduling disconnection
+	defer client.Close()
+
+	return index.Follow(client, _index.Index, ethereum.FilterQuery{
+		Addresses: []common.Address{common.HexToAddress(config.StateSender)},
+		Topics:    [][]common.Hash{{stateSyncedTopic}},
+	}, config.StartBlock, appLog, "StateSynced", func(_log *types.Log) error {
+		return indexLog(client, _index, _log, backlog, mutex)
+	}, done)
+}
+
+// This function is supposed to be run in a different thread of
+// execution, which will wake up every minute & query child chain's
+// StateReceiver contract, to get latest `lastStateId` value, until `done`
+// is closed, returns error only when it fails to connect to child chain,
+// so that it can be run once again
+func trackLastStateID(config *Config, backlog *Backlog, mutex *sync.Mutex, done <-chan struct{}) error {
+	client, err := getClient(config, false)
+	if err != nil {
+		return fmt.Errorf("failed to connect to child chain : %w", err)
+	}
+
+	// scheduling disconnection
+	defer client.Close()
+
+	updateStateID := func() {
+		id, err := getLastStateID(config, client)
+		if err != nil {
+			appLog.Error("Failed to fetch `lastStateId`", logger.Fields{"error": err})
+			collector.RPCFailure("lastStateId")
+			return
+		}
+
+		mutex.Lock()
+		if backlog.LastSyncedID.Cmp(id) == -1 {
+			backlog.LastSyncedID = id
+			appLog.Info("Updated `lastStateId`", logger.Fields{"stateId": id.String()})
+		}
+		mutex.Unlock()
+	}
+
+	for {
+		updateStateID()
+
+		select {
+		case <-done:
+			return nil
+		case <-time.After(time.Minute):
+		}
+	}
+
+}
@@ -0,0 +1,24 @@
+package main
+
+import (
+	"logger"
+	"os"
+	"path/filepath"
+	app "state-sender-indexer/app"
+)
+
+func main() {
+	path, err := filepath.Abs(".env")
+	if err != nil {
+		logger.New("state-sender-indexer").Fatal("Failed to resolve config file path", logger.Fields{"error": err})
+		return
+	}
+
+	// Printing effective config & problems in it, instead of running service
+	if len(os.Args) > 2 && os.Args[1] == "config" && os.Args[2] == "check" {
+		app.CheckConfig(path, os.Args[3:])
+		return
+	}
+
+	app.Run(path, os.Args[1:])
+}